@@ -0,0 +1,306 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package assembler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// Lookup resolves a symbol to a value. The bool return value is false if the
+// symbol is not known.
+type Lookup func(symbol string) (uint16, bool)
+
+// the operand syntax before the choice between zero page and absolute
+// addressing has been made.
+type syntax int
+
+const (
+	synImplied syntax = iota
+	synImmediate
+	synDirect
+	synIndexedX
+	synIndexedY
+	synIndirect
+	synIndexedIndirect
+	synIndirectIndexed
+)
+
+// Assemble the instruction as though it was located at address. The address
+// is only important for branch instructions. The lookup function can be nil
+// if symbols are not required.
+//
+// Returns the bytes of the instruction, starting with the opcode.
+func Assemble(address uint16, instruction string, lookup Lookup) ([]uint8, error) {
+	instruction = strings.TrimSpace(instruction)
+	if instruction == "" {
+		return nil, curated.Errorf("assembler: no instruction")
+	}
+
+	var mnemonic, operand string
+	if i := strings.IndexAny(instruction, " \t"); i >= 0 {
+		mnemonic = instruction[:i]
+		operand = strings.Join(strings.Fields(instruction[i:]), "")
+	} else {
+		mnemonic = instruction
+	}
+
+	candidates := definitions(mnemonic)
+	if len(candidates) == 0 {
+		return nil, curated.Errorf("assembler: unknown mnemonic (%s)", mnemonic)
+	}
+
+	syn, expr, err := parseOperand(operand)
+	if err != nil {
+		return nil, err
+	}
+
+	var value uint16
+	if syn != synImplied {
+		value, err = evaluate(expr, lookup)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// branch instructions have one addressing mode and it's always relative
+	if candidates[0].IsBranch() {
+		if syn != synDirect {
+			return nil, curated.Errorf("assembler: branch instructions require an address (%s)", instruction)
+		}
+		// cartridge addresses can be expressed using any of the cartridge
+		// mirrors. symbols are usually resolved to the $1000 mirror while the
+		// address of the instruction might be $f000 (or vice versa). compare
+		// the two addresses in the same mirror
+		next := address + 2
+		if isCartridge(value) && isCartridge(next) {
+			value &= memorymap.CartridgeBits
+			next &= memorymap.CartridgeBits
+		}
+
+		offset := int(value) - int(next)
+		if offset < -128 || offset > 127 {
+			return nil, curated.Errorf("assembler: branch out of range (%s)", instruction)
+		}
+		return []uint8{candidates[0].OpCode, uint8(offset)}, nil
+	}
+
+	// addressing modes to try for the operand syntax, in order of preference
+	var modes []instructions.AddressingMode
+	switch syn {
+	case synImplied:
+		modes = []instructions.AddressingMode{instructions.Implied}
+	case synImmediate:
+		if value > 0xff {
+			return nil, curated.Errorf("assembler: immediate value too large (%s)", instruction)
+		}
+		modes = []instructions.AddressingMode{instructions.Immediate}
+	case synDirect:
+		modes = []instructions.AddressingMode{instructions.Absolute}
+		if value <= 0xff {
+			modes = []instructions.AddressingMode{instructions.ZeroPage, instructions.Absolute}
+		}
+	case synIndexedX:
+		modes = []instructions.AddressingMode{instructions.AbsoluteIndexedX}
+		if value <= 0xff {
+			modes = []instructions.AddressingMode{instructions.ZeroPageIndexedX, instructions.AbsoluteIndexedX}
+		}
+	case synIndexedY:
+		modes = []instructions.AddressingMode{instructions.AbsoluteIndexedY}
+		if value <= 0xff {
+			modes = []instructions.AddressingMode{instructions.ZeroPageIndexedY, instructions.AbsoluteIndexedY}
+		}
+	case synIndirect:
+		modes = []instructions.AddressingMode{instructions.Indirect}
+	case synIndexedIndirect:
+		modes = []instructions.AddressingMode{instructions.IndexedIndirect}
+	case synIndirectIndexed:
+		modes = []instructions.AddressingMode{instructions.IndirectIndexed}
+	}
+
+	for _, m := range modes {
+		for _, defn := range candidates {
+			if defn.AddressingMode != m {
+				continue // for loop
+			}
+
+			switch defn.Bytes {
+			case 1:
+				return []uint8{defn.OpCode}, nil
+			case 2:
+				if value > 0xff {
+					return nil, curated.Errorf("assembler: operand too large (%s)", instruction)
+				}
+				return []uint8{defn.OpCode, uint8(value)}, nil
+			case 3:
+				return []uint8{defn.OpCode, uint8(value), uint8(value >> 8)}, nil
+			}
+		}
+	}
+
+	return nil, curated.Errorf("assembler: addressing mode not supported by %s (%s)", strings.ToUpper(mnemonic), instruction)
+}
+
+// returns true if address is in cartridge space.
+func isCartridge(address uint16) bool {
+	return address&memorymap.OriginCart == memorymap.OriginCart
+}
+
+// the definitions matching the mnemonic. documented instructions are matched
+// regardless of case but undocumented instructions must be lower case. this
+// means that an upper case NOP is always the documented instruction.
+func definitions(mnemonic string) []*instructions.Definition {
+	candidates := make([]*instructions.Definition, 0)
+
+	upper := strings.ToUpper(mnemonic)
+	for _, defn := range instructions.GetDefinitions() {
+		if defn != nil && defn.Mnemonic == upper {
+			candidates = append(candidates, defn)
+		}
+	}
+
+	if mnemonic == strings.ToLower(mnemonic) {
+		for _, defn := range instructions.GetDefinitions() {
+			if defn != nil && defn.Mnemonic == mnemonic {
+				candidates = append(candidates, defn)
+			}
+		}
+	}
+
+	return candidates
+}
+
+// split operand into the syntax and the expression. whitespace has been
+// removed from the operand by the caller.
+func parseOperand(operand string) (syntax, string, error) {
+	u := strings.ToUpper(operand)
+
+	switch {
+	case u == "" || u == "A":
+		return synImplied, "", nil
+	case strings.HasPrefix(u, "#"):
+		return synImmediate, operand[1:], nil
+	case strings.HasPrefix(u, "(") && strings.HasSuffix(u, ",X)"):
+		return synIndexedIndirect, operand[1 : len(operand)-3], nil
+	case strings.HasPrefix(u, "(") && strings.HasSuffix(u, "),Y"):
+		return synIndirectIndexed, operand[1 : len(operand)-3], nil
+	case strings.HasPrefix(u, "(") && strings.HasSuffix(u, ")"):
+		return synIndirect, operand[1 : len(operand)-1], nil
+	case strings.HasSuffix(u, ",X"):
+		return synIndexedX, operand[:len(operand)-2], nil
+	case strings.HasSuffix(u, ",Y"):
+		return synIndexedY, operand[:len(operand)-2], nil
+	case strings.ContainsAny(u, "(),#"):
+		return 0, "", curated.Errorf("assembler: unrecognised operand (%s)", operand)
+	}
+
+	return synDirect, operand, nil
+}
+
+// evaluate expression. an expression is a number or symbol, optionally offset
+// by a number or symbol, and optionally prefixed with < or > to take the low or
+// high byte of the result.
+func evaluate(expr string, lookup Lookup) (uint16, error) {
+	if expr == "" {
+		return 0, curated.Errorf("assembler: missing operand")
+	}
+
+	var lo, hi bool
+	switch expr[0] {
+	case '<':
+		lo = true
+		expr = expr[1:]
+	case '>':
+		hi = true
+		expr = expr[1:]
+	}
+
+	if expr == "" {
+		return 0, curated.Errorf("assembler: missing operand")
+	}
+
+	// look for an offset. the search starts at index one so that a leading
+	// sign is treated as part of the value
+	var value uint16
+	if i := strings.IndexAny(expr[1:], "+-"); i >= 0 {
+		i++
+		a, err := term(expr[:i], lookup)
+		if err != nil {
+			return 0, err
+		}
+		b, err := term(expr[i+1:], lookup)
+		if err != nil {
+			return 0, err
+		}
+		if expr[i] == '+' {
+			value = a + b
+		} else {
+			value = a - b
+		}
+	} else {
+		v, err := term(expr, lookup)
+		if err != nil {
+			return 0, err
+		}
+		value = v
+	}
+
+	if lo {
+		value &= 0x00ff
+	} else if hi {
+		value >>= 8
+	}
+
+	return value, nil
+}
+
+// a single number or symbol.
+func term(s string, lookup Lookup) (uint16, error) {
+	if s == "" {
+		return 0, curated.Errorf("assembler: missing value")
+	}
+
+	var v uint64
+	var err error
+
+	switch {
+	case strings.HasPrefix(s, "$"):
+		v, err = strconv.ParseUint(s[1:], 16, 16)
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		v, err = strconv.ParseUint(s[2:], 16, 16)
+	case strings.HasPrefix(s, "%"):
+		v, err = strconv.ParseUint(s[1:], 2, 16)
+	case s[0] >= '0' && s[0] <= '9':
+		v, err = strconv.ParseUint(s, 10, 16)
+	default:
+		if lookup != nil {
+			if a, ok := lookup(s); ok {
+				return a, nil
+			}
+		}
+		return 0, curated.Errorf("assembler: unknown symbol (%s)", s)
+	}
+
+	if err != nil {
+		return 0, curated.Errorf("assembler: invalid value (%s)", s)
+	}
+
+	return uint16(v), nil
+}
@@ -0,0 +1,107 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package assembler_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+)
+
+func TestAssemble(t *testing.T) {
+	lookup := func(symbol string) (uint16, bool) {
+		switch symbol {
+		case "WSYNC":
+			return 0x02, true
+		case "kernel":
+			return 0xf010, true
+		case "loop":
+			// symbols from the symbols file are mapped to the $1000 mirror
+			return 0x1008, true
+		case "ramcode":
+			return 0x0090, true
+		}
+		return 0, false
+	}
+
+	tests := []struct {
+		address     uint16
+		instruction string
+		expected    []uint8
+	}{
+		{0xf000, "NOP", []uint8{0xea}},
+		{0xf000, "asl a", []uint8{0x0a}},
+		{0xf000, "LDA #$10", []uint8{0xa9, 0x10}},
+		{0xf000, "LDA #%00000011", []uint8{0xa9, 0x03}},
+		{0xf000, "LDA #<kernel", []uint8{0xa9, 0x10}},
+		{0xf000, "LDA #>kernel", []uint8{0xa9, 0xf0}},
+		{0xf000, "LDA $80", []uint8{0xa5, 0x80}},
+		{0xf000, "LDA $1080", []uint8{0xad, 0x80, 0x10}},
+		{0xf000, "LDA $80,X", []uint8{0xb5, 0x80}},
+		{0xf000, "LDA $80, Y", []uint8{0xb9, 0x80, 0x00}},
+		{0xf000, "LDX $80,Y", []uint8{0xb6, 0x80}},
+		{0xf000, "LDA ($80,X)", []uint8{0xa1, 0x80}},
+		{0xf000, "LDA ($80),Y", []uint8{0xb1, 0x80}},
+		{0xf000, "JMP ($fffc)", []uint8{0x6c, 0xfc, 0xff}},
+		{0xf000, "STA WSYNC", []uint8{0x85, 0x02}},
+		{0xf000, "JSR kernel+3", []uint8{0x20, 0x13, 0xf0}},
+		{0xf000, "BNE kernel", []uint8{0xd0, 0x0e}},
+		{0xf010, "BEQ $f000", []uint8{0xf0, 0xee}},
+		{0xf010, "BNE loop", []uint8{0xd0, 0xf6}},
+		{0x1010, "BNE loop", []uint8{0xd0, 0xf6}},
+		{0xd000, "BCC loop", []uint8{0x90, 0x06}},
+		{0x0080, "BPL ramcode", []uint8{0x10, 0x0e}},
+		{0xf000, "nop $80", []uint8{0x04, 0x80}},
+		{0xf000, "lax $80", []uint8{0xa7, 0x80}},
+	}
+
+	for _, tst := range tests {
+		b, err := assembler.Assemble(tst.address, tst.instruction, lookup)
+		if err != nil {
+			t.Errorf("unexpected error for %s (%s)", tst.instruction, err)
+			continue // for loop
+		}
+		if !bytes.Equal(b, tst.expected) {
+			t.Errorf("unexpected bytes for %s (% 02x)", tst.instruction, b)
+		}
+	}
+}
+
+func TestAssembleErrors(t *testing.T) {
+	errs := []string{
+		"",
+		"XYZ",
+		"LAX $80",
+		"LDA #$100",
+		"LDA unknown",
+		"STA #$10",
+		"JMP ($80,X)",
+		"BNE $f100",
+		"LDA ($80",
+	}
+
+	for _, s := range errs {
+		if _, err := assembler.Assemble(0xf000, s, nil); err == nil {
+			t.Errorf("expected error for %s", s)
+		}
+	}
+
+	// a branch from RAM to cartridge space is never in range
+	if _, err := assembler.Assemble(0x0080, "BNE $f000", nil); err == nil {
+		t.Errorf("expected error for branch from RAM to cartridge")
+	}
+}
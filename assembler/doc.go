@@ -0,0 +1,43 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package assembler converts single 6502 instructions, written in the usual
+// assembly language syntax, to machine code. It is intended for patching
+// cartridges from the debugger rather than for assembling entire programs.
+//
+// Opcodes and addressing modes are taken from the instruction definitions in
+// the hardware/cpu/instructions package. The supported operand syntax is:
+//
+//	implied		NOP
+//	accumulator	ASL A
+//	immediate	LDA #$10
+//	zero page	LDA $80
+//	absolute	LDA $1080
+//	indexed		LDA $80,X    LDA $1080,Y
+//	indirect	JMP ($1080)
+//	(ind,X)		LDA ($80,X)
+//	(ind),Y		LDA ($80),Y
+//	relative	BNE $f010
+//
+// Numbers can be hexadecimal ($ or 0x prefix), binary (% prefix) or decimal.
+// Symbols are resolved with the Lookup function supplied to Assemble().
+// Values can be offset with a single + or - and, for immediate values, the
+// low or high byte can be taken with the < and > prefixes.
+//
+// Zero page addressing is preferred to absolute addressing when the value is
+// less than 256 and the instruction supports it. Undocumented instructions
+// are accepted when written with lower case mnemonics, following the
+// convention of the instructions package.
+package assembler
@@ -0,0 +1,190 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package clipwriter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+// the PNG file signature.
+var pngSignature = []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a}
+
+// a single chunk from a PNG file.
+type pngChunk struct {
+	typ  string
+	data []byte
+}
+
+// split a PNG file into its chunks.
+func pngChunks(b []byte) ([]pngChunk, error) {
+	if !bytes.HasPrefix(b, pngSignature) {
+		return nil, fmt.Errorf("not a png file")
+	}
+	b = b[len(pngSignature):]
+
+	var chunks []pngChunk
+	for len(b) >= 12 {
+		l := int(binary.BigEndian.Uint32(b))
+		if len(b) < l+12 {
+			return nil, fmt.Errorf("truncated png chunk")
+		}
+		chunks = append(chunks, pngChunk{typ: string(b[4:8]), data: b[8 : 8+l]})
+		b = b[l+12:]
+	}
+
+	return chunks, nil
+}
+
+func writeChunk(w io.Writer, typ string, data []byte) error {
+	var l [4]byte
+	binary.BigEndian.PutUint32(l[:], uint32(len(data)))
+	if _, err := w.Write(l[:]); err != nil {
+		return err
+	}
+
+	crc := crc32.NewIEEE()
+	_, _ = crc.Write([]byte(typ))
+	_, _ = crc.Write(data)
+
+	if _, err := w.Write([]byte(typ)); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+
+	var c [4]byte
+	binary.BigEndian.PutUint32(c[:], crc.Sum32())
+	_, err := w.Write(c[:])
+	return err
+}
+
+// saveAPNG writes the frames as an animated PNG. the standard library does not
+// support APNG so each frame is encoded as a PNG file and the image data is
+// copied into the APNG file.
+func (clip *Clip) saveAPNG(w io.Writer, frames []*image.Paletted) error {
+	// an APNG file can have only one palette. if the palette changes during
+	// the clip then the frames are saved as RGB images
+	var imgs []image.Image
+	if samePalette(frames) {
+		for _, f := range frames {
+			imgs = append(imgs, f)
+		}
+	} else {
+		for _, f := range frames {
+			rgba := image.NewRGBA(f.Bounds())
+			draw.Draw(rgba, rgba.Bounds(), f, image.Point{}, draw.Src)
+			imgs = append(imgs, rgba)
+		}
+	}
+
+	// delay for each frame as a fraction. both values are 16 bit
+	delayNum := uint16(100)
+	delayDen := uint16(math.Round(float64(clip.fps) * 100))
+
+	if _, err := w.Write(pngSignature); err != nil {
+		return err
+	}
+
+	// sequence number for fcTL and fdAT chunks
+	var seq uint32
+
+	b := &bytes.Buffer{}
+	for i, img := range imgs {
+		b.Reset()
+		err := png.Encode(b, img)
+		if err != nil {
+			return err
+		}
+
+		chunks, err := pngChunks(b.Bytes())
+		if err != nil {
+			return err
+		}
+
+		// the header chunks are taken from the first frame
+		if i == 0 {
+			for _, c := range chunks {
+				if c.typ == "IHDR" {
+					if err := writeChunk(w, c.typ, c.data); err != nil {
+						return err
+					}
+
+					actl := make([]byte, 8)
+					binary.BigEndian.PutUint32(actl[0:], uint32(len(imgs)))
+					binary.BigEndian.PutUint32(actl[4:], 0)
+					if err := writeChunk(w, "acTL", actl); err != nil {
+						return err
+					}
+				}
+				if c.typ == "PLTE" || c.typ == "tRNS" {
+					if err := writeChunk(w, c.typ, c.data); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		bounds := img.Bounds()
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:], seq)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(bounds.Dx()))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(bounds.Dy()))
+		binary.BigEndian.PutUint32(fctl[12:], 0)
+		binary.BigEndian.PutUint32(fctl[16:], 0)
+		binary.BigEndian.PutUint16(fctl[20:], delayNum)
+		binary.BigEndian.PutUint16(fctl[22:], delayDen)
+		fctl[24] = 0 // dispose op: none
+		fctl[25] = 0 // blend op: source
+		if err := writeChunk(w, "fcTL", fctl); err != nil {
+			return err
+		}
+		seq++
+
+		for _, c := range chunks {
+			if c.typ != "IDAT" {
+				continue
+			}
+
+			// the first frame uses IDAT chunks. the image data for subsequent
+			// frames is in fdAT chunks, which are prefixed with a sequence
+			// number
+			if i == 0 {
+				if err := writeChunk(w, "IDAT", c.data); err != nil {
+					return err
+				}
+			} else {
+				fdat := make([]byte, 4+len(c.data))
+				binary.BigEndian.PutUint32(fdat, seq)
+				copy(fdat[4:], c.data)
+				if err := writeChunk(w, "fdAT", fdat); err != nil {
+					return err
+				}
+				seq++
+			}
+		}
+	}
+
+	return writeChunk(w, "IEND", nil)
+}
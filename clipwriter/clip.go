@@ -0,0 +1,243 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package clipwriter
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// the number of times each pixel is repeated horizontally.
+const pixelWidth = 2
+
+// the smallest delay between GIF frames, in hundredths of a second, that
+// will be honoured by most GIF viewers. smaller values are usually treated
+// as a much longer delay.
+const gifMinDelay = 2
+
+// Clip is a copy of the frames in the ClipWriter buffer. It is safe to save a
+// Clip while the emulation continues.
+type Clip struct {
+	fps    float32
+	frames []frame
+}
+
+// Len returns the number of frames in the clip.
+func (clip *Clip) Len() int {
+	return len(clip.frames)
+}
+
+// Save writes the clip to disk. The format is decided by the extension of the
+// filename. Supported extensions are .gif, .png and .apng. Both the .png and
+// .apng extensions produce an animated PNG.
+//
+// Most GIF viewers can't show more than 50 frames per second. For clips with
+// a higher frame rate, consecutive frames are blended into one GIF frame so
+// that objects drawn on alternate frames remain visible.
+func (clip *Clip) Save(filename string) (rerr error) {
+	if len(clip.frames) == 0 {
+		return curated.Errorf("clipwriter: no frames to save")
+	}
+
+	var save func(w io.Writer, frames []*image.Paletted) error
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".gif":
+		save = clip.saveGIF
+	case ".png", ".apng":
+		save = clip.saveAPNG
+	default:
+		return curated.Errorf("clipwriter: unsupported clip format (%s)", filename)
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return curated.Errorf("clipwriter: %v", err)
+	}
+	defer func() {
+		err := f.Close()
+		if err != nil && rerr == nil {
+			rerr = curated.Errorf("clipwriter: %v", err)
+		}
+	}()
+
+	err = save(f, clip.images())
+	if err != nil {
+		return curated.Errorf("clipwriter: %v", err)
+	}
+
+	return nil
+}
+
+// images converts the frames to paletted images of the same size. the size
+// of the most recent frame is used. earlier frames of a different height are
+// centred, and cropped or padded as required.
+func (clip *Clip) images() []*image.Paletted {
+	width := specification.HorizClksVisible * pixelWidth
+	height := clip.frames[len(clip.frames)-1].height
+	r := image.Rect(0, 0, width, height)
+
+	imgs := make([]*image.Paletted, 0, len(clip.frames))
+	for _, f := range clip.frames {
+		img := image.NewPaletted(r, f.palette)
+		offset := (f.height - height) / 2
+
+		for y := 0; y < height; y++ {
+			sy := y + offset
+			row := img.Pix[y*img.Stride : y*img.Stride+width]
+			if sy < 0 || sy >= f.height {
+				for x := range row {
+					row[x] = videoBlack
+				}
+				continue
+			}
+			src := f.pix[sy*specification.HorizClksVisible : (sy+1)*specification.HorizClksVisible]
+			for x, c := range src {
+				for p := 0; p < pixelWidth; p++ {
+					row[x*pixelWidth+p] = c
+				}
+			}
+		}
+
+		imgs = append(imgs, img)
+	}
+
+	return imgs
+}
+
+func (clip *Clip) saveGIF(w io.Writer, frames []*image.Paletted) error {
+	// GIF delays are measured in hundredths of a second. frames are blended
+	// together if the frame rate is too high for GIF viewers
+	step := 1
+	if clip.fps > 0 {
+		step = int(math.Ceil(float64(clip.fps) * gifMinDelay / 100))
+	}
+	if step < 1 {
+		step = 1
+	}
+
+	// delays are calculated from the running time of the clip so that
+	// rounding errors do not accumulate
+	delay := func(n int) int {
+		if clip.fps <= 0 {
+			return 0
+		}
+		return int(math.Round(float64(n) * 100 / float64(clip.fps)))
+	}
+
+	g := &gif.GIF{}
+	for i := 0; i < len(frames); i += step {
+		end := i + step
+		if end > len(frames) {
+			end = len(frames)
+		}
+		g.Image = append(g.Image, blend(frames[i:end]))
+		g.Delay = append(g.Delay, delay(i+step)-delay(i))
+	}
+
+	return gif.EncodeAll(w, g)
+}
+
+// blend the frames into a single image by averaging the color of each pixel.
+// frames must all be the same size. the palette of the new image contains
+// only the colors that are used. if there are more colors than will fit in a
+// GIF palette then the nearest color already in the palette is used.
+func blend(frames []*image.Paletted) *image.Paletted {
+	if len(frames) == 1 {
+		return frames[0]
+	}
+
+	palettes := make([][]color.RGBA, len(frames))
+	for i, f := range frames {
+		palettes[i] = make([]color.RGBA, len(f.Palette))
+		for j, c := range f.Palette {
+			palettes[i][j] = color.RGBAModel.Convert(c).(color.RGBA)
+		}
+	}
+
+	img := image.NewPaletted(frames[0].Bounds(), make(color.Palette, 0, 256))
+	lookup := make(map[color.RGBA]uint8)
+	n := len(frames)
+
+	for i := range img.Pix {
+		var r, g, b int
+		for j, f := range frames {
+			c := palettes[j][f.Pix[i]]
+			r += int(c.R)
+			g += int(c.G)
+			b += int(c.B)
+		}
+		c := color.RGBA{
+			R: uint8((r + n/2) / n),
+			G: uint8((g + n/2) / n),
+			B: uint8((b + n/2) / n),
+			A: 255,
+		}
+
+		idx, ok := lookup[c]
+		if !ok {
+			if len(img.Palette) < 256 {
+				idx = uint8(len(img.Palette))
+				img.Palette = append(img.Palette, c)
+			} else {
+				idx = uint8(img.Palette.Index(c))
+			}
+			lookup[c] = idx
+		}
+		img.Pix[i] = idx
+	}
+
+	return img
+}
+
+// returns true if all frames use the same palette.
+func samePalette(frames []*image.Paletted) bool {
+	for _, f := range frames[1:] {
+		if !equalPalette(f.Palette, frames[0].Palette) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalPalette(a color.Palette, b color.Palette) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (clip *Clip) String() string {
+	if clip.fps <= 0 {
+		return fmt.Sprintf("%d frames", len(clip.frames))
+	}
+	return fmt.Sprintf("%d frames (%.1f seconds)", len(clip.frames), float32(len(clip.frames))/clip.fps)
+}
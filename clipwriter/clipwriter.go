@@ -0,0 +1,226 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package clipwriter keeps a rolling buffer of the most recent television
+// frames, which can be saved as an animated GIF or APNG file. It is intended
+// for the capture of short clips for bug reports and the like.
+//
+// The buffered frames use the palette of the television specification,
+// which fits in a GIF palette with room to spare.
+package clipwriter
+
+import (
+	"image/color"
+
+	"github.com/jetsetilly/gopher2600/framebuffer"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// the number of colors in the television palette. the palette index for
+// video black follows the television colors.
+const (
+	numColors  = 128
+	videoBlack = numColors
+)
+
+// DefaultLength is the default number of seconds kept in the buffer.
+const DefaultLength = 10
+
+// a single buffered frame. pixels are indexes into the palette.
+type frame struct {
+	pix     []uint8
+	height  int
+	palette color.Palette
+}
+
+// ClipWriter implements the television.PixelRenderer interface.
+type ClipWriter struct {
+	// the full television signal
+	buf framebuffer.Buffer
+
+	// the palette for the current specification. a new palette is created if
+	// the colors of the specification change
+	palette color.Palette
+
+	// ring buffer of frames. next is the index of the next frame to be
+	// written and count is the number of frames in the buffer
+	frames []frame
+	next   int
+	count  int
+
+	// length of the buffer in seconds
+	length int
+}
+
+// New is the preferred method of initialisation for the ClipWriter type. The
+// length argument is the number of seconds to keep in the buffer. The
+// ClipWriter will add itself to the television as a PixelRenderer.
+func New(tv *television.Television, length int) *ClipWriter {
+	w := &ClipWriter{}
+	spec := tv.GetSpec()
+	_ = w.Resize(spec, spec.AtariSafeTop, spec.AtariSafeBottom-spec.AtariSafeTop)
+	w.SetLength(length)
+	tv.AddPixelRenderer(w)
+	return w
+}
+
+// SetLength changes the number of seconds kept in the buffer. The buffer is
+// emptied.
+func (w *ClipWriter) SetLength(length int) {
+	if length < 1 {
+		length = 1
+	}
+	w.length = length
+	w.frames = make([]frame, int(float32(length)*w.buf.Spec.FramesPerSecond))
+	w.next = 0
+	w.count = 0
+}
+
+// Length returns the number of seconds kept in the buffer.
+func (w *ClipWriter) Length() int {
+	return w.length
+}
+
+// Len returns the number of frames currently in the buffer.
+func (w *ClipWriter) Len() int {
+	return w.count
+}
+
+// Snapshot returns a copy of the frames currently in the buffer, oldest
+// first.
+func (w *ClipWriter) Snapshot() *Clip {
+	clip := &Clip{
+		fps:    w.buf.Spec.FramesPerSecond,
+		frames: make([]frame, 0, w.count),
+	}
+
+	i := w.next - w.count
+	if i < 0 {
+		i += len(w.frames)
+	}
+
+	for n := 0; n < w.count; n++ {
+		f := w.frames[i]
+		clip.frames = append(clip.frames, frame{
+			pix:     append([]uint8{}, f.pix...),
+			height:  f.height,
+			palette: f.palette,
+		})
+		i++
+		if i >= len(w.frames) {
+			i = 0
+		}
+	}
+
+	return clip
+}
+
+// Resize implements the television.PixelRenderer interface.
+func (w *ClipWriter) Resize(spec specification.Spec, topScanline, visibleScanlines int) error {
+	w.buf.Resize(spec, topScanline, visibleScanlines)
+	return nil
+}
+
+// NewFrame implements the television.PixelRenderer interface.
+func (w *ClipWriter) NewFrame(_ bool) error {
+	if len(w.frames) == 0 {
+		return nil
+	}
+
+	w.updatePalette()
+
+	f := &w.frames[w.next]
+	f.height = w.buf.Visible
+	f.palette = w.palette
+
+	l := specification.HorizClksVisible * w.buf.Visible
+	if cap(f.pix) < l {
+		f.pix = make([]uint8, l)
+	}
+	f.pix = f.pix[:l]
+
+	for y := 0; y < w.buf.Visible; y++ {
+		o := f.pix[y*specification.HorizClksVisible : (y+1)*specification.HorizClksVisible]
+		for x := range o {
+			sig := w.buf.Signal(x+specification.HorizClksHBlank, y+w.buf.Top)
+			if sig == signal.VideoBlack {
+				o[x] = videoBlack
+			} else {
+				o[x] = uint8(sig>>1) & (numColors - 1)
+			}
+		}
+	}
+
+	w.next++
+	if w.next >= len(w.frames) {
+		w.next = 0
+	}
+	if w.count < len(w.frames) {
+		w.count++
+	}
+
+	return nil
+}
+
+// create a new palette if the colors of the specification have changed.
+// frames that share a palette share the same color.Palette instance.
+func (w *ClipWriter) updatePalette() {
+	changed := len(w.palette) != numColors+1
+	if !changed {
+		for i := 0; i < numColors; i++ {
+			if w.palette[i] != w.buf.Spec.GetColor(signal.ColorSignal(i<<1)) {
+				changed = true
+				break
+			}
+		}
+	}
+
+	if !changed {
+		return
+	}
+
+	w.palette = make(color.Palette, numColors+1)
+	for i := 0; i < numColors; i++ {
+		w.palette[i] = w.buf.Spec.GetColor(signal.ColorSignal(i << 1))
+	}
+	w.palette[videoBlack] = color.RGBA{A: 255}
+}
+
+// NewScanline implements the television.PixelRenderer interface.
+func (w *ClipWriter) NewScanline(_ int) error {
+	return nil
+}
+
+// UpdatingPixels implements the television.PixelRenderer interface.
+func (w *ClipWriter) UpdatingPixels(_ bool) {
+}
+
+// SetPixel implements the television.PixelRenderer interface.
+func (w *ClipWriter) SetPixel(sig signal.SignalAttributes, _ bool) error {
+	w.buf.SetPixel(sig)
+	return nil
+}
+
+// Reset implements the television.PixelRenderer interface.
+func (w *ClipWriter) Reset() {
+	w.buf.Reset()
+}
+
+// EndRendering implements the television.PixelRenderer interface.
+func (w *ClipWriter) EndRendering() error {
+	return nil
+}
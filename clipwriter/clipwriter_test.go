@@ -0,0 +1,238 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package clipwriter
+
+import (
+	"encoding/binary"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// create a ClipWriter with one second of NTSC frames. the first pixel of each
+// frame is set to a color that identifies the frame.
+func testClip(t *testing.T, numFrames int) *Clip {
+	t.Helper()
+
+	// not using New() because we don't need a television for this test
+	w := &ClipWriter{}
+	spec := specification.SpecNTSC
+	_ = w.Resize(spec, spec.AtariSafeTop, spec.AtariSafeBottom-spec.AtariSafeTop)
+	w.SetLength(1)
+
+	for i := 0; i < numFrames; i++ {
+		_ = w.SetPixel(signal.SignalAttributes{
+			Pixel:    signal.ColorSignal((i << 1) & 0xfe),
+			HorizPos: specification.HorizClksHBlank,
+			Scanline: spec.AtariSafeTop,
+		}, true)
+		_ = w.NewFrame(true)
+	}
+
+	return w.Snapshot()
+}
+
+func TestRollingBuffer(t *testing.T) {
+	clip := testClip(t, 10)
+	if clip.Len() != 10 {
+		t.Errorf("unexpected number of frames (%d)", clip.Len())
+	}
+
+	// more frames than the buffer can hold. oldest frames are discarded
+	clip = testClip(t, 70)
+	if clip.Len() != 60 {
+		t.Fatalf("unexpected number of frames (%d)", clip.Len())
+	}
+	if clip.frames[0].pix[0] != 10 || clip.frames[59].pix[0] != 69 {
+		t.Errorf("unexpected frame order (%d, %d)", clip.frames[0].pix[0], clip.frames[59].pix[0])
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clipwriter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	clip := testClip(t, 60)
+
+	// GIF. at 60fps every pair of frames is blended into one GIF frame
+	fn := filepath.Join(dir, "test.gif")
+	err = clip.Save(fn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, err := os.Open(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := gif.DecodeAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(g.Image) != 30 {
+		t.Errorf("unexpected number of GIF frames (%d)", len(g.Image))
+	}
+	total := 0
+	for _, d := range g.Delay {
+		if d < gifMinDelay {
+			t.Errorf("GIF delay too short (%d)", d)
+		}
+		total += d
+	}
+	if total != 100 {
+		t.Errorf("unexpected GIF duration (%d)", total)
+	}
+	if g.Image[0].Bounds().Dx() != specification.HorizClksVisible*pixelWidth {
+		t.Errorf("unexpected GIF width (%d)", g.Image[0].Bounds().Dx())
+	}
+
+	// APNG
+	fn = filepath.Join(dir, "test.png")
+	err = clip.Save(fn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks, err := pngChunks(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var fctl, fdat int
+	for _, c := range chunks {
+		switch c.typ {
+		case "acTL":
+			if n := binary.BigEndian.Uint32(c.data); n != 60 {
+				t.Errorf("unexpected number of APNG frames (%d)", n)
+			}
+		case "fcTL":
+			fctl++
+		case "fdAT":
+			fdat++
+		}
+	}
+	if fctl != 60 || fdat < 59 {
+		t.Errorf("unexpected APNG chunks (%d fcTL, %d fdAT)", fctl, fdat)
+	}
+
+	// APNG files are readable as a regular PNG
+	f, err = os.Open(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = png.Decode(f)
+	f.Close()
+	if err != nil {
+		t.Errorf("APNG is not a valid PNG: %v", err)
+	}
+
+	// unsupported format
+	if err := clip.Save(filepath.Join(dir, "test.bmp")); err == nil {
+		t.Errorf("expected error for unsupported format")
+	}
+}
+
+// an object drawn on alternate frames should be visible in every GIF frame
+// when frames are blended.
+func TestSaveFlicker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clipwriter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	w := &ClipWriter{}
+	spec := specification.SpecNTSC
+	_ = w.Resize(spec, spec.AtariSafeTop, spec.AtariSafeBottom-spec.AtariSafeTop)
+	w.SetLength(1)
+
+	const white = signal.ColorSignal(0x0e)
+	const black = signal.ColorSignal(0x00)
+
+	for i := 0; i < 60; i++ {
+		// the flickering object is drawn on even frames only
+		col := black
+		if i%2 == 0 {
+			col = white
+		}
+		_ = w.SetPixel(signal.SignalAttributes{
+			Pixel:    col,
+			HorizPos: specification.HorizClksHBlank,
+			Scanline: spec.AtariSafeTop,
+		}, true)
+
+		// a second object is drawn on every frame
+		_ = w.SetPixel(signal.SignalAttributes{
+			Pixel:    white,
+			HorizPos: specification.HorizClksHBlank + 1,
+			Scanline: spec.AtariSafeTop,
+		}, true)
+
+		_ = w.NewFrame(true)
+	}
+
+	fn := filepath.Join(dir, "flicker.gif")
+	if err := w.Snapshot().Save(fn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, err := os.Open(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := gif.DecodeAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(g.Image) != 30 {
+		t.Fatalf("unexpected number of GIF frames (%d)", len(g.Image))
+	}
+
+	w0 := spec.GetColor(white)
+	b0 := spec.GetColor(black)
+	blended := color.RGBA{
+		R: uint8((int(w0.R) + int(b0.R) + 1) / 2),
+		G: uint8((int(w0.G) + int(b0.G) + 1) / 2),
+		B: uint8((int(w0.B) + int(b0.B) + 1) / 2),
+		A: 255,
+	}
+
+	for i, img := range g.Image {
+		if c := color.RGBAModel.Convert(img.At(0, 0)); c != blended {
+			t.Errorf("frame %d: flickering object not blended (%v)", i, c)
+		}
+		if c := color.RGBAModel.Convert(img.At(pixelWidth, 0)); c != w0 {
+			t.Errorf("frame %d: solid object has changed color (%v)", i, c)
+		}
+	}
+}
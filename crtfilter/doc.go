@@ -0,0 +1,39 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package crtfilter is a software implementation of some of the effects of
+// displaying the VCS on a CRT television over a composite NTSC signal. Unlike
+// the shaders in the gui/crt package, the filter runs entirely on the CPU and
+// works on any image.Image. It is suitable for use with the pngwriter and
+// videowriter packages and with any renderer that does not use OpenGL.
+//
+// The effects are:
+//
+//	chroma bleed: the colour information in a composite signal has a much
+//	lower bandwidth than the brightness information. colours smear
+//	horizontally across sharp edges
+//
+//	dot crawl: the colour subcarrier is not completely removed from the
+//	brightness signal, producing a fine pattern of dots at the edges of
+//	coloured areas. the pattern moves from frame to frame
+//
+//	scanlines: the gap between the lines drawn by the electron beam
+//
+//	phosphor persistence: the phosphors on the screen take time to fade
+//	after being lit, leaving trails behind moving objects
+//
+// Each effect can be turned on or off and its strength adjusted through the
+// Preferences type, in the same way as the CRT effects in the gui/crt package.
+package crtfilter
@@ -0,0 +1,237 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package crtfilter
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// the size of each input pixel in the output image. the horizontal scaling
+// gives the image the correct aspect ratio and the vertical scaling leaves
+// room for the scanlines.
+const (
+	PixelWidth  = 2
+	PixelHeight = 2
+)
+
+// the bandwidth of the I and Q components of the composite signal is much
+// lower than the bandwidth of the Y component. the amount of blurring of each
+// is given as the standard deviation, in pixels, of a gaussian blur. one VCS
+// pixel is one cycle of the colour subcarrier (3.58MHz) so the values are
+// approximately those of the 1.3MHz and 0.4MHz bandwidths of the I and Q
+// signals.
+const (
+	iSigma = 1.0
+	qSigma = 2.5
+)
+
+// Filter applies the CRT effects to an image. The filter keeps state from
+// one image to the next, for the dot crawl and phosphor persistence effects,
+// so a separate filter should be used for each sequence of images.
+type Filter struct {
+	prefs *Preferences
+
+	// the number of images that have been filtered. used for the phase of the
+	// dot crawl
+	frame int
+
+	// the previous output image. used for phosphor persistence
+	prev *image.RGBA
+
+	// one scanline of YIQ values. reused between scanlines
+	y []float32
+	i []float32
+	q []float32
+	t []float32
+
+	iKernel []float32
+	qKernel []float32
+}
+
+// NewFilter is the preferred method of initialisation for the Filter type.
+// Preference values are read every time the filter is applied so changes to
+// the preferences take effect immediately.
+func NewFilter(prefs *Preferences) *Filter {
+	return &Filter{
+		prefs:   prefs,
+		iKernel: gaussian(iSigma),
+		qKernel: gaussian(qSigma),
+	}
+}
+
+// Reset forgets the state kept between images.
+func (f *Filter) Reset() {
+	f.frame = 0
+	f.prev = nil
+}
+
+// Apply the filter to the src image. The returned image is PixelWidth times
+// wider and PixelHeight times taller than the src image.
+func (f *Filter) Apply(src image.Image) *image.RGBA {
+	chromaBleed := f.prefs.ChromaBleed.Get().(bool)
+	chromaBleedLevel := float32(f.prefs.ChromaBleedLevel.Get().(float64))
+	dotCrawl := f.prefs.DotCrawl.Get().(bool)
+	dotCrawlLevel := float32(f.prefs.DotCrawlLevel.Get().(float64))
+	scanlines := f.prefs.Scanlines.Get().(bool)
+	scanlinesBrightness := float32(f.prefs.ScanlinesBrightness.Get().(float64))
+	persistence := f.prefs.Persistence.Get().(bool)
+	persistenceLevel := float32(f.prefs.PersistenceLevel.Get().(float64))
+
+	bounds := src.Bounds()
+	w := bounds.Dx()
+	h := bounds.Dy()
+
+	out := image.NewRGBA(image.Rect(0, 0, w*PixelWidth, h*PixelHeight))
+
+	if len(f.y) != w {
+		f.y = make([]float32, w)
+		f.i = make([]float32, w)
+		f.q = make([]float32, w)
+		f.t = make([]float32, w)
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, b, _ := src.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			f.y[x], f.i[x], f.q[x] = rgbToYIQ(float32(r>>8)/255, float32(g>>8)/255, float32(b>>8)/255)
+		}
+
+		if chromaBleed {
+			blur(f.i, f.t, f.iKernel, chromaBleedLevel)
+			blur(f.q, f.t, f.qKernel, chromaBleedLevel)
+		}
+
+		// the phase of the colour subcarrier at the start of the scanline. a
+		// broadcast NTSC signal has 227.5 cycles of the subcarrier per
+		// scanline and so the phase alternates on every scanline and every
+		// frame. the VCS produces exactly 228 cycles per scanline but we use
+		// the broadcast phase because the effect is more obvious
+		phase := math.Pi * float64((y+f.frame)%2)
+
+		for x := 0; x < w; x++ {
+			for p := 0; p < PixelWidth; p++ {
+				yv := f.y[x]
+
+				if dotCrawl {
+					s := phase + math.Pi*2*float64(p)/PixelWidth + math.Pi/4
+					yv += dotCrawlLevel * (f.i[x]*float32(math.Cos(s)) + f.q[x]*float32(math.Sin(s)))
+				}
+
+				c := yiqToRGB(yv, f.i[x], f.q[x], 1.0)
+				out.SetRGBA(x*PixelWidth+p, y*PixelHeight, c)
+
+				for l := 1; l < PixelHeight; l++ {
+					if scanlines {
+						out.SetRGBA(x*PixelWidth+p, y*PixelHeight+l, yiqToRGB(yv, f.i[x], f.q[x], scanlinesBrightness))
+					} else {
+						out.SetRGBA(x*PixelWidth+p, y*PixelHeight+l, c)
+					}
+				}
+			}
+		}
+	}
+
+	// phosphor persistence. the previous image fades by the persistence level
+	// and shows through wherever it is brighter than the new image
+	if persistence && f.prev != nil && f.prev.Bounds() == out.Bounds() {
+		for i := range out.Pix {
+			v := uint8(float32(f.prev.Pix[i]) * persistenceLevel)
+			if v > out.Pix[i] {
+				out.Pix[i] = v
+			}
+		}
+	}
+
+	if f.prev == nil || f.prev.Bounds() != out.Bounds() {
+		f.prev = image.NewRGBA(out.Bounds())
+	}
+	copy(f.prev.Pix, out.Pix)
+
+	f.frame++
+
+	return out
+}
+
+// blur the values in v using the kernel. the result is mixed with the
+// original values by the level argument. t is used as temporary storage and
+// must be the same length as v.
+func blur(v []float32, t []float32, kernel []float32, level float32) {
+	r := len(kernel) / 2
+	for x := range v {
+		var s float32
+		for k := range kernel {
+			i := x + k - r
+			if i < 0 {
+				i = 0
+			} else if i >= len(v) {
+				i = len(v) - 1
+			}
+			s += v[i] * kernel[k]
+		}
+		t[x] = s
+	}
+
+	for x := range v {
+		v[x] += (t[x] - v[x]) * level
+	}
+}
+
+// returns a normalised gaussian kernel for the standard deviation.
+func gaussian(sigma float64) []float32 {
+	r := int(math.Ceil(sigma * 3))
+	k := make([]float32, r*2+1)
+
+	var sum float64
+	for i := range k {
+		x := float64(i - r)
+		v := math.Exp(-(x * x) / (2 * sigma * sigma))
+		k[i] = float32(v)
+		sum += v
+	}
+	for i := range k {
+		k[i] /= float32(sum)
+	}
+
+	return k
+}
+
+func rgbToYIQ(r, g, b float32) (float32, float32, float32) {
+	y := 0.299*r + 0.587*g + 0.114*b
+	i := 0.596*r - 0.274*g - 0.322*b
+	q := 0.211*r - 0.523*g + 0.312*b
+	return y, i, q
+}
+
+// converts YIQ to an RGBA value, with the brightness scaled by the
+// brightness argument.
+func yiqToRGB(y, i, q float32, brightness float32) color.RGBA {
+	r := (y + 0.956*i + 0.621*q) * brightness
+	g := (y - 0.272*i - 0.647*q) * brightness
+	b := (y - 1.106*i + 1.703*q) * brightness
+	return color.RGBA{R: clamp(r), G: clamp(g), B: clamp(b), A: 255}
+}
+
+func clamp(v float32) uint8 {
+	if v <= 0.0 {
+		return 0
+	}
+	if v >= 1.0 {
+		return 255
+	}
+	return uint8(v*255 + 0.5)
+}
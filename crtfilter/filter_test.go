@@ -0,0 +1,147 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package crtfilter_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/crtfilter"
+)
+
+// preferences with all effects turned off.
+func noEffects() *crtfilter.Preferences {
+	p := &crtfilter.Preferences{}
+	p.SetDefaults()
+	p.ChromaBleed.Set(false)
+	p.DotCrawl.Set(false)
+	p.Scanlines.Set(false)
+	p.Persistence.Set(false)
+	return p
+}
+
+func solid(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func near(a, b uint8) bool {
+	return a-b < 3 || b-a < 3
+}
+
+func TestSize(t *testing.T) {
+	f := crtfilter.NewFilter(noEffects())
+	out := f.Apply(solid(160, 192, color.RGBA{A: 255}))
+	if out.Bounds().Dx() != 160*crtfilter.PixelWidth || out.Bounds().Dy() != 192*crtfilter.PixelHeight {
+		t.Errorf("unexpected output size (%v)", out.Bounds())
+	}
+}
+
+func TestNoEffects(t *testing.T) {
+	// with all effects turned off the image is only scaled. the conversion to
+	// and from YIQ may introduce a small error
+	c := color.RGBA{R: 200, G: 100, B: 50, A: 255}
+	f := crtfilter.NewFilter(noEffects())
+	out := f.Apply(solid(8, 8, c))
+	for y := 0; y < out.Bounds().Dy(); y++ {
+		for x := 0; x < out.Bounds().Dx(); x++ {
+			o := out.RGBAAt(x, y)
+			if !near(o.R, c.R) || !near(o.G, c.G) || !near(o.B, c.B) {
+				t.Fatalf("unexpected color at %d,%d (%v)", x, y, o)
+			}
+		}
+	}
+}
+
+func TestScanlines(t *testing.T) {
+	p := noEffects()
+	p.Scanlines.Set(true)
+	p.ScanlinesBrightness.Set(0.5)
+
+	f := crtfilter.NewFilter(p)
+	out := f.Apply(solid(8, 8, color.RGBA{R: 200, G: 200, B: 200, A: 255}))
+
+	lit := out.RGBAAt(0, 0)
+	gap := out.RGBAAt(0, 1)
+	if !near(gap.R, lit.R/2) {
+		t.Errorf("scanline not darkened (%v %v)", lit, gap)
+	}
+}
+
+func TestChromaBleed(t *testing.T) {
+	p := noEffects()
+	p.ChromaBleed.Set(true)
+	p.ChromaBleedLevel.Set(1.0)
+
+	// a red area next to a grey area of the same brightness
+	img := solid(16, 1, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+	for x := 0; x < 8; x++ {
+		img.SetRGBA(x, 0, color.RGBA{R: 255, G: 73, B: 73, A: 255})
+	}
+
+	f := crtfilter.NewFilter(p)
+	out := f.Apply(img)
+
+	// the grey pixel next to the edge should have picked up some red
+	o := out.RGBAAt(8*crtfilter.PixelWidth, 0)
+	if o.R <= o.B {
+		t.Errorf("colour has not bled across edge (%v)", o)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	p := noEffects()
+	p.Persistence.Set(true)
+	p.PersistenceLevel.Set(0.5)
+
+	f := crtfilter.NewFilter(p)
+	_ = f.Apply(solid(4, 4, color.RGBA{R: 200, G: 200, B: 200, A: 255}))
+	out := f.Apply(solid(4, 4, color.RGBA{A: 255}))
+
+	o := out.RGBAAt(0, 0)
+	if !near(o.R, 100) {
+		t.Errorf("previous image has not persisted (%v)", o)
+	}
+
+	f.Reset()
+	out = f.Apply(solid(4, 4, color.RGBA{A: 255}))
+	o = out.RGBAAt(0, 0)
+	if o.R != 0 {
+		t.Errorf("previous image persisted after reset (%v)", o)
+	}
+}
+
+func TestDotCrawl(t *testing.T) {
+	p := noEffects()
+	p.DotCrawl.Set(true)
+	p.DotCrawlLevel.Set(0.5)
+
+	f := crtfilter.NewFilter(p)
+	c := color.RGBA{R: 200, G: 50, B: 50, A: 255}
+
+	// the pattern on a coloured area should change from frame to frame
+	a := f.Apply(solid(4, 4, c)).RGBAAt(0, 0)
+	b := f.Apply(solid(4, 4, c)).RGBAAt(0, 0)
+	if a == b {
+		t.Errorf("dot crawl pattern has not moved (%v %v)", a, b)
+	}
+}
@@ -0,0 +1,130 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package crtfilter
+
+import (
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// Preferences for the software CRT filter.
+type Preferences struct {
+	dsk *prefs.Disk
+
+	ChromaBleed prefs.Bool
+	DotCrawl    prefs.Bool
+	Scanlines   prefs.Bool
+	Persistence prefs.Bool
+
+	ChromaBleedLevel    prefs.Float
+	DotCrawlLevel       prefs.Float
+	ScanlinesBrightness prefs.Float
+	PersistenceLevel    prefs.Float
+}
+
+func (p *Preferences) String() string {
+	return p.dsk.String()
+}
+
+const (
+	chromaBleed         = true
+	dotCrawl            = true
+	scanlines           = true
+	persistence         = true
+	chromaBleedLevel    = 0.80
+	dotCrawlLevel       = 0.15
+	scanlinesBrightness = 0.70
+	persistenceLevel    = 0.40
+)
+
+// NewPreferences is the preferred method of initialisation for the Preferences type.
+func NewPreferences() (*Preferences, error) {
+	p := &Preferences{}
+	p.SetDefaults()
+
+	// save server using the prefs package
+	pth, err := paths.ResourcePath("", prefs.DefaultPrefsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	p.dsk, err = prefs.NewDisk(pth)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.dsk.Add("crtfilter.chromaBleed", &p.ChromaBleed)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("crtfilter.dotCrawl", &p.DotCrawl)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("crtfilter.scanlines", &p.Scanlines)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("crtfilter.persistence", &p.Persistence)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("crtfilter.chromaBleedLevel", &p.ChromaBleedLevel)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("crtfilter.dotCrawlLevel", &p.DotCrawlLevel)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("crtfilter.scanlinesBrightness", &p.ScanlinesBrightness)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("crtfilter.persistenceLevel", &p.PersistenceLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.dsk.Load(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// SetDefaults reverts all CRT filter settings to default values.
+func (p *Preferences) SetDefaults() {
+	p.ChromaBleed.Set(chromaBleed)
+	p.DotCrawl.Set(dotCrawl)
+	p.Scanlines.Set(scanlines)
+	p.Persistence.Set(persistence)
+	p.ChromaBleedLevel.Set(chromaBleedLevel)
+	p.DotCrawlLevel.Set(dotCrawlLevel)
+	p.ScanlinesBrightness.Set(scanlinesBrightness)
+	p.PersistenceLevel.Set(persistenceLevel)
+}
+
+// Load CRT filter preferences from disk.
+func (p *Preferences) Load() error {
+	return p.dsk.Load(false)
+}
+
+// Save current CRT filter preferences to disk.
+func (p *Preferences) Save() error {
+	return p.dsk.Save()
+}
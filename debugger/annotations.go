@@ -0,0 +1,73 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/symbols"
+)
+
+// annotation error formatting, for consistency.
+const (
+	annotationAddress = "annotations can only be added to cartridge addresses (%s)"
+)
+
+// resolve the address argument of the LABEL and COMMENT commands. the address
+// can be an existing label or any address that mapAddress() understands. the
+// bank is the bank currently mapped into the address.
+func (dbg *Debugger) resolveAnnotation(arg string) (int, uint16, error) {
+	var address uint16
+
+	if ok, _, _, a := dbg.Disasm.Symbols.Search(arg, symbols.LabelTable); ok {
+		address = a
+	} else {
+		ai := dbg.dbgmem.mapAddress(arg, true)
+		if ai == nil {
+			return 0, 0, curated.Errorf("invalid address (%s)", arg)
+		}
+		address = ai.address
+	}
+
+	bank := dbg.VCS.Mem.Cart.GetBank(address)
+	if bank.NonCart {
+		return 0, 0, curated.Errorf(annotationAddress, arg)
+	}
+
+	return bank.Number, address, nil
+}
+
+// list user labels or user comments.
+func (dbg *Debugger) listAnnotations(labels bool) {
+	n := 0
+	for _, a := range dbg.Disasm.Symbols.Annotations() {
+		if labels && a.Label != "" {
+			dbg.printLine(terminal.StyleFeedback, "bank %d %#04x %s", a.Bank, a.Address, a.Label)
+			n++
+		} else if !labels && a.Comment != "" {
+			dbg.printLine(terminal.StyleFeedback, "bank %d %#04x ; %s", a.Bank, a.Address, a.Comment)
+			n++
+		}
+	}
+
+	if n == 0 {
+		if labels {
+			dbg.printLine(terminal.StyleFeedback, "no user labels")
+		} else {
+			dbg.printLine(terminal.StyleFeedback, "no user comments")
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/patch"
+	"github.com/jetsetilly/gopher2600/symbols"
+)
+
+// resolve symbols for the assembler. labels are preferred to the read and
+// write symbols.
+func (dbg *Debugger) assemblerLookup(symbol string) (uint16, bool) {
+	if ok, _, _, a := dbg.Disasm.Symbols.Search(symbol, symbols.LabelTable); ok {
+		return a, true
+	}
+	if ok, _, _, a := dbg.Disasm.Symbols.Search(symbol, symbols.UnspecifiedSymTable); ok {
+		return a, true
+	}
+	return 0, false
+}
+
+// the offset into the cartridge data of the address in the bank. offsets are
+// the same as those used by the patch package.
+func (dbg *Debugger) cartridgeOffset(bank mapper.BankInfo, address uint16) (int, error) {
+	banks, err := dbg.VCS.Mem.Cart.CopyBanks()
+	if err != nil {
+		return 0, err
+	}
+
+	a := address & memorymap.CartridgeBits
+
+	offset := 0
+	for _, b := range banks {
+		if b.Number == bank.Number {
+			for _, o := range b.Origins {
+				o &= memorymap.CartridgeBits
+				if a >= o && int(a-o) < len(b.Data) {
+					return offset + int(a-o), nil
+				}
+			}
+		}
+		offset += len(b.Data)
+	}
+
+	return 0, curated.Errorf("address is not in cartridge ROM (%#04x)", address)
+}
+
+// assemble instruction and patch it into the cartridge at the address. the
+// address can be a label or anything that mapAddress() understands.
+func (dbg *Debugger) assemble(arg string, instruction string) error {
+	var address uint16
+
+	if a, ok := dbg.assemblerLookup(arg); ok {
+		address = a
+	} else {
+		ai := dbg.dbgmem.mapAddress(arg, true)
+		if ai == nil {
+			return curated.Errorf("invalid address (%s)", arg)
+		}
+		address = ai.address
+	}
+
+	bank := dbg.VCS.Mem.Cart.GetBank(address)
+	if bank.NonCart || bank.IsRAM {
+		return curated.Errorf("can only assemble to cartridge ROM (%s)", arg)
+	}
+
+	data, err := assembler.Assemble(address, instruction, dbg.assemblerLookup)
+	if err != nil {
+		return err
+	}
+
+	offset, err := dbg.cartridgeOffset(bank, address)
+	if err != nil {
+		return err
+	}
+
+	for i, v := range data {
+		err = dbg.VCS.Mem.Cart.Patch(offset+i, v)
+		if err != nil {
+			return err
+		}
+		dbg.assembled[offset+i] = v
+	}
+
+	err = dbg.Disasm.PatchedEntry(bank, address, data)
+	if err != nil {
+		return err
+	}
+
+	s := strings.Builder{}
+	for _, v := range data {
+		s.WriteString(fmt.Sprintf("%02x ", v))
+	}
+	dbg.printLine(terminal.StyleFeedback, "%#04x (bank %d) %s", address, bank.Number, strings.TrimSpace(s.String()))
+	dbg.printLine(terminal.StyleFeedback, "next address %#04x", address+uint16(len(data)))
+
+	return nil
+}
+
+// save everything assembled since the cartridge was attached as a patch file.
+func (dbg *Debugger) saveAssembled(patchFile string) error {
+	if len(dbg.assembled) == 0 {
+		return curated.Errorf("nothing has been assembled")
+	}
+
+	err := patch.Write(patchFile, dbg.assembled, fmt.Sprintf("assembled for %s", filepath.Base(dbg.VCS.Mem.Cart.Filename)))
+	if err != nil {
+		return err
+	}
+
+	dbg.printLine(terminal.StyleFeedback, "patch file saved (%s)", patchFile)
+	return nil
+}
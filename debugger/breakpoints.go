@@ -16,6 +16,9 @@
 // breakpoints are used to halt execution when a  target is *changed to* a
 // specific value.  compare to traps which are used to halt execution when the
 // target *changes from* its current value *to* any other value.
+//
+// breakpoints can also be defined with a condition, in which case execution
+// will halt when the condition *becomes* true.
 
 package debugger
 
@@ -54,9 +57,21 @@ type breaker struct {
 
 	// single linked list ANDs breakers together
 	next *breaker
+
+	// a breaker with a condition has no target or value and is never part of
+	// a linked list
+	cond *condition
+
+	// the result of the condition the last time it was checked. the breaker
+	// only matches when the condition changes from false to true
+	condMet bool
 }
 
 func (bk breaker) String() string {
+	if bk.cond != nil {
+		return bk.cond.String()
+	}
+
 	s := strings.Builder{}
 	s.WriteString(fmt.Sprintf("%s->%s", bk.target.Label(), bk.target.FormatValue(bk.value)))
 	n := bk.next
@@ -70,6 +85,11 @@ func (bk breaker) String() string {
 // compares two breakers for equality. returns true if the two breakers are
 // logically the same.
 func (bk breaker) cmp(ck breaker) bool {
+	// conditional breakers are compared by their normalised expression
+	if bk.cond != nil || ck.cond != nil {
+		return bk.cond != nil && ck.cond != nil && bk.cond.String() == ck.cond.String()
+	}
+
 	// count number of nodes
 	bn := 0
 	b := &bk
@@ -124,6 +144,25 @@ const (
 // check checks the specific break condition with the current value of
 // the break target.
 func (bk *breaker) check() checkResult {
+	if bk.cond != nil {
+		t, err := bk.cond.expr.True()
+		if err != nil || !t {
+			bk.condMet = false
+			return checkNoMatch
+		}
+
+		if bk.condMet {
+			return checkIgnoredValue
+		}
+		bk.condMet = true
+
+		if !bk.cond.hit() {
+			return checkIgnoredValue
+		}
+
+		return checkMatch
+	}
+
 	currVal := bk.target.TargetValue()
 	m := currVal == bk.value
 	if !m {
@@ -221,7 +260,11 @@ func (bp breakpoints) list() {
 	} else {
 		bp.dbg.printLine(terminal.StyleFeedback, "breakpoints:")
 		for i := range bp.breaks {
-			bp.dbg.printLine(terminal.StyleFeedback, "% 2d: %s", i, bp.breaks[i])
+			if bp.breaks[i].cond != nil {
+				bp.dbg.printLine(terminal.StyleFeedback, "% 2d: %s (hit %d times)", i, bp.breaks[i], bp.breaks[i].cond.hits)
+			} else {
+				bp.dbg.printLine(terminal.StyleFeedback, "% 2d: %s", i, bp.breaks[i])
+			}
 		}
 	}
 }
@@ -243,8 +286,57 @@ func (bp breakpoints) list() {
 //
 //	& SL 100 HP 0 X 10
 //
+// a conditional breakpoint is specified with the IF keyword. the remainder of
+// the tokens form the condition.
+//
+//	IF PEEK($80) & $0F == 3
+//
 // !!TODO: simplify breakpoints parser to match help description.
 func (bp *breakpoints) parseCommand(tokens *commandline.Tokens) error {
+	if tok, ok := tokens.Peek(); ok && strings.ToUpper(tok) == "IF" {
+		tokens.Get()
+		cnd, err := parseConditionTokens(bp.dbg, tokens)
+		if err != nil {
+			return err
+		}
+
+		nb := breaker{cond: cnd}
+		if i := bp.checkBreaker(nb); i != noBreakEqualivalent {
+			return curated.Errorf("already exists (%s)", bp.breaks[i])
+		}
+
+		// if the condition is already true when the breakpoint is added then
+		// we don't want to break immediately
+		nb.condMet, _ = cnd.expr.True()
+
+		bp.breaks = append(bp.breaks, nb)
+		return nil
+	}
+
+	// a reference to a source line is a PC breakpoint for the address of the
+	// code generated by the line
+	if tok, ok := tokens.Peek(); ok {
+		address, bank, isRef, err := bp.dbg.parseSourceRef(tok)
+		if isRef {
+			if err != nil {
+				return err
+			}
+			tokens.Get()
+
+			nb := breaker{target: bp.checkPcBreak, value: int(address)}
+			if bank != -1 {
+				nb.next = &breaker{target: bp.checkBankBreak, value: bank}
+			}
+
+			if i := bp.checkBreaker(nb); i != noBreakEqualivalent {
+				return curated.Errorf("already exists (%s)", bp.breaks[i])
+			}
+
+			bp.breaks = append(bp.breaks, nb)
+			return nil
+		}
+	}
+
 	andBreaks := false
 
 	// default target of CPU PC. meaning that "BREAK n" will cause a breakpoint
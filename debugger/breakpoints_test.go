@@ -53,4 +53,49 @@ func (trm *mockTerm) testBreakpoints() {
 
 	trm.sndInput("BREAK HP 100")
 	trm.cmpOutput("")
+
+	// conditional break. the validation of the command line will normalise
+	// the $ hex prefix
+	trm.sndInput("BREAK IF PEEK($80)&$0F==3")
+	trm.cmpOutput("")
+
+	trm.sndInput("LIST BREAKS")
+	trm.cmpOutput(" 3: if PEEK($80) & $0f == 3 (hit 0 times)")
+
+	// the same condition expressed with different spacing is a duplicate
+	trm.sndInput("BREAK IF PEEK(0x80) & 0x0F == 3")
+	trm.cmpOutput("already exists (if PEEK($80) & $0f == 3)")
+
+	// conditional break with hit count
+	trm.sndInput("BREAK IF x == 0 HITS 10")
+	trm.cmpOutput("")
+
+	trm.sndInput("LIST BREAKS")
+	trm.cmpOutput(" 4: if X == 0 hits 10 (hit 0 times)")
+
+	// invalid conditions
+	trm.sndInput("BREAK IF X ==")
+	trm.cmpOutput("expression: unexpected end of expression")
+
+	trm.sndInput("BREAK IF NOSUCHSYMBOL == 1")
+	trm.cmpOutput("expression: unrecognised identifier (NOSUCHSYMBOL)")
+
+	// TIA register symbols are evaluated as their address and not as a
+	// debugger target. each expression divides by zero if the symbol has
+	// been evaluated correctly
+	trm.sndInput("BREAK IF 1 / (VBLANK - 1)")
+	trm.cmpOutput("expression: divide by zero")
+	trm.sndInput("BREAK IF 1 / (WSYNC - 2)")
+	trm.cmpOutput("expression: divide by zero")
+	trm.sndInput("BREAK IF 1 / (HMOVE - $2a)")
+	trm.cmpOutput("expression: divide by zero")
+
+	// the TV and TIA state targets have names that don't collide with the
+	// register symbols
+	trm.sndInput("BREAK IF TVVSYNC || TVVBLANK || WSYNCWAIT || HMOVELATCH")
+	trm.cmpOutput("")
+
+	// source line breaks require source information
+	trm.sndInput("BREAK kernel.asm:123")
+	trm.cmpOutput("no source information for cartridge")
 }
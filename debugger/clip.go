@@ -0,0 +1,62 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/debugger/terminal/commandline"
+)
+
+// clip implements the CLIP command.
+func (dbg *Debugger) clip(tokens *commandline.Tokens) error {
+	arg, ok := tokens.Get()
+	if !ok {
+		dbg.printLine(terminal.StyleFeedback, "%d frames kept (maximum %d seconds)",
+			dbg.clipWriter.Len(), dbg.clipWriter.Length())
+		return nil
+	}
+
+	switch strings.ToUpper(arg) {
+	case "LENGTH":
+		arg, _ := tokens.Get()
+		length, _ := strconv.Atoi(arg)
+		dbg.clipWriter.SetLength(length)
+		dbg.printLine(terminal.StyleFeedback, "keeping %d seconds of frames", dbg.clipWriter.Length())
+
+	case "SAVE":
+		filename, ok := tokens.Get()
+		if !ok {
+			n := time.Now()
+			filename = fmt.Sprintf("clip_%s_%s.gif",
+				dbg.cartload.ShortName(), fmt.Sprintf("%04d%02d%02d_%02d%02d%02d",
+					n.Year(), n.Month(), n.Day(), n.Hour(), n.Minute(), n.Second()))
+		}
+
+		clip := dbg.clipWriter.Snapshot()
+		err := clip.Save(filename)
+		if err != nil {
+			return err
+		}
+		dbg.printLine(terminal.StyleFeedback, "%s saved to %s", clip, filename)
+	}
+
+	return nil
+}
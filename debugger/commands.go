@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"os"
 	"runtime"
 	"sort"
 	"strconv"
@@ -40,6 +41,8 @@ import (
 	"github.com/jetsetilly/gopher2600/linter"
 	"github.com/jetsetilly/gopher2600/logger"
 	"github.com/jetsetilly/gopher2600/patch"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/profiler"
 	"github.com/jetsetilly/gopher2600/symbols"
 )
 
@@ -200,6 +203,13 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		case "VIDEO":
 			// changes quantum
 			dbg.quantum = QuantumVideo
+		case "SOURCE":
+			// does not change quantum
+			err := dbg.startStepSource()
+			if err != nil {
+				return err
+			}
+			dbg.runUntilHalt = true
 		default:
 			// does not change quantum
 			tokens.Unget()
@@ -309,6 +319,11 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 
 	case cmdInsert:
 		cart, _ := tokens.Get()
+
+		// a symbols file specified on the command line refers to the original
+		// cartridge only
+		dbg.SymbolsFile = ""
+
 		err := dbg.attachCartridge(cartridgeloader.NewLoader(cart, "AUTO"))
 		if err != nil {
 			return err
@@ -381,6 +396,32 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			dbg.printLine(terminal.StyleInstrument, dbg.VCS.Mem.Cart.String())
 		}
 
+	case cmdHotReload:
+		arg, ok := tokens.Get()
+		if !ok {
+			if dbg.isHotReloading() {
+				dbg.printLine(terminal.StyleFeedback, "cartridge will be reloaded when files change")
+			} else {
+				dbg.printLine(terminal.StyleFeedback, "cartridge will not be reloaded when files change")
+			}
+			return nil
+		}
+
+		switch strings.ToUpper(arg) {
+		case "ON":
+			keepState, _ := tokens.Get()
+			dbg.startHotReload(strings.ToUpper(keepState) == "KEEPSTATE")
+			if !dbg.isHotReloading() {
+				return curated.Errorf("cartridge has no file to watch")
+			}
+			dbg.printLine(terminal.StyleFeedback, "watching cartridge files for changes")
+		case "OFF":
+			dbg.stopHotReload()
+			dbg.printLine(terminal.StyleFeedback, "not watching cartridge files")
+		case "NOW":
+			return dbg.reloadCartridge()
+		}
+
 	case cmdPatch:
 		f, _ := tokens.Get()
 		patched, err := patch.CartridgeMemory(dbg.VCS.Mem.Cart, f)
@@ -395,6 +436,16 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			dbg.printLine(terminal.StyleFeedback, "cartridge patched")
 		}
 
+	case cmdAssemble:
+		arg, _ := tokens.Get()
+		if strings.ToUpper(arg) == "SAVE" {
+			f, _ := tokens.Get()
+			return dbg.saveAssembled(f)
+		}
+
+		mnemonic, _ := tokens.Get()
+		return dbg.assemble(arg, fmt.Sprintf("%s %s", mnemonic, tokens.Remainder()))
+
 	case cmdDisassembly:
 		bytecode := false
 		bank := -1
@@ -404,6 +455,51 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			switch arg {
 			case "BYTECODE":
 				bytecode = true
+			case "DASM":
+				filename, _ := tokens.Get()
+				f, err := os.Create(filename)
+				if err != nil {
+					return curated.Errorf("disassembly: %v", err)
+				}
+				defer f.Close()
+
+				err = dbg.Disasm.WriteDASM(f)
+				if err != nil {
+					return err
+				}
+				dbg.printLine(terminal.StyleFeedback, "DASM source written to %s", filename)
+				return nil
+			case "CFG":
+				format, _ := tokens.Get()
+				filename, _ := tokens.Get()
+
+				// graph for all banks unless a bank number is specified
+				if b, ok := tokens.Get(); ok {
+					bank, _ = strconv.Atoi(b)
+				}
+
+				cfg, err := dbg.Disasm.CFG(bank)
+				if err != nil {
+					return err
+				}
+
+				f, err := os.Create(filename)
+				if err != nil {
+					return curated.Errorf("disassembly: %v", err)
+				}
+				defer f.Close()
+
+				switch strings.ToUpper(format) {
+				case "DOT":
+					err = cfg.WriteDOT(f)
+				case "JSON":
+					err = cfg.WriteJSON(f)
+				}
+				if err != nil {
+					return err
+				}
+				dbg.printLine(terminal.StyleFeedback, "control flow graph written to %s (%d blocks)", filename, len(cfg.Blocks))
+				return nil
 			default:
 				bank, _ = strconv.Atoi(arg)
 			}
@@ -427,13 +523,239 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		dbg.printLine(terminal.StyleFeedback, s.String())
 
 	case cmdLint:
+		arg, ok := tokens.Get()
+		if ok && strings.ToUpper(arg) == "RULES" {
+			s := &strings.Builder{}
+			for _, r := range linter.Rules {
+				on := "off"
+				if dbg.lintPrefs.Enabled(r.ID) {
+					on = "on"
+				}
+				kind := "static"
+				if r.Runtime {
+					kind = "runtime"
+				}
+				s.WriteString(fmt.Sprintf("%-19s %-7s %-7s %-3s %s\n", r.ID, r.Severity, kind, on, r.Description))
+			}
+			dbg.printLine(terminal.StyleFeedback, s.String())
+			return nil
+		}
+
+		if ok && strings.ToUpper(arg) == "RUNTIME" {
+			arg, _ := tokens.Get()
+			switch strings.ToUpper(arg) {
+			case "ON":
+				if dbg.runtimeLinter == nil {
+					dbg.runtimeLinter = linter.NewRuntime(dbg.VCS.TV, dbg.Disasm.Symbols, dbg.lintPrefs)
+				}
+				dbg.printLine(terminal.StyleFeedback, "runtime linter is on")
+			case "OFF":
+				dbg.runtimeLinter = nil
+				dbg.printLine(terminal.StyleFeedback, "runtime linter is off")
+			case "RESET":
+				if dbg.runtimeLinter != nil {
+					dbg.runtimeLinter.Reset()
+				}
+				dbg.printLine(terminal.StyleFeedback, "runtime linter reset")
+			default:
+				if dbg.runtimeLinter == nil {
+					dbg.printLine(terminal.StyleFeedback, "runtime linter is off")
+					return nil
+				}
+				if len(dbg.runtimeLinter.Reports()) == 0 {
+					dbg.printLine(terminal.StyleFeedback, "no runtime lint findings")
+					return nil
+				}
+				dbg.runtimeLinter.Write(dbg.printStyle(terminal.StyleFeedback))
+			}
+			return nil
+		}
+
 		output := &strings.Builder{}
-		err := linter.Lint(dbg.Disasm, output)
+		err := linter.Lint(dbg.Disasm, dbg.VCS.Mem.Cart, dbg.lintPrefs, output)
 		if err != nil {
 			return err
 		}
+		if output.Len() == 0 {
+			dbg.printLine(terminal.StyleFeedback, "no lint issues")
+			return nil
+		}
 		dbg.printLine(terminal.StyleFeedback, output.String())
 
+	case cmdCoverage:
+		arg, ok := tokens.Get()
+		if !ok {
+			for _, cov := range dbg.Disasm.Coverage() {
+				dbg.printLine(terminal.StyleFeedback, cov.String())
+			}
+			return nil
+		}
+
+		switch strings.ToUpper(arg) {
+		case "RESET":
+			dbg.Disasm.ResetCoverage()
+			dbg.printLine(terminal.StyleFeedback, "coverage reset")
+
+		case "HOT":
+			n := 10
+			if arg, ok := tokens.Get(); ok {
+				v, err := strconv.ParseInt(arg, 0, 32)
+				if err != nil {
+					return curated.Errorf("coverage: invalid number of entries (%s)", arg)
+				}
+				if v < 0 {
+					return curated.Errorf("coverage: number of entries cannot be negative (%s)", arg)
+				}
+				n = int(v)
+			}
+			for _, e := range dbg.Disasm.HotEntries(n) {
+				dbg.printLine(terminal.StyleFeedback, "%10d %10d  bank %d %s %s %s",
+					e.ExecutionCount, e.ExecutionCycles, e.Bank.Number,
+					e.GetField(disassembly.FldAddress), e.GetField(disassembly.FldMnemonic),
+					strings.TrimSpace(e.GetField(disassembly.FldOperand)))
+			}
+
+		case "DEAD":
+			bank := dbg.VCS.Mem.Cart.GetBank(dbg.VCS.CPU.PC.Address()).Number
+			if arg, ok := tokens.Get(); ok {
+				v, err := strconv.ParseInt(arg, 0, 32)
+				if err != nil {
+					return curated.Errorf("coverage: invalid bank number (%s)", arg)
+				}
+				bank = int(v)
+			}
+			dead, err := dbg.Disasm.DeadEntries(bank)
+			if err != nil {
+				return err
+			}
+			if len(dead) == 0 {
+				dbg.printLine(terminal.StyleFeedback, "no unexecuted entries in bank %d", bank)
+			}
+			for _, e := range dead {
+				dbg.printLine(terminal.StyleFeedback, "%s %s %s",
+					e.GetField(disassembly.FldAddress), e.GetField(disassembly.FldMnemonic),
+					strings.TrimSpace(e.GetField(disassembly.FldOperand)))
+			}
+
+		case "EXPORT":
+			format := disassembly.CoverageText
+			if arg, ok := tokens.Get(); ok {
+				switch strings.ToUpper(arg) {
+				case "TEXT":
+				case "LCOV":
+					format = disassembly.CoverageLCOV
+				default:
+					tokens.Unget()
+				}
+			}
+
+			filename, ok := tokens.Get()
+			if !ok || filename == "" {
+				return curated.Errorf("coverage: no filename for export")
+			}
+			f, err := os.Create(filename)
+			if err != nil {
+				return curated.Errorf("coverage: %v", err)
+			}
+			defer f.Close()
+
+			err = dbg.Disasm.WriteCoverage(f, format)
+			if err != nil {
+				return err
+			}
+			dbg.printLine(terminal.StyleFeedback, "coverage written to %s", filename)
+		}
+
+	case cmdClassify:
+		arg, ok := tokens.Get()
+		if !ok {
+			sum := dbg.Disasm.ClassificationSummary()
+			for _, c := range []disassembly.Class{disassembly.ClassCode, disassembly.ClassData, disassembly.ClassGFX, disassembly.ClassVector} {
+				dbg.printLine(terminal.StyleFeedback, "%-6s %d bytes", c, sum[c])
+			}
+			return nil
+		}
+
+		switch strings.ToUpper(arg) {
+		case "RESET":
+			dbg.Disasm.ResetClassification()
+			dbg.printLine(terminal.StyleFeedback, "classification reset")
+		case "SAVE":
+			err := dbg.Disasm.SaveClassification()
+			if err != nil {
+				return err
+			}
+			dbg.printLine(terminal.StyleFeedback, "classification saved")
+		}
+
+	case cmdProfile:
+		arg, ok := tokens.Get()
+		if !ok {
+			if dbg.profiler == nil {
+				dbg.printLine(terminal.StyleFeedback, "profiler is off")
+				return nil
+			}
+			dbg.profiler.WriteSummary(dbg.printStyle(terminal.StyleFeedback), 10)
+			return nil
+		}
+
+		switch strings.ToUpper(arg) {
+		case "ON":
+			if dbg.profiler == nil {
+				dbg.profiler = profiler.NewProfiler(dbg.VCS.TV.GetSpec(), dbg.Disasm.Symbols)
+			}
+			dbg.printLine(terminal.StyleFeedback, "profiler is on")
+			return nil
+		case "OFF":
+			dbg.profiler = nil
+			dbg.printLine(terminal.StyleFeedback, "profiler is off")
+			return nil
+		}
+
+		if dbg.profiler == nil {
+			return curated.Errorf("profiler is not on")
+		}
+
+		switch strings.ToUpper(arg) {
+		case "RESET":
+			dbg.profiler.Reset()
+			dbg.printLine(terminal.StyleFeedback, "profiler reset")
+
+		case "FRAME":
+			fr, ok := dbg.profiler.LastFrame()
+			if arg, ok2 := tokens.Get(); ok2 {
+				fn, err := strconv.ParseInt(arg, 0, 32)
+				if err != nil {
+					return curated.Errorf("profiler: invalid frame number (%s)", arg)
+				}
+				fr, ok = dbg.profiler.Frame(int(fn))
+			}
+			if !ok {
+				return curated.Errorf("profiler: frame not available")
+			}
+			dbg.profiler.WriteFrame(dbg.printStyle(terminal.StyleFeedback), fr)
+
+		case "CALLS":
+			dbg.profiler.WriteCallGraph(dbg.printStyle(terminal.StyleFeedback))
+
+		case "EXPORT":
+			filename, ok := tokens.Get()
+			if !ok || filename == "" {
+				return curated.Errorf("profiler: no filename for export")
+			}
+			f, err := os.Create(filename)
+			if err != nil {
+				return curated.Errorf("profiler: %v", err)
+			}
+			defer f.Close()
+
+			err = dbg.profiler.WritePProf(f, dbg.VCS.Mem.Cart.Filename)
+			if err != nil {
+				return err
+			}
+			dbg.printLine(terminal.StyleFeedback, "profile written to %s", filename)
+		}
+
 	case cmdGrep:
 		scope := disassembly.GrepAll
 
@@ -511,6 +833,14 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		}
 
 	case cmdOnHalt:
+		// prefix for ONHALT feedback if there is a condition
+		cond := func() string {
+			if dbg.commandOnHaltCond == nil {
+				return ""
+			}
+			return fmt.Sprintf(" (%s)", dbg.commandOnHaltCond)
+		}
+
 		if tokens.Remaining() == 0 {
 			if len(dbg.commandOnHalt) == 0 {
 				dbg.printLine(terminal.StyleFeedback, "auto-command on halt: OFF")
@@ -520,27 +850,48 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 					s.WriteString(c.String())
 					s.WriteString("; ")
 				}
-				dbg.printLine(terminal.StyleFeedback, "command on halt: %s", strings.TrimSuffix(s.String(), "; "))
+				dbg.printLine(terminal.StyleFeedback, "command on halt%s: %s", cond(), strings.TrimSuffix(s.String(), "; "))
 			}
 			return nil
 		}
 
 		var input string
+		var newCond *condition
 
 		option, _ := tokens.Get()
 		switch strings.ToUpper(option) {
 		case "OFF":
 			dbg.commandOnHalt = dbg.commandOnHalt[:0]
+			dbg.commandOnHaltCond = nil
 			dbg.printLine(terminal.StyleFeedback, "no command on halt")
 			return nil
 
 		case "ON":
 			dbg.commandOnHalt = dbg.commandOnHaltStored
+			dbg.commandOnHaltCond = dbg.commandOnHaltCondStored
 			for _, c := range dbg.commandOnHalt {
-				dbg.printLine(terminal.StyleFeedback, "auto-command on halt: %s", c)
+				dbg.printLine(terminal.StyleFeedback, "auto-command on halt%s: %s", cond(), c)
 			}
 			return nil
 
+		case "IF":
+			// the condition is separated from the command sequence by the
+			// first comma
+			input = strings.TrimSpace(tokens.Remainder())
+			tokens.End()
+
+			s := strings.SplitN(input, ",", 2)
+			if len(s) < 2 || strings.TrimSpace(s[1]) == "" {
+				return curated.Errorf("no command specified for conditional ONHALT")
+			}
+
+			var err error
+			newCond, err = parseCondition(dbg, s[0])
+			if err != nil {
+				return err
+			}
+			input = strings.TrimSpace(s[1])
+
 		default:
 			// token isn't one we recognise so push it back onto the token queue
 			tokens.Unget()
@@ -567,6 +918,8 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 
 		// make a copy of
 		dbg.commandOnHaltStored = dbg.commandOnHalt
+		dbg.commandOnHaltCond = newCond
+		dbg.commandOnHaltCondStored = newCond
 
 		// display the new ONHALT command(s)
 		s := strings.Builder{}
@@ -574,7 +927,7 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			s.WriteString(c.String())
 			s.WriteString("; ")
 		}
-		dbg.printLine(terminal.StyleFeedback, "command on halt: %s", strings.TrimSuffix(s.String(), "; "))
+		dbg.printLine(terminal.StyleFeedback, "command on halt%s: %s", cond(), strings.TrimSuffix(s.String(), "; "))
 
 		return nil
 
@@ -768,6 +1121,103 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			dbg.printLine(terminal.StyleVideoStep, s.String())
 		}
 
+	case cmdSource:
+		address := dbg.VCS.CPU.PC.Address()
+		if arg, ok := tokens.Get(); ok {
+			ai := dbg.dbgmem.mapAddress(arg, true)
+			if ai == nil {
+				dbg.printLine(terminal.StyleError, "invalid address (%s)", arg)
+				return nil
+			}
+			address = ai.address
+		}
+
+		ln, ok := dbg.sourceLine(address)
+		if !ok {
+			dbg.printLine(terminal.StyleFeedback, "no source for %#04x", address)
+			return nil
+		}
+		dbg.printSource(ln)
+
+	case cmdLabel:
+		arg, _ := tokens.Get()
+		if strings.ToUpper(arg) == "LIST" {
+			dbg.listAnnotations(true)
+			return nil
+		}
+
+		bank, address, err := dbg.resolveAnnotation(arg)
+		if err != nil {
+			return err
+		}
+
+		label, _ := tokens.Get()
+		err = dbg.Disasm.Symbols.SetLabel(bank, address, label)
+		if err != nil {
+			return err
+		}
+
+		if label == "" {
+			dbg.printLine(terminal.StyleFeedback, "label removed from %#04x (bank %d)", address, bank)
+		} else {
+			dbg.printLine(terminal.StyleFeedback, "%#04x (bank %d) labelled %s", address, bank, label)
+		}
+
+	case cmdComment:
+		arg, _ := tokens.Get()
+		if strings.ToUpper(arg) == "LIST" {
+			dbg.listAnnotations(false)
+			return nil
+		}
+
+		bank, address, err := dbg.resolveAnnotation(arg)
+		if err != nil {
+			return err
+		}
+
+		s := strings.Builder{}
+		for t, ok := tokens.Get(); ok; t, ok = tokens.Get() {
+			s.WriteString(t)
+			s.WriteString(" ")
+		}
+
+		err = dbg.Disasm.Symbols.SetComment(bank, address, s.String())
+		if err != nil {
+			return err
+		}
+
+		if s.Len() == 0 {
+			dbg.printLine(terminal.StyleFeedback, "comment removed from %#04x (bank %d)", address, bank)
+		}
+
+	case cmdXref:
+		arg, _ := tokens.Get()
+		if strings.ToUpper(arg) != "RUNTIME" {
+			return dbg.printXrefs(arg)
+		}
+
+		option, ok := tokens.Get()
+		if !ok {
+			if dbg.Disasm.IsObservingXrefs() {
+				dbg.printLine(terminal.StyleFeedback, "runtime references are being recorded")
+			} else {
+				dbg.printLine(terminal.StyleFeedback, "runtime references are not being recorded")
+			}
+			return nil
+		}
+
+		switch strings.ToUpper(option) {
+		case "ON":
+			dbg.Disasm.SetXrefObservation(true)
+			dbg.printLine(terminal.StyleFeedback, "recording runtime references")
+		case "OFF":
+			dbg.Disasm.SetXrefObservation(false)
+			dbg.printLine(terminal.StyleFeedback, "not recording runtime references")
+		case "RESET":
+			dbg.Disasm.ResetXrefs()
+			dbg.printLine(terminal.StyleFeedback, "runtime references reset")
+		}
+
 	case cmdMemMap:
 		address, ok := tokens.Get()
 		if ok {
@@ -1004,6 +1454,15 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			dbg.printLine(terminal.StyleInstrument, dbg.tv.String())
 		}
 
+	case cmdScreenshot:
+		return dbg.screenshot(tokens)
+
+	case cmdClip:
+		return dbg.clip(tokens)
+
+	case cmdTracker:
+		return dbg.tracker(tokens)
+
 	// information about the machine (sprites, playfield)
 	case cmdPlayer:
 		plyr := -1
@@ -1467,6 +1926,9 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			dbg.printLine(terminal.StyleFeedback, dbg.VCS.Prefs.String())
 			dbg.printLine(terminal.StyleFeedback, dbg.Disasm.Prefs.String())
 			dbg.printLine(terminal.StyleFeedback, dbg.Rewind.Prefs.String())
+			dbg.printLine(terminal.StyleFeedback, dbg.lintPrefs.String())
+			dbg.printLine(terminal.StyleFeedback, dbg.tv.PalettePrefs.String())
+			dbg.printLine(terminal.StyleFeedback, dbg.crtFilterPrefs.String())
 			return nil
 		}
 
@@ -1484,6 +1946,18 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			if err != nil {
 				return curated.Errorf("%v", err)
 			}
+			err = dbg.lintPrefs.Load()
+			if err != nil {
+				return curated.Errorf("%v", err)
+			}
+			err = dbg.tv.PalettePrefs.Load()
+			if err != nil {
+				return curated.Errorf("%v", err)
+			}
+			err = dbg.crtFilterPrefs.Load()
+			if err != nil {
+				return curated.Errorf("%v", err)
+			}
 			return nil
 
 		case "SAVE":
@@ -1499,6 +1973,18 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			if err != nil {
 				return curated.Errorf("%v", err)
 			}
+			err = dbg.lintPrefs.Save()
+			if err != nil {
+				return curated.Errorf("%v", err)
+			}
+			err = dbg.tv.PalettePrefs.Save()
+			if err != nil {
+				return curated.Errorf("%v", err)
+			}
+			err = dbg.crtFilterPrefs.Save()
+			if err != nil {
+				return curated.Errorf("%v", err)
+			}
 			return nil
 
 		case "REWIND":
@@ -1515,6 +2001,81 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				return dbg.Rewind.Prefs.Freq.Set(freq)
 			}
 			return nil
+
+		case "PALETTE":
+			option, ok := tokens.Get()
+			if !ok {
+				dbg.printLine(terminal.StyleFeedback, dbg.tv.PalettePrefs.String())
+				return nil
+			}
+
+			option = strings.ToUpper(option)
+			arg, _ := tokens.Get()
+
+			var err error
+			switch option {
+			case "HUE":
+				err = dbg.tv.PalettePrefs.Hue.Set(arg)
+			case "SATURATION":
+				err = dbg.tv.PalettePrefs.Saturation.Set(arg)
+			case "CONTRAST":
+				err = dbg.tv.PalettePrefs.Contrast.Set(arg)
+			case "BRIGHTNESS":
+				err = dbg.tv.PalettePrefs.Brightness.Set(arg)
+			case "GAMMA":
+				err = dbg.tv.PalettePrefs.Gamma.Set(arg)
+			default:
+				p := dbg.tv.PalettePrefs.Palette(option)
+				if p == nil {
+					return curated.Errorf("no palette preference for %s", option)
+				}
+				err = p.Set(arg)
+			}
+			if err != nil {
+				return curated.Errorf("%v", err)
+			}
+			return nil
+
+		case "CRTFILTER":
+			option, ok := tokens.Get()
+			if !ok {
+				dbg.printLine(terminal.StyleFeedback, dbg.crtFilterPrefs.String())
+				return nil
+			}
+
+			var enabled *prefs.Bool
+			var level *prefs.Float
+
+			switch strings.ToUpper(option) {
+			case "CHROMABLEED":
+				enabled = &dbg.crtFilterPrefs.ChromaBleed
+				level = &dbg.crtFilterPrefs.ChromaBleedLevel
+			case "DOTCRAWL":
+				enabled = &dbg.crtFilterPrefs.DotCrawl
+				level = &dbg.crtFilterPrefs.DotCrawlLevel
+			case "SCANLINES":
+				enabled = &dbg.crtFilterPrefs.Scanlines
+				level = &dbg.crtFilterPrefs.ScanlinesBrightness
+			case "PERSISTENCE":
+				enabled = &dbg.crtFilterPrefs.Persistence
+				level = &dbg.crtFilterPrefs.PersistenceLevel
+			}
+
+			arg, _ := tokens.Get()
+
+			var err error
+			switch strings.ToUpper(arg) {
+			case "ON":
+				err = enabled.Set(true)
+			case "OFF":
+				err = enabled.Set(false)
+			default:
+				err = level.Set(arg)
+			}
+			if err != nil {
+				return curated.Errorf("%v", err)
+			}
+			return nil
 		}
 
 		var err error
@@ -1562,6 +2123,21 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				v := dbg.Disasm.Prefs.Symbols.Get().(bool)
 				err = dbg.Disasm.Prefs.Symbols.Set(!v)
 			}
+		case "LINT":
+			rule, _ := tokens.Get()
+			b, rerr := dbg.lintPrefs.Rule(rule)
+			if rerr != nil {
+				return rerr
+			}
+			switch action {
+			case "SET":
+				err = b.Set(true)
+			case "UNSET":
+				err = b.Set(false)
+			case "TOGGLE":
+				v := b.Get().(bool)
+				err = b.Set(!v)
+			}
 		}
 
 		if err != nil {
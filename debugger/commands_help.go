@@ -41,7 +41,12 @@ command.
 
 In the above example, the emulation will run until the next frame is reached.
 Think of target stepping as a single use trap. Note that breakpoints, watches
-and traps still trigger a halt during a target step.`,
+and traps still trigger a halt during a target step.
+
+If source information is available for the cartridge (see the SOURCE command)
+then the SOURCE argument will run the emulation until the next source line is
+reached. In other words, the emulation will halt at the first instruction that
+belongs to a different source line.`,
 
 	cmdQuantum: `Change or view stepping quantum. The stepping quantum defines the frequency
 at which the emulation is checked and reported upon by the debugger.
@@ -84,11 +89,144 @@ cartridge will be loaded from disk.`,
 will show where the game was loaded from, the cartridge type and bank number. The BANK
 argument meanwhile can be used to switch banks (if possible).`,
 
-	cmdPatch: "Apply a patch file to the loaded cartridge",
+	cmdHotReload: `Reload the cartridge whenever the cartridge file or the symbols file changes
+on disk. This is useful when developing a cartridge because the cartridge can be
+reassembled without restarting the debugger.
+
+	HOTRELOAD ON
+
+On reload the machine is reset with the new cartridge and the symbols and
+disassembly are reloaded. Breakpoints on labelled addresses are moved to the
+new address of the label.
+
+The KEEPSTATE argument restores the contents of VCS RAM after the cartridge has
+been reloaded. The console panel settings are never reset on reload.
+
+	HOTRELOAD ON KEEPSTATE
+
+HOTRELOAD NOW reloads the cartridge immediately, whether or not the files have
+changed. HOTRELOAD OFF stops watching the files.`,
+
+	cmdPatch: "Apply a patch file to the loaded cartridge. Text, IPS and BPS patch files are supported",
+
+	cmdAssemble: `Assemble a single 6502 instruction and write it to the cartridge at the
+specified address. The address can be given numerically or as a label and refers
+to the bank currently mapped into the address.
+
+	ASSEMBLE 0xf010 LDA #$80
+	ASSEMBLE kernel STA WSYNC
+	ASSEMBLE 0xf020 BNE kernel
+
+Symbols can be used in the operand. The low and high bytes of a value can be
+taken with the < and > prefixes, for example LDA #<kernel. Undocumented
+instructions must be given in lower case.
+
+The address of the next instruction is printed after each instruction has been
+assembled.
+
+Everything assembled since the cartridge was inserted can be saved as a patch
+file, suitable for the PATCH command, with ASSEMBLE SAVE.
+
+	ASSEMBLE SAVE fix.patch`,
 
 	cmdDisassembly: `Display cartridge disassembly. By default, all banks will be displayed. Single
 banks can be displayed by specifying the bank number. Use BYTECODE to display raw bytes alongside
-the disassembly.`,
+the disassembly.
+
+The DASM argument writes the disassembly of the entire cartridge to file, as source code that
+can be reassembled with DASM. Blessed instructions are output as instructions, everything else
+as data bytes, such that reassembling the source produces a binary identical to the original.
+
+	DISASSEMBLY DASM game.asm
+
+The CFG argument writes the control flow graph of the disassembly to file, either in the Graphviz
+DOT language or as JSON. The graph is made up of basic blocks of instructions, connected by
+fallthrough, branch, jump, indirect jump, call and return edges. Accesses of bank switching
+hotspots end a block and are shown as edges to the bank being switched to. The graph is for all
+banks unless a bank number is specified.
+
+	DISASSEMBLY CFG DOT kernel.dot 0`,
+
+	cmdLint: `Check the cartridge for common programming errors. With no arguments, the
+disassembly is checked against the static lint rules: reads from TIA and RIOT
+addresses that can only be written to, undocumented opcodes, indirect JMP
+vectors on a page boundary, subroutine calls and pushes that overflow the stack
+into TIA space, writes to cartridge addresses that are not hotspots or
+cartridge RAM, unreachable code, and loop branches that cross a page boundary.
+
+The RULES argument lists every rule, its severity and whether it is enabled.
+Rules can be enabled and disabled with the PREFS command.
+
+	PREFS UNSET LINT undocumented-opcode
+
+The RUNTIME argument controls the runtime linter. Once turned on with the ON
+argument, the runtime linter watches the emulation for timing problems: frames
+with the wrong number of scanlines, VSYNC not lasting the number of scanlines
+required by the television specification, RIOT timers that have expired before
+being read, and HMOVE and VBLANK writes that may cause visual artefacts.
+
+Findings are listed frame by frame with the address of the instruction that
+caused the problem, and the nearest label if there is one. The list can be
+cleared with the RESET argument.
+
+	LINT RUNTIME ON
+	LINT RUNTIME`,
+
+	cmdCoverage: `Show execution coverage information for the cartridge. Every time an
+instruction in the cartridge is executed, the execution count and the number of
+cycles consumed is noted. With no arguments, COVERAGE prints a summary of each
+cartridge bank.
+
+The HOT argument lists the instructions that have consumed the most cycles. By
+default the ten hottest instructions are listed but this can be changed by
+specifying a number.
+
+The DEAD argument lists the instructions in the bank that have never been
+executed. If no bank is specified then the current bank is used.
+
+The coverage information can be reset with the RESET argument. Use the EXPORT
+argument to write a coverage report to a file. The TEXT format is a listing of
+the disassembly with the execution count and cycles for each instruction. The
+LCOV format is similar to the tracefile format used by the LCOV tool, with each
+bank treated as a source file and each address treated as a line number.
+
+	COVERAGE EXPORT LCOV coverage.info`,
+
+	cmdClassify: `Show how the bytes in the cartridge have been classified. As the emulation
+runs, every cartridge byte is classified according to how it is accessed: CODE
+bytes have been executed; DATA bytes have been read by an instruction; GFX bytes
+have been read and then written to one of the TIA graphics registers; and
+VECTOR bytes have been used as the address for an indirect JMP.
+
+Bytes that have been classified as data, but never executed, are shown as data
+in the disassembly.
+
+The classification is saved when the cartridge is changed or when the debugger
+exits, and restored the next time the same cartridge is loaded. Use the SAVE
+argument to save the classification immediately. The RESET argument forgets the
+classification of every byte.`,
+
+	cmdProfile: `Profile the CPU cycles consumed by the cartridge. Cycles are attributed
+to the nearest preceding label in the symbols table and to the scanline on
+which the instruction began. Cycles spent waiting for WSYNC are noted
+separately.
+
+The profiler must be turned on with the ON argument. With no arguments,
+PROFILE shows the average usage of each region of the screen (VSYNC, VBLANK,
+visible and overscan) compared to the budget suggested by the television
+specification. The functions that have consumed the most cycles are also
+listed.
+
+The FRAME argument shows the cycle usage of every scanline in a frame. If no
+frame number is given then the most recently completed frame is shown. Only a
+limited number of frames are kept by the profiler.
+
+The CALLS argument lists the call graph, as built from JSR and RTS
+instructions. The EXPORT argument writes the cumulative profile to a file in
+the pprof format. The file can be viewed with the pprof tool.
+
+	PROFILE EXPORT kernel.pprof
+	go tool pprof -top kernel.pprof`,
 
 	cmdGrep: `Simple string search (case insensitive) of the disassembly. Prints all matching lines
 in the disassembly to the termain.
@@ -120,12 +258,75 @@ Note that a cartridge without an accompanying symbols file will only have the
 canonical Atari VCS symbols defined and possibly symbols associated with a
 particular cartridge type.`,
 
+	cmdSource: `Show the source line for an address, along with the lines around it. If no
+address is given then the address of the current instruction is used.
+
+Source information is read from the symbols files for the cartridge. DASM
+listing files, ca65 debug information files and batari Basic listing files
+provide source information. If the original source files can be found in the
+same directory as the symbols file then they will be used for the text of the
+source lines.`,
+
+	cmdLabel: `Add a label to a cartridge address. The label is added to the bank currently
+mapped into the address. An existing label for the address, including a label
+from the symbols file, is replaced.
+
+	LABEL 0xf010 kernel
+
+The address can be given as an existing label, in which case the label is
+renamed.
+
+	LABEL kernel drawPlayfield
+
+If no label is given then the user label is removed. LABEL LIST shows all user
+labels.
+
+User labels are saved as soon as they are added and are reloaded whenever the
+same cartridge is loaded again. They appear in the disassembly and in the
+results of the GREP command.`,
+
+	cmdComment: `Add a comment to a cartridge address. The comment is added to the bank
+currently mapped into the address. An existing comment for the address is
+replaced.
+
+	COMMENT 0xf010 wait for end of vblank
+
+If no comment is given then the existing comment is removed. COMMENT LIST shows
+all comments.
+
+Like user labels, comments are saved as soon as they are added and are reloaded
+whenever the same cartridge is loaded again.`,
+
+	cmdXref: `List every instruction that refers to an address. The address can be given
+numerically or as any symbol or label.
+
+	XREF 0x80
+	XREF WSYNC
+
+Each reference is listed with the bank and address of the instruction and how
+the address is referred to: read, write, read/write, jump or branch.
+
+References are found from the operands of the instructions in the disassembly.
+The effective address of indexed and indirect instructions is not known from
+the disassembly alone so references can also be recorded as the emulation runs.
+XREF RUNTIME ON turns recording on and XREF RUNTIME OFF turns it off again.
+Recorded references are forgotten with XREF RUNTIME RESET. References that were
+only found by recording are marked as runtime references.`,
+
 	cmdOnHalt: `Define commands to run whenever emulation is halted. A halt is
 caused by a BREAK, a TRAP, a WATCH or a manual interrupt. Specify multiple
 commands by separating with a comma.
 
 THE OFF argument can be used to toggle the ONHALT commands temporarily. Use the
-ON argument to resume ONSTEP reporting.`,
+ON argument to resume ONSTEP reporting.
+
+The ONHALT commands can be made to run only when a condition is met. The
+condition is introduced with the IF keyword and is separated from the commands
+by a comma. For example:
+
+	ONHALT IF PEEK(0x80) == 0, CPU, TIA
+
+See the help for the BREAK command for a description of conditions.`,
 
 	cmdOnStep: `Define commands to run whenever emulation moves forward one step. A step
 is defined by the QUANTUM command. Specify multiple commands by separating with
@@ -189,7 +390,50 @@ information about the RIOT ports (SWCHA, etc.)`,
 	cmdTV: `Display the current TV state. Optional argument SPEC will display the currently
 selected TV specification. Supplying an argument to the TV SPEC command will set the TV to that
 specification. AUTO indicates that the specification will change if the condition of the TV signal
-suggest that it should.`,
+suggest that it should.
+
+As well as NTSC and PAL, the PAL60, PAL-M, PAL-N and SECAM specifications can be selected. These
+specifications differ from NTSC or PAL only in the palette and so are never selected by AUTO.`,
+
+	cmdScreenshot: `Save the current television image to a PNG file. If no filename is given then
+a unique filename is created from the cartridge name. The image is cropped to the visible screen
+unless the FULL argument is given, in which case the entire signal, including the blanking areas,
+is saved. The DEBUG argument will save the image using debug colors.
+
+Note that the image may include part of the frame currently being drawn.
+
+Frames can also be saved automatically as the emulation runs with the AUTO argument, followed by a
+comma separated list of options. AUTO OFF stops the automatic saving of frames. The options are:
+
+	frame=N       save frame N
+	every=N       save every Nth frame
+	range=N-M     save frames N to M (M can be omitted)
+	full          save the entire signal
+	debug         use debug colors
+	name=prefix   prefix for the filenames
+
+	SCREENSHOT AUTO every=10,range=100-200`,
+
+	cmdClip: `The most recent frames are kept so that they can be saved as a short clip. With no
+arguments, the number of frames currently kept is printed. The LENGTH argument sets the number of
+seconds to keep. Changing the length empties the buffer.
+
+The SAVE argument saves the clip as an animated GIF or animated PNG, depending on the extension of
+the filename. If no filename is given then a GIF file with a unique filename is created.
+
+Note that the clip will only contain frames that have been completed.`,
+
+	cmdTracker: `Writes to the audio registers (AUDC0/1, AUDF0/1, AUDV0/1) are recorded along with the
+frame, scanline and horizontal position of the write. With no arguments, the most recent writes
+are printed. The LAST argument prints the specified number of writes. CLEAR forgets all writes.
+
+The SAVE argument saves the writes to a file. The format can be TEXT or CSV. If no format is
+given then it is chosen from the extension of the filename (.csv for CSV). If no filename is
+given then a unique filename is created.
+
+Entries are printed in the form:
+
+	frame scanline clock register  AUDC0 @ AUDF0 ^ AUDV0  AUDC1 @ AUDF1 ^ AUDV1`,
 
 	cmdPlayer: `Display the current state of the player sprites. The player information to
 display can be selected with 0 or 1 arguments. Omitting this argument will show
@@ -409,6 +653,15 @@ Becomes:
 
 	BREAK PC <address> & BANK <current bank>
 
+If source information is available for the cartridge (see the SOURCE command)
+then the address can be given as a reference to a source line. For example:
+
+	BREAK kernel.asm:123
+
+If line 123 does not generate any code then the next line that does is used.
+The bank condition is the bank which contains the code for the line, rather
+than the current bank.
+
 A break can depend on the condition of more than one target. Specify complex
 conditions with the & operative. For example:
 
@@ -428,6 +681,41 @@ execution breaks on SL 10 & X 255. After resumption, the break will not apply
 until X changes from 255 to something else and then back again, or SL is hit on
 the next frame and X again (or still) has a value of 255.i
 
+More complex conditions can be specified with the IF keyword. A conditional
+break will halt execution when the condition becomes true. For example:
+
+	BREAK IF PEEK($80) & $0F == 3
+
+Conditions are expressions that can make use of arithmetic (+ - * /), bit
+operations (& | ^ ~ << >>), comparisons (== != < <= > >=) and logical
+operators (&& || !). Operator precedence is the same as for the Go language,
+meaning that bit operations are performed before comparisons. Numbers can be
+written in decimal, in hex (with a $ or 0x prefix) or in binary (with a % or 0b
+prefix).
+
+Expressions can refer to any of the targets listed above, in addition to the
+following:
+
+	CPU STATUS register (or P)
+	WSYNCWAIT (true if the CPU is waiting for WSYNC)
+	the TV signal (TVVSYNC, TVVBLANK)
+	the TIA state (HBLANK, HMOVELATCH)
+
+Symbols can also be used and are evaluated as their address. The PEEK()
+function returns the value stored at an address. For example, PEEK(VBLANK)
+reads the VBLANK register while TVVBLANK is the state of the TV signal.
+
+	BREAK IF PEEK(SCORE) > 9 && SL > 200
+
+A condition can be made to apply only after it has been met a number of times
+with the HITS keyword. The following will halt execution on the 10th time the
+X register is loaded with zero:
+
+	BREAK IF X == 0 HITS 10
+
+The number of times a condition has been met is shown by the LIST command.
+Conditions can also be used with TRAP, WATCH and ONHALT.
+
 Existing breakpoints can be reviewed with the LIST command and deleted with the
 DROP or CLEAR commands`,
 
@@ -436,6 +724,13 @@ to any other value. Traps are very similar to breakpoints in some ways.  They
 can be applied to the same set of targets as BREAK (see help for BREAK command
 for details).
 
+A trap can also be specified with the IF keyword, in which case execution will
+halt whenever the value of the expression changes. For example:
+
+	TRAP IF PEEK($80) & $80
+
+See the help for BREAK for details about conditions and expressions.
+
 Existing traps can be reviewed with the LIST command and deleted with the
 DROP or CLEAR commands`,
 
@@ -482,6 +777,13 @@ the equivalent (or mirror) or the one specified. For example:
 Will watch for a read access of the cartridge address 0xf000 or any of it's mirrors. In this instance,
 if the CPU attempts to read 0x1000, the watch will match.
 
+A watch can be made conditional with the IF keyword. The watch will only match
+if the condition is also met at the time of the memory access. For example:
+
+	WATCH WRITE 0x80 IF SL > 100
+
+See the help for BREAK for details about conditions and expressions.
+
 Existing watches can be reviewed with the LIST command and deleted with the
 DROP or CLEAR commands`,
 
@@ -505,7 +807,29 @@ about an address.`,
 	cmdClear: "Clear all BREAKS, TRAPS, WATCHES and TRACES.",
 
 	// meta
-	cmdPrefs: "Set preferences for debugger.",
+	cmdPrefs: `Set preferences for debugger. With no arguments, the current preferences are
+printed. LOAD and SAVE will load and save the preferences from and to disk.
+
+Individual lint rules are enabled and disabled with the LINT argument and the
+ID of the rule, as listed by LINT RULES.
+
+	PREFS TOGGLE LINT branch-page-cross
+
+The palette used for each TV specification is set with the PALETTE argument.
+The palette can be "canonical", "parametric" or the filename of a Stella
+palette file. The parametric palette is adjusted with the HUE, SATURATION,
+CONTRAST, BRIGHTNESS and GAMMA arguments.
+
+	PREFS PALETTE NTSC parametric
+	PREFS PALETTE HUE 5.0
+
+The software CRT filter, used by SCREENSHOT CRT and by the "crt" screenshot
+option, is adjusted with the CRTFILTER argument. Each effect can be turned
+ON or OFF or given a level between 0.0 and 1.0. For SCANLINES the level is
+the brightness of the gap between scanlines.
+
+	PREFS CRTFILTER DOTCRAWL OFF
+	PREFS CRTFILTER PERSISTENCE 0.6`,
 	cmdLog: `Print log to terminal. The LAST argument will cause the most recent log entry to be printed.
 
 Note that while "ONSTEP LOG LAST" is a valid construct it may not print what you expect - it will always print the last
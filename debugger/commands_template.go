@@ -29,11 +29,20 @@ const (
 
 	cmdInsert      = "INSERT"
 	cmdCartridge   = "CARTRIDGE"
+	cmdHotReload   = "HOTRELOAD"
 	cmdPatch       = "PATCH"
+	cmdAssemble    = "ASSEMBLE"
 	cmdDisassembly = "DISASSEMBLY"
 	cmdLint        = "LINT"
+	cmdCoverage    = "COVERAGE"
+	cmdClassify    = "CLASSIFY"
+	cmdProfile     = "PROFILE"
 	cmdGrep        = "GREP"
 	cmdSymbol      = "SYMBOL"
+	cmdSource      = "SOURCE"
+	cmdLabel       = "LABEL"
+	cmdComment     = "COMMENT"
+	cmdXref        = "XREF"
 	cmdOnHalt      = "ONHALT"
 	cmdOnStep      = "ONSTEP"
 	cmdOnTrace     = "ONTRACE"
@@ -47,6 +56,9 @@ const (
 	cmdRIOT        = "RIOT"
 	cmdAudio       = "AUDIO"
 	cmdTV          = "TV"
+	cmdScreenshot  = "SCREENSHOT"
+	cmdClip        = "CLIP"
+	cmdTracker     = "TRACKER"
 	cmdPlayer      = "PLAYER"
 	cmdMissile     = "MISSILE"
 	cmdBall        = "BALL"
@@ -83,7 +95,7 @@ var commandTemplate = []string{
 	cmdQuit,
 
 	cmdRun,
-	cmdStep + " (CPU|VIDEO|%<target>S)",
+	cmdStep + " (CPU|VIDEO|SOURCE|%<target>S)",
 	cmdHalt,
 	cmdQuantum + " (CPU|VIDEO)",
 	cmdScript + " [RECORD %<new file>F|END|%<file>F]",
@@ -91,12 +103,21 @@ var commandTemplate = []string{
 
 	cmdInsert + " %<cartridge>F",
 	cmdCartridge + " (BANK|STATIC|REGISTERS|RAM)",
+	cmdHotReload + " (ON (KEEPSTATE)|OFF|NOW)",
 	cmdPatch + " %<patch file>S",
-	cmdDisassembly + " (BYTECODE) (%<bank num>N)",
-	cmdLint,
+	cmdAssemble + " [SAVE %<patch file>S|%<address>S %<mnemonic>S {%<operand>S}]",
+	cmdDisassembly + " (BYTECODE|DASM %<file>F|CFG [DOT|JSON] %<file>F) (%<bank num>N)",
+	cmdLint + " (RULES|RUNTIME (ON|OFF|RESET))",
+	cmdCoverage + " (RESET|HOT (%<number>N)|DEAD (%<bank>N)|EXPORT (TEXT|LCOV) %<file>F)",
+	cmdClassify + " (RESET|SAVE)",
+	cmdProfile + " (ON|OFF|RESET|FRAME (%<frame>N)|CALLS|EXPORT %<file>F)",
 	cmdGrep + " (MNEMONIC|OPERAND) %<search>S",
 	cmdSymbol + " [LIST (LABELS|READ|WRITE)|%<symbol>S (ALL|MIRRORS)]",
-	cmdOnHalt + " (OFF|ON|%<command>S {%<commands>S})",
+	cmdSource + " (%<address>S)",
+	cmdLabel + " [LIST|%<address>S (%<label>S)]",
+	cmdComment + " [LIST|%<address>S {%<comment>S}]",
+	cmdXref + " [RUNTIME (ON|OFF|RESET)|%<address>S]",
+	cmdOnHalt + " (OFF|ON|IF %<expression>S {%<commands>S}|%<command>S {%<commands>S})",
 	cmdOnStep + " (OFF|ON|%<command>S {%<commands>S})",
 	cmdOnTrace + " (OFF|ON|%<command>S {%<commands>S})",
 	cmdLast + " (DEFN|BYTECODE)",
@@ -108,7 +129,10 @@ var commandTemplate = []string{
 	cmdTIA,
 	cmdRIOT + " (PORTS|TIMER)",
 	cmdAudio,
-	cmdTV + " (SPEC (PAL|NTSC|AUTO))",
+	cmdTV + " (SPEC (NTSC|PAL|PAL60|PAL-M|PAL-N|SECAM|AUTO))",
+	cmdScreenshot + " (AUTO (OFF|%<options>S)|FULL (DEBUG) (%<file>F)|DEBUG (%<file>F)|CRT (%<file>F)|%<file>F)",
+	cmdClip + " (LENGTH %<seconds>N|SAVE (%<file>F))",
+	cmdTracker + " (LAST %<number>N|CLEAR|SAVE (TEXT|CSV) (%<file>F))",
 	cmdPlayer + " (0|1)",
 	cmdMissile + " (0|1)",
 	cmdBall,
@@ -125,17 +149,17 @@ var commandTemplate = []string{
 	cmdKeyboard + " [0|1] [none|0|1|2|3|4|5|6|7|8|9|*|#]",
 
 	// halt conditions
-	cmdBreak + " [%<pc value>S|%<target>S %<value>N] {& %<value>S|%<target>S %<value>S}",
+	cmdBreak + " [IF %<expression>S {%<expression>S}|%<pc value>S|%<target>S %<value>N] {& %<value>S|%<target>S %<value>S}",
 
-	cmdTrap + " [%<target>S] {%<targets>S}",
-	cmdWatch + " (READ|WRITE) (MIRRORS|ANY) [%<address>S] (%<value>S)",
+	cmdTrap + " [IF %<expression>S {%<expression>S}|%<target>S] {%<targets>S}",
+	cmdWatch + " (READ|WRITE) (MIRRORS|ANY) [%<address>S] (IF %<expression>S {%<expression>S}|%<value>S (IF %<expression>S {%<expression>S}))",
 	cmdTrace + " (%<address>S)",
 	cmdList + " [BREAKS|TRAPS|WATCHES|TRACES|ALL]",
 	cmdDrop + " [BREAK|TRAP|WATCH|TRACE] %<number in list>N",
 	cmdClear + " [BREAKS|TRAPS|WATCHES|TRACES|ALL]",
 
 	// emulation
-	cmdPrefs + " ([LOAD|SAVE]|[SET|UNSET|TOGGLE] [RANDSTART|RANDPINS|FXXXMIRROR|SYMBOLS]|REWIND [MAX %<entries>N|FREQ %<frames>N])",
+	cmdPrefs + " ([LOAD|SAVE]|[SET|UNSET|TOGGLE] [RANDSTART|RANDPINS|FXXXMIRROR|SYMBOLS|LINT %<rule>S]|REWIND [MAX %<entries>N|FREQ %<frames>N]|PALETTE ([NTSC|PAL|PAL60|PAL-M|PAL-N|SECAM] %<palette>F|[HUE|SATURATION|CONTRAST|BRIGHTNESS|GAMMA] %<value>S)|CRTFILTER ([CHROMABLEED|DOTCRAWL|SCANLINES|PERSISTENCE] %<value>S))",
 	cmdLog + " (LAST|RECENT|CLEAR)",
 	cmdMemUsage,
 }
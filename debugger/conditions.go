@@ -0,0 +1,155 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// conditions are expressions that can be attached to breakpoints, traps,
+// watches and the ONHALT command. they are introduced on the command line with
+// the IF keyword. see the expression package for a description of the
+// expression language.
+
+package debugger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/expression"
+	"github.com/jetsetilly/gopher2600/debugger/terminal/commandline"
+	"github.com/jetsetilly/gopher2600/symbols"
+)
+
+// condition wraps an expression with a hit counter.
+type condition struct {
+	expr *expression.Expression
+
+	// the number of times the condition has been met
+	hits int
+
+	// the condition is only considered to have been met once the number of
+	// hits reaches this value. a value of zero or one means the condition is
+	// met every time
+	hitsRequired int
+}
+
+func (cnd condition) String() string {
+	if cnd.hitsRequired > 1 {
+		return fmt.Sprintf("if %s hits %d", cnd.expr, cnd.hitsRequired)
+	}
+	return fmt.Sprintf("if %s", cnd.expr)
+}
+
+// hit increases the hit count and returns true if the required number of hits
+// has been reached.
+func (cnd *condition) hit() bool {
+	cnd.hits++
+	return cnd.hits >= cnd.hitsRequired
+}
+
+// test evaluates the condition and counts a hit if the result is true.
+// returns true if the expression is true and the required number of hits has
+// been reached. evaluation errors are treated as false.
+func (cnd *condition) test() bool {
+	t, err := cnd.expr.True()
+	if err != nil || !t {
+		return false
+	}
+	return cnd.hit()
+}
+
+// parseCondition parses the string as a condition. the string should not
+// include the IF keyword. the optional HITS suffix specifies the number of
+// times the condition must be met before it succeeds.
+func parseCondition(dbg *Debugger, s string) (*condition, error) {
+	cnd := &condition{}
+
+	f := strings.Fields(s)
+	if len(f) >= 2 && strings.ToUpper(f[len(f)-2]) == "HITS" {
+		n, err := strconv.Atoi(f[len(f)-1])
+		if err != nil || n < 1 {
+			return nil, curated.Errorf("invalid hit count (%s)", f[len(f)-1])
+		}
+		cnd.hitsRequired = n
+		s = strings.Join(f[:len(f)-2], " ")
+	}
+
+	var err error
+	cnd.expr, err = expression.Parse(s, &exprResolver{dbg: dbg})
+	if err != nil {
+		return nil, err
+	}
+
+	// evaluate the expression once to make sure it is sensible
+	if _, err := cnd.expr.Eval(); err != nil {
+		return nil, err
+	}
+
+	return cnd, nil
+}
+
+// parseConditionTokens is a convenience function that parses all remaining
+// tokens as a condition.
+func parseConditionTokens(dbg *Debugger, tokens *commandline.Tokens) (*condition, error) {
+	s := tokens.Remainder()
+	tokens.End()
+	return parseCondition(dbg, s)
+}
+
+// exprResolver implements the expression.Resolver interface.
+type exprResolver struct {
+	dbg *Debugger
+}
+
+// Resolve implements the expression.Resolver interface. Names are first
+// checked against the list of debugger targets. If the name is not a target
+// then it is looked up in the symbol tables.
+func (res *exprResolver) Resolve(name string) (expression.Value, string, error) {
+	if tgt, err := parseTarget(res.dbg, commandline.TokeniseInput(name)); err == nil && tgt != nil {
+		switch tgt.TargetValue().(type) {
+		case int:
+			return func() (int, error) {
+				return tgt.TargetValue().(int), nil
+			}, strings.ToUpper(name), nil
+		case bool:
+			return func() (int, error) {
+				if tgt.TargetValue().(bool) {
+					return 1, nil
+				}
+				return 0, nil
+			}, strings.ToUpper(name), nil
+		default:
+			return nil, "", curated.Errorf("target is not numeric (%s)", tgt.Label())
+		}
+	}
+
+	// the value of a symbol is its address
+	if ok, _, _, addr := res.dbg.dbgmem.symbols.Search(name, symbols.UnspecifiedSymTable); ok {
+		v := int(addr)
+		return func() (int, error) {
+			return v, nil
+		}, name, nil
+	}
+
+	return nil, "", curated.Errorf("unrecognised identifier (%s)", name)
+}
+
+// Peek implements the expression.Resolver interface.
+func (res *exprResolver) Peek(address uint16) (uint8, error) {
+	ai, err := res.dbg.dbgmem.peek(address)
+	if err != nil {
+		return 0, err
+	}
+	return ai.data, nil
+}
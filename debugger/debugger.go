@@ -21,6 +21,8 @@ import (
 	"strings"
 
 	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/clipwriter"
+	"github.com/jetsetilly/gopher2600/crtfilter"
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/debugger/script"
 	"github.com/jetsetilly/gopher2600/debugger/terminal"
@@ -36,11 +38,15 @@ import (
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports/savekey"
 	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/linter"
 	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/pngwriter"
+	"github.com/jetsetilly/gopher2600/profiler"
 	"github.com/jetsetilly/gopher2600/reflection"
 	"github.com/jetsetilly/gopher2600/rewind"
 	"github.com/jetsetilly/gopher2600/setup"
 	"github.com/jetsetilly/gopher2600/symbols"
+	"github.com/jetsetilly/gopher2600/tracker"
 )
 
 // Debugger is the basic debugging frontend for the emulation. In order to be
@@ -52,6 +58,20 @@ type Debugger struct {
 	VCS    *hardware.VCS
 	Disasm *disassembly.Disassembly
 
+	// the symbols file to use with the cartridge. if empty then symbols
+	// files with the same name as the cartridge will be used
+	SymbolsFile string
+
+	// reload the cartridge whenever the cartridge file or symbols file
+	// changes. see HOTRELOAD command
+	HotReload bool
+
+	// the loader used to attach the current cartridge
+	cartload cartridgeloader.Loader
+
+	// watches cartridge and symbols files for changes
+	hotReload hotReload
+
 	// the bank and formatted result of the last step (cpu or video)
 	lastBank   mapper.BankInfo
 	lastResult *disassembly.Entry
@@ -77,15 +97,48 @@ type Debugger struct {
 	watches     *watches
 	traces      *traces
 
+	// cycle profiler. nil if profiling is not active
+	profiler *profiler.Profiler
+
+	// runtime linter. nil if runtime linting is not active
+	runtimeLinter *linter.Runtime
+
+	// which lint rules are enabled. used by the LINT command and by the
+	// runtime linter
+	lintPrefs *linter.Preferences
+
 	// single-fire step traps. these are used for the STEP command, allowing
 	// things like "STEP FRAME".
 	stepTraps *traps
 
+	// stepping by source line. see STEP SOURCE
+	stepSource stepSource
+
+	// bytes written to the cartridge by the ASSEMBLE command, keyed by
+	// cartridge offset. saved as a patch file with ASSEMBLE SAVE
+	assembled map[int]uint8
+
+	// writes the television image to PNG files. see SCREENSHOT command
+	pngWriter *pngwriter.PNGWriter
+
+	// preferences for the software CRT filter used by the pngWriter
+	crtFilterPrefs *crtfilter.Preferences
+
+	// rolling buffer of recent frames. see CLIP command
+	clipWriter *clipwriter.ClipWriter
+
+	// record of writes to the audio registers. see TRACKER command
+	Tracker *tracker.Tracker
+
 	// commandOnHalt is the sequence of commands that runs when emulation
 	// halts
 	commandOnHalt       []*commandline.Tokens
 	commandOnHaltStored []*commandline.Tokens
 
+	// the ONHALT sequence is only run if the condition is met. can be nil
+	commandOnHaltCond       *condition
+	commandOnHaltCondStored *condition
+
 	// commandOnStep is the command to run afer every cpu/video cycle
 	commandOnStep       []*commandline.Tokens
 	commandOnStepStored []*commandline.Tokens
@@ -152,6 +205,8 @@ func NewDebugger(tv *television.Television, scr gui.GUI, term terminal.Terminal,
 
 		// create a minimal lastResult for initialisation
 		lastResult: &disassembly.Entry{Result: execution.Result{Final: true}},
+
+		assembled: make(map[int]uint8),
 	}
 
 	// create a new VCS instance
@@ -181,12 +236,40 @@ func NewDebugger(tv *television.Television, scr gui.GUI, term terminal.Terminal,
 	// function.
 	dbg.dbgmem = &memoryDebug{vcs: dbg.VCS, symbols: dbg.Disasm.Symbols}
 
+	// lint rule preferences
+	dbg.lintPrefs, err = linter.NewPreferences()
+	if err != nil {
+		return nil, curated.Errorf("debugger: %v", err)
+	}
+
 	// setup reflection monitor
 	if b, ok := scr.(reflection.IdentifyReflector); ok {
 		dbg.reflect = reflection.NewMonitor(dbg.VCS, b.GetReflectionRenderer())
 		dbg.tv.AddReflector(dbg.reflect)
 	}
 
+	// png writer for the SCREENSHOT command. debug colors are only available
+	// if there is a reflection monitor
+	dbg.pngWriter = pngwriter.New(dbg.tv, pngwriter.DefaultOptions)
+	if dbg.reflect != nil {
+		dbg.reflect.AddRenderer(dbg.pngWriter)
+	}
+
+	// software CRT filter for the SCREENSHOT command
+	dbg.crtFilterPrefs, err = crtfilter.NewPreferences()
+	if err != nil {
+		return nil, curated.Errorf("debugger: %v", err)
+	}
+	dbg.pngWriter.SetFilter(crtfilter.NewFilter(dbg.crtFilterPrefs))
+
+	// clip writer for the CLIP command
+	dbg.clipWriter = clipwriter.New(dbg.tv, clipwriter.DefaultLength)
+
+	// audio tracker for the TRACKER command. the tracker must be attached
+	// before the rewind system takes any snapshots
+	dbg.Tracker = tracker.NewTracker(dbg.tv)
+	dbg.VCS.TIA.Audio.SetTracker(dbg.Tracker)
+
 	// plug in rewind system
 	dbg.Rewind, err = rewind.NewRewind(dbg.VCS, dbg)
 	if err != nil {
@@ -265,6 +348,20 @@ func (dbg *Debugger) Start(initScript string, cartload cartridgeloader.Loader) e
 		return curated.Errorf("debugger: %v", err)
 	}
 
+	if dbg.HotReload {
+		dbg.startHotReload(false)
+	}
+	defer dbg.stopHotReload()
+
+	// save classification of the cartridge bytes for the next time the
+	// cartridge is loaded
+	defer func() {
+		err := dbg.Disasm.SaveClassification()
+		if err != nil {
+			logger.Log("classification", err.Error())
+		}
+	}()
+
 	dbg.running = true
 
 	// run initialisation script
@@ -346,6 +443,8 @@ func (dbg *Debugger) restartInputLoop(onRestart func() error) {
 // this is the glue that hold the cartridge and disassembly packages together.
 // especially important is the repointing of the symbols table in the instance of dbgmem.
 func (dbg *Debugger) attachCartridge(cartload cartridgeloader.Loader) error {
+	dbg.cartload = cartload
+
 	// set OnLoaded function for specific cartridge formats
 	cartload.OnLoaded = func(cart mapper.CartMapper) error {
 		if _, ok := cart.(*supercharger.Supercharger); ok {
@@ -383,6 +482,12 @@ func (dbg *Debugger) attachCartridge(cartload cartridgeloader.Loader) error {
 		_ = dbg.scr.SetFeature(gui.ReqChangingCartridge, false)
 	}()
 
+	// save classification for the outgoing cartridge before it is replaced
+	err = dbg.Disasm.SaveClassification()
+	if err != nil {
+		logger.Log("classification", err.Error())
+	}
+
 	// reset of vcs is implied with attach cartridge
 	err = setup.AttachCartridge(dbg.VCS, cartload)
 	if err != nil && !curated.Has(err, cartridge.Ejected) {
@@ -392,7 +497,10 @@ func (dbg *Debugger) attachCartridge(cartload cartridgeloader.Loader) error {
 	// attaching a new cartridge always causes the rewind system to reset
 	dbg.Rewind.Reset()
 
-	symbols, err := symbols.ReadSymbolsFile(dbg.VCS.Mem.Cart)
+	// forget anything assembled for the previous cartridge
+	dbg.assembled = make(map[int]uint8)
+
+	symbols, err := symbols.ReadSymbolsFileFrom(dbg.VCS.Mem.Cart, dbg.SymbolsFile)
 	if err != nil {
 		logger.Log("symbols", err.Error())
 	}
@@ -405,6 +513,19 @@ func (dbg *Debugger) attachCartridge(cartload cartridgeloader.Loader) error {
 	// repoint debug memory's symbol table
 	dbg.dbgmem.symbols = dbg.Disasm.Symbols
 
+	// profiling information for the previous cartridge is meaningless
+	if dbg.profiler != nil {
+		dbg.profiler = profiler.NewProfiler(dbg.VCS.TV.GetSpec(), dbg.Disasm.Symbols)
+	}
+	if dbg.runtimeLinter != nil {
+		dbg.runtimeLinter = linter.NewRuntime(dbg.VCS.TV, dbg.Disasm.Symbols, dbg.lintPrefs)
+	}
+
+	// watch the files of the new cartridge
+	if dbg.isHotReloading() {
+		dbg.startHotReload(dbg.hotReload.keepState)
+	}
+
 	return nil
 }
 
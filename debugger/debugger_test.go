@@ -135,11 +135,28 @@ func (trm *mockTerm) cmpOutput(s string) {
 	trm.t.Errorf(fmt.Sprintf("unexpected debugger output (%s) should be (%s)", trm.output[l], s))
 }
 
+// hasOutput checks that the string argument is one of the lines of the most
+// recent output. the output should have been received with rcvOutput() or
+// cmpOutput() first.
+func (trm *mockTerm) hasOutput(s string) {
+	trm.rcvOutput()
+
+	for _, o := range trm.output {
+		if o == s {
+			return
+		}
+	}
+
+	trm.t.Errorf(fmt.Sprintf("expected debugger output (%s) in %q", s, trm.output))
+}
+
 func (trm *mockTerm) testSequence() {
 	defer func() { trm.sndInput("QUIT") }()
 	trm.testBreakpoints()
 	trm.testTraps()
 	trm.testWatches()
+	trm.testCoverage()
+	trm.testProfile()
 }
 
 func TestDebugger_withNonExistantInitScript(t *testing.T) {
@@ -0,0 +1,54 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package expression implements the small expression language used by the
+// debugger for conditional breakpoints, traps, watches and ONHALT commands.
+//
+// An expression is parsed with the Parse() function. Identifiers in the
+// expression are resolved at parse time by an implementation of the Resolver
+// interface. The resulting Expression can then be evaluated as many times as
+// required with the Eval() function.
+//
+//	e, err := expression.Parse("PEEK($80) & $0F == 3", resolver)
+//	if err != nil {
+//		panic(err)
+//	}
+//	v, err := e.Eval()
+//
+// All values are integers. The result of a comparison or of a logical
+// operator is 1 for true and 0 for false. Any non-zero value is considered to
+// be true when used as an operand for a logical operator.
+//
+// Numbers can be expressed in decimal, in hexadecimal with either the $ or 0x
+// prefix, or in binary with either the % or 0b prefix.
+//
+// Operator precedence is the same as for the Go language. From highest to
+// lowest:
+//
+//	unary	-  !  ~
+//	5	*  /  <<  >>  &
+//	4	+  -  |  ^
+//	3	==  !=  <  <=  >  >=
+//	2	&&
+//	1	||
+//
+// Note that this means the bitwise operators bind more tightly than the
+// comparison operators, which is usually what is wanted when masking values
+// read from memory. For convenience, a single = is accepted as an alternative
+// to ==.
+//
+// The PEEK() function reads the value at the specified address, without side
+// effects. The value returned depends entirely on the Resolver.
+package expression
@@ -0,0 +1,254 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package expression
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// Value is returned by the Resolver for a named identifier. It will be called
+// every time the expression is evaluated.
+type Value func() (int, error)
+
+// Resolver is used by the parser to give meaning to identifiers and by the
+// evaluator to peek at memory.
+type Resolver interface {
+	// Resolve returns the Value for the named identifier. The returned string
+	// is the normalised name of the identifier, used when printing the
+	// expression.
+	Resolve(name string) (Value, string, error)
+
+	// Peek returns the value at the address, without side effects.
+	Peek(address uint16) (uint8, error)
+}
+
+// Sentinal errors.
+const (
+	DivideByZero = "expression: divide by zero"
+)
+
+// Expression is the result of a successful call to Parse().
+type Expression struct {
+	root node
+}
+
+// String returns a normalised representation of the expression.
+func (e *Expression) String() string {
+	return e.root.String()
+}
+
+// Eval evaluates the expression and returns the result.
+func (e *Expression) Eval() (int, error) {
+	return e.root.eval()
+}
+
+// True evaluates the expression and returns true if the result is non-zero.
+func (e *Expression) True() (bool, error) {
+	v, err := e.root.eval()
+	return v != 0, err
+}
+
+type node interface {
+	eval() (int, error)
+	String() string
+}
+
+type number struct {
+	val  int
+	base int
+}
+
+func (n number) eval() (int, error) {
+	return n.val, nil
+}
+
+// numbers are normalised according to the base they were specified in. the
+// prefix used in the original expression is not preserved.
+func (n number) String() string {
+	switch n.base {
+	case 16:
+		if n.val > 0xff {
+			return fmt.Sprintf("$%04x", n.val)
+		}
+		return fmt.Sprintf("$%02x", n.val)
+	case 2:
+		return fmt.Sprintf("%%%b", n.val)
+	}
+	return fmt.Sprintf("%d", n.val)
+}
+
+type ident struct {
+	label string
+	val   Value
+}
+
+func (n ident) eval() (int, error) {
+	return n.val()
+}
+
+func (n ident) String() string {
+	return n.label
+}
+
+type peek struct {
+	res     Resolver
+	address node
+}
+
+func (n peek) eval() (int, error) {
+	a, err := n.address.eval()
+	if err != nil {
+		return 0, err
+	}
+	v, err := n.res.Peek(uint16(a))
+	if err != nil {
+		return 0, err
+	}
+	return int(v), nil
+}
+
+func (n peek) String() string {
+	return fmt.Sprintf("PEEK(%s)", n.address)
+}
+
+type paren struct {
+	n node
+}
+
+func (n paren) eval() (int, error) {
+	return n.n.eval()
+}
+
+func (n paren) String() string {
+	return fmt.Sprintf("(%s)", n.n)
+}
+
+type unary struct {
+	op string
+	n  node
+}
+
+func (n unary) eval() (int, error) {
+	v, err := n.n.eval()
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case "-":
+		return -v, nil
+	case "!":
+		return boolToInt(v == 0), nil
+	case "~":
+		return ^v, nil
+	}
+
+	return 0, curated.Errorf("expression: unknown unary operator (%s)", n.op)
+}
+
+func (n unary) String() string {
+	return fmt.Sprintf("%s%s", n.op, n.n)
+}
+
+type binary struct {
+	op  string
+	lhs node
+	rhs node
+}
+
+func (n binary) eval() (int, error) {
+	l, err := n.lhs.eval()
+	if err != nil {
+		return 0, err
+	}
+
+	// short-circuit logical operators
+	switch n.op {
+	case "&&":
+		if l == 0 {
+			return 0, nil
+		}
+	case "||":
+		if l != 0 {
+			return 1, nil
+		}
+	}
+
+	r, err := n.rhs.eval()
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return 0, curated.Errorf(DivideByZero)
+		}
+		return l / r, nil
+	case "<<":
+		return l << uint(r), nil
+	case ">>":
+		return l >> uint(r), nil
+	case "&":
+		return l & r, nil
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "|":
+		return l | r, nil
+	case "^":
+		return l ^ r, nil
+	case "==":
+		return boolToInt(l == r), nil
+	case "!=":
+		return boolToInt(l != r), nil
+	case "<":
+		return boolToInt(l < r), nil
+	case "<=":
+		return boolToInt(l <= r), nil
+	case ">":
+		return boolToInt(l > r), nil
+	case ">=":
+		return boolToInt(l >= r), nil
+	case "&&", "||":
+		return boolToInt(r != 0), nil
+	}
+
+	return 0, curated.Errorf("expression: unknown operator (%s)", n.op)
+}
+
+func (n binary) String() string {
+	s := strings.Builder{}
+	s.WriteString(n.lhs.String())
+	s.WriteString(" ")
+	s.WriteString(n.op)
+	s.WriteString(" ")
+	s.WriteString(n.rhs.String())
+	return s.String()
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
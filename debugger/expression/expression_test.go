@@ -0,0 +1,127 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package expression_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/expression"
+)
+
+type mockResolver struct {
+	mem  [256]uint8
+	vars map[string]int
+}
+
+func (res *mockResolver) Resolve(name string) (expression.Value, string, error) {
+	name = strings.ToUpper(name)
+	if _, ok := res.vars[name]; !ok {
+		return nil, "", fmt.Errorf("unknown identifier (%s)", name)
+	}
+	return func() (int, error) {
+		return res.vars[name], nil
+	}, name, nil
+}
+
+func (res *mockResolver) Peek(address uint16) (uint8, error) {
+	return res.mem[address&0xff], nil
+}
+
+func eval(t *testing.T, res *mockResolver, s string, expected int) {
+	t.Helper()
+
+	e, err := expression.Parse(s, res)
+	if err != nil {
+		t.Errorf("unexpected error for (%s): %v", s, err)
+		return
+	}
+
+	v, err := e.Eval()
+	if err != nil {
+		t.Errorf("unexpected error for (%s): %v", s, err)
+		return
+	}
+
+	if v != expected {
+		t.Errorf("expression (%s) evaluated to %d - wanted %d", s, v, expected)
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	res := &mockResolver{}
+	eval(t, res, "10", 10)
+	eval(t, res, "010", 10)
+	eval(t, res, "$10", 16)
+	eval(t, res, "0x10", 16)
+	eval(t, res, "%10", 2)
+	eval(t, res, "0b10", 2)
+	eval(t, res, "0", 0)
+}
+
+func TestPrecedence(t *testing.T) {
+	res := &mockResolver{}
+	eval(t, res, "1 + 2 * 3", 7)
+	eval(t, res, "(1 + 2) * 3", 9)
+	eval(t, res, "$f3 & $0f == 3", 1)
+	eval(t, res, "1 | 2 == 3", 1)
+	eval(t, res, "1 < 2 && 3 > 2", 1)
+	eval(t, res, "1 > 2 || 3 > 2", 1)
+	eval(t, res, "-1 + 2", 1)
+	eval(t, res, "!0", 1)
+	eval(t, res, "~0 & $ff", 255)
+	eval(t, res, "1 << 4 >> 2", 4)
+	eval(t, res, "10 - 2 - 3", 5)
+	eval(t, res, "5 = 5", 1)
+}
+
+func TestIdentifiersAndPeek(t *testing.T) {
+	res := &mockResolver{vars: map[string]int{"A": 3, "SCORE": 0x80}}
+	res.mem[0x80] = 0xf3
+
+	eval(t, res, "a", 3)
+	eval(t, res, "PEEK($80) & $0F == A", 1)
+	eval(t, res, "peek(score) >> 4", 0x0f)
+	eval(t, res, "PEEK(SCORE + 1)", 0)
+
+	e, err := expression.Parse("peek(score)&0x0F==a || 010 == %0011", res)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.String() != "PEEK(SCORE) & $0f == A || 10 == %11" {
+		t.Errorf("unexpected normalised expression (%s)", e.String())
+	}
+}
+
+func TestErrors(t *testing.T) {
+	res := &mockResolver{}
+
+	for _, s := range []string{"", "1 +", "(1", "PEEK 1", "1 2", "FOO", "1 @ 2", "$"} {
+		if _, err := expression.Parse(s, res); err == nil {
+			t.Errorf("expected error for (%s)", s)
+		}
+	}
+
+	e, err := expression.Parse("1 / 0", res)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := e.Eval(); !curated.Is(err, expression.DivideByZero) {
+		t.Errorf("expected divide by zero error")
+	}
+}
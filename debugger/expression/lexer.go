@@ -0,0 +1,167 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package expression
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+type tokenType int
+
+const (
+	tokEnd tokenType = iota
+	tokNumber
+	tokIdent
+	tokOperator
+	tokOpenParen
+	tokCloseParen
+)
+
+type token struct {
+	typ  tokenType
+	val  string
+	num  int
+	base int
+}
+
+// the list of operators recognised by the lexer. two character operators must
+// be listed before any single character operator that is also a prefix.
+var operators = []string{
+	"==", "!=", "<=", ">=", "<<", ">>", "&&", "||",
+	"<", ">", "=", "+", "-", "*", "/", "&", "|", "^", "!", "~",
+}
+
+// lex divides the input string into tokens. the list of tokens is always
+// terminated by a token of type tokEnd.
+func lex(s string) ([]token, error) {
+	toks := make([]token, 0, 16)
+
+	i := 0
+	for i < len(s) {
+		c := s[i]
+
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+
+		case c == '(':
+			toks = append(toks, token{typ: tokOpenParen, val: "("})
+			i++
+
+		case c == ')':
+			toks = append(toks, token{typ: tokCloseParen, val: ")"})
+			i++
+
+		case c == '$':
+			j := i + 1
+			for j < len(s) && isHexDigit(s[j]) {
+				j++
+			}
+			n, err := strconv.ParseUint(s[i+1:j], 16, 32)
+			if err != nil {
+				return nil, curated.Errorf("expression: invalid hex number (%s)", s[i:j])
+			}
+			toks = append(toks, token{typ: tokNumber, val: s[i:j], num: int(n), base: 16})
+			i = j
+
+		// the % symbol indicates a binary number. we don't support the modulo
+		// operator so there is no ambiguity
+		case c == '%':
+			j := i + 1
+			for j < len(s) && (s[j] == '0' || s[j] == '1') {
+				j++
+			}
+			n, err := strconv.ParseUint(s[i+1:j], 2, 32)
+			if err != nil {
+				return nil, curated.Errorf("expression: invalid binary number (%s)", s[i:j])
+			}
+			toks = append(toks, token{typ: tokNumber, val: s[i:j], num: int(n), base: 2})
+			i = j
+
+		case c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(s) && isIdentChar(s[j]) {
+				j++
+			}
+
+			// strconv.ParseUint() with a base of zero handles the 0x and 0b
+			// prefixes for us. it also interprets a leading zero as an octal
+			// prefix, which we don't want
+			base := 10
+			v := strings.TrimLeft(s[i:j], "0")
+			if v == "" {
+				v = "0"
+			} else if len(v) < j-i {
+				switch v[0] {
+				case 'x', 'X':
+					base = 16
+					v = s[i:j]
+				case 'b', 'B':
+					base = 2
+					v = s[i:j]
+				}
+			}
+
+			n, err := strconv.ParseUint(v, 0, 32)
+			if err != nil {
+				return nil, curated.Errorf("expression: invalid number (%s)", s[i:j])
+			}
+			toks = append(toks, token{typ: tokNumber, val: s[i:j], num: int(n), base: base})
+			i = j
+
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(s) && isIdentChar(s[j]) {
+				j++
+			}
+			toks = append(toks, token{typ: tokIdent, val: s[i:j]})
+			i = j
+
+		default:
+			found := false
+			for _, op := range operators {
+				if strings.HasPrefix(s[i:], op) {
+					toks = append(toks, token{typ: tokOperator, val: op})
+					i += len(op)
+					found = true
+					break // for loop
+				}
+			}
+			if !found {
+				return nil, curated.Errorf("expression: unexpected character (%c)", c)
+			}
+		}
+	}
+
+	toks = append(toks, token{typ: tokEnd})
+
+	return toks, nil
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || c == '.'
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,177 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package expression
+
+import (
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// binary operators grouped by precedence. lowest precedence first.
+var precedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<", "<=", ">", ">="},
+	{"+", "-", "|", "^"},
+	{"*", "/", "<<", ">>", "&"},
+}
+
+type parser struct {
+	res  Resolver
+	toks []token
+	curr int
+}
+
+// Parse the string and return a new Expression.
+func Parse(s string, res Resolver) (*Expression, error) {
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{res: res, toks: toks}
+
+	if p.peek().typ == tokEnd {
+		return nil, curated.Errorf("expression: empty expression")
+	}
+
+	n, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.typ != tokEnd {
+		return nil, curated.Errorf("expression: unexpected token (%s)", t.val)
+	}
+
+	return &Expression{root: n}, nil
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.curr]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.curr]
+	if t.typ != tokEnd {
+		p.curr++
+	}
+	return t
+}
+
+// parseBinary parses binary operators of the precedence level and above.
+func (p *parser) parseBinary(level int) (node, error) {
+	if level >= len(precedence) {
+		return p.parseUnary()
+	}
+
+	lhs, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		if t.typ != tokOperator {
+			return lhs, nil
+		}
+
+		op := t.val
+		if op == "=" {
+			op = "=="
+		}
+
+		if !isOperatorAtLevel(op, level) {
+			return lhs, nil
+		}
+		p.next()
+
+		rhs, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		lhs = binary{op: op, lhs: lhs, rhs: rhs}
+	}
+}
+
+func isOperatorAtLevel(op string, level int) bool {
+	for _, o := range precedence[level] {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	if t.typ == tokOperator && (t.val == "-" || t.val == "!" || t.val == "~") {
+		p.next()
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unary{op: t.val, n: n}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+
+	switch t.typ {
+	case tokNumber:
+		return number{val: t.num, base: t.base}, nil
+
+	case tokOpenParen:
+		n, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		if p.next().typ != tokCloseParen {
+			return nil, curated.Errorf("expression: missing closing parenthesis")
+		}
+		return paren{n: n}, nil
+
+	case tokIdent:
+		if strings.ToUpper(t.val) == "PEEK" {
+			if p.next().typ != tokOpenParen {
+				return nil, curated.Errorf("expression: PEEK requires an address in parenthesis")
+			}
+			a, err := p.parseBinary(0)
+			if err != nil {
+				return nil, err
+			}
+			if p.next().typ != tokCloseParen {
+				return nil, curated.Errorf("expression: missing closing parenthesis")
+			}
+			return peek{res: p.res, address: a}, nil
+		}
+
+		v, label, err := p.res.Resolve(t.val)
+		if err != nil {
+			return nil, curated.Errorf("expression: %v", err)
+		}
+		return ident{label: label, val: v}, nil
+
+	case tokEnd:
+		return nil, curated.Errorf("expression: unexpected end of expression")
+	}
+
+	return nil, curated.Errorf("expression: unexpected token (%s)", t.val)
+}
@@ -0,0 +1,206 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+package debugger
+
+import (
+	"os"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/symbols"
+)
+
+// how often the watched files are checked for changes.
+const hotReloadPoll = 500 * time.Millisecond
+
+// hotReload watches the cartridge file and symbols file(s) for changes. when a
+// change is seen the cartridge is reloaded.
+type hotReload struct {
+	// closing the quit channel stops the watcher. nil if hot reloading is not
+	// active
+	quit chan bool
+
+	// restore the VCS RAM after reloading the cartridge. the panel settings
+	// are never reset when a cartridge is attached so there is no need to
+	// restore them
+	keepState bool
+}
+
+// start watching the files of the current cartridge. any existing watcher is
+// stopped first.
+func (dbg *Debugger) startHotReload(keepState bool) {
+	dbg.stopHotReload()
+
+	dbg.hotReload.keepState = keepState
+
+	if dbg.cartload.Filename == "" {
+		return
+	}
+
+	files := []string{dbg.cartload.Filename}
+	if dbg.SymbolsFile != "" {
+		files = append(files, dbg.SymbolsFile)
+	} else {
+		files = append(files, symbols.Filenames(dbg.cartload.Filename)...)
+	}
+
+	dbg.hotReload.quit = make(chan bool)
+	go dbg.watchFiles(files, dbg.hotReload.quit)
+}
+
+// stop watching files.
+func (dbg *Debugger) stopHotReload() {
+	if dbg.hotReload.quit != nil {
+		close(dbg.hotReload.quit)
+		dbg.hotReload.quit = nil
+	}
+}
+
+// isHotReloading returns true if files are being watched.
+func (dbg *Debugger) isHotReloading() bool {
+	return dbg.hotReload.quit != nil
+}
+
+// watchFiles should be run as a goroutine. files that don't exist are watched
+// in case they are created.
+func (dbg *Debugger) watchFiles(files []string, quit chan bool) {
+	modTime := func(fn string) time.Time {
+		if fi, err := os.Stat(fn); err == nil {
+			return fi.ModTime()
+		}
+		return time.Time{}
+	}
+
+	last := make(map[string]time.Time)
+	for _, fn := range files {
+		last[fn] = modTime(fn)
+	}
+
+	tick := time.NewTicker(hotReloadPoll)
+	defer tick.Stop()
+
+	// files are often written in stages by assemblers. we don't reload until
+	// the files have stopped changing for at least one poll period
+	changed := false
+
+	for {
+		select {
+		case <-quit:
+			return
+		case <-tick.C:
+		}
+
+		settled := true
+		for fn, t := range last {
+			if m := modTime(fn); !m.Equal(t) {
+				last[fn] = m
+				settled = false
+			}
+		}
+
+		if !settled {
+			changed = true
+			continue // for loop
+		}
+
+		if changed {
+			changed = false
+			dbg.PushRawEvent(func() {
+				if err := dbg.reloadCartridge(); err != nil {
+					dbg.printLine(terminal.StyleError, "%v", err)
+				}
+			})
+		}
+	}
+}
+
+// a PC breakpoint that was set on a labelled address. see reloadCartridge().
+type breakLabel struct {
+	bk    *breaker
+	label string
+}
+
+// the PC breakpoints that are on labelled addresses.
+func (dbg *Debugger) breakLabels() []breakLabel {
+	labels := make([]breakLabel, 0)
+
+	for i := range dbg.breakpoints.breaks {
+		// the bank, if the breakpoint has been limited to a bank
+		bank := -1
+		for n := &dbg.breakpoints.breaks[i]; n != nil; n = n.next {
+			if n.target != nil && n.target.label == "BANK" {
+				if b, ok := n.value.(int); ok {
+					bank = b
+				}
+			}
+		}
+
+		for n := &dbg.breakpoints.breaks[i]; n != nil; n = n.next {
+			if n.target == nil || n.target.label != "PC" {
+				continue // for loop
+			}
+			if v, ok := n.value.(int); ok {
+				if l, ok := dbg.Disasm.Symbols.LookupLabel(bank, uint16(v)); ok {
+					labels = append(labels, breakLabel{bk: n, label: l})
+				}
+			}
+		}
+	}
+
+	return labels
+}
+
+// reload the current cartridge from disk, along with the symbols and
+// disassembly. PC breakpoints on labelled addresses are moved to the new
+// address of the label.
+func (dbg *Debugger) reloadCartridge() error {
+	if dbg.cartload.Filename == "" {
+		return curated.Errorf("cartridge has no file to reload")
+	}
+
+	labels := dbg.breakLabels()
+
+	var ram []uint8
+	if dbg.hotReload.keepState {
+		ram = make([]uint8, len(dbg.VCS.Mem.RAM.RAM))
+		copy(ram, dbg.VCS.Mem.RAM.RAM)
+	}
+
+	err := dbg.attachCartridge(cartridgeloader.NewLoader(dbg.cartload.Filename, dbg.cartload.Mapping))
+	if err != nil {
+		return err
+	}
+
+	if ram != nil {
+		copy(dbg.VCS.Mem.RAM.RAM, ram)
+	}
+
+	for _, l := range labels {
+		ok, _, _, address := dbg.Disasm.Symbols.Search(l.label, symbols.LabelTable)
+		if !ok {
+			dbg.printLine(terminal.StyleError, "label for breakpoint no longer exists (%s)", l.label)
+			continue // for loop
+		}
+		if ai := dbg.dbgmem.mapAddress(address, true); ai != nil {
+			l.bk.value = int(ai.mappedAddress)
+		}
+	}
+
+	dbg.printLine(terminal.StyleFeedback, "cartridge reloaded (%s)", dbg.cartload.Filename)
+
+	return nil
+}
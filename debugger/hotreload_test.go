@@ -0,0 +1,139 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/debugger"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// write a copy of the disassembly package's test cartridge and a ca65 debug
+// file containing labels. the labels should be after the program in the test
+// cartridge, which ends at $f013.
+func writeReloadFiles(t *testing.T, cartFile string, labels map[string]uint16) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("..", "disassembly", "testdata", "test_4k.bin"))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// the cartridge data is different for every set of labels
+	for _, a := range labels {
+		data[a&0x0fff] = 0xea
+	}
+
+	err = os.WriteFile(cartFile, data, 0644)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbg := "version\tmajor=2,minor=0\n"
+	id := 0
+	for l, a := range labels {
+		dbg += fmt.Sprintf("sym\tid=%d,name=\"%s\",addrsize=absolute,scope=0,def=0,val=0x%04X,type=lab\n", id, l, a)
+		id++
+	}
+
+	err = os.WriteFile(cartFile[:len(cartFile)-len(filepath.Ext(cartFile))]+".dbg", []byte(dbg), 0644)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+func (trm *mockTerm) testHotReload(t *testing.T, cartFile string) {
+	defer func() { trm.sndInput("QUIT") }()
+
+	// breakpoints on the kernel and gone labels
+	trm.sndInput("BREAK $f110")
+	trm.cmpOutput("")
+	trm.sndInput("BREAK $f120")
+	trm.cmpOutput("")
+	trm.sndInput("BREAK $f140")
+	trm.cmpOutput("")
+	trm.sndInput("LIST BREAKS")
+	trm.cmpOutput(" 2: PC->0x1140")
+
+	// kernel has moved and gone has been removed
+	writeReloadFiles(t, cartFile, map[string]uint16{"kernel": 0xf130})
+
+	trm.sndInput("HOTRELOAD NOW")
+	trm.cmpOutput(fmt.Sprintf("cartridge reloaded (%s)", cartFile))
+	trm.hasOutput("label for breakpoint no longer exists (gone)")
+
+	// breakpoint on kernel has moved. the other breakpoints are unchanged
+	trm.sndInput("LIST BREAKS")
+	trm.hasOutput(" 0: PC->0x1130")
+	trm.hasOutput(" 1: PC->0x1120")
+	trm.hasOutput(" 2: PC->0x1140")
+
+	// RAM is reset by a reload. random start state is turned off so that the
+	// reset value is known
+	trm.sndInput("PREFS UNSET RANDSTART")
+	trm.cmpOutput("")
+	trm.sndInput("POKE $80 $42")
+	trm.cmpOutput("0x0080 (RAM) -> 0x42")
+	trm.sndInput("HOTRELOAD NOW")
+	trm.cmpOutput(fmt.Sprintf("cartridge reloaded (%s)", cartFile))
+	trm.sndInput("PEEK $80")
+	trm.cmpOutput("0x0080 (RAM) -> 0x00")
+
+	// RAM is kept when hot reloading has been started with KEEPSTATE. the
+	// files aren't changed after the watcher has been started so the watcher
+	// won't reload the cartridge
+	trm.sndInput("POKE $80 $42")
+	trm.cmpOutput("0x0080 (RAM) -> 0x42")
+	trm.sndInput("HOTRELOAD ON KEEPSTATE")
+	trm.cmpOutput("watching cartridge files for changes")
+	trm.sndInput("HOTRELOAD NOW")
+	trm.cmpOutput(fmt.Sprintf("cartridge reloaded (%s)", cartFile))
+	trm.sndInput("PEEK $80")
+	trm.cmpOutput("0x0080 (RAM) -> 0x42")
+	trm.sndInput("HOTRELOAD OFF")
+	trm.cmpOutput("not watching cartridge files")
+}
+
+func TestDebugger_hotReload(t *testing.T) {
+	prefs.DisableSaving = true
+
+	cartFile := filepath.Join(t.TempDir(), "reload.bin")
+	writeReloadFiles(t, cartFile, map[string]uint16{"kernel": 0xf110, "gone": 0xf120})
+
+	trm := newMockTerm(t)
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbg, err := debugger.NewDebugger(tv, &mockGUI{}, trm, false)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	go trm.testHotReload(t, cartFile)
+
+	err = dbg.Start("", cartridgeloader.NewLoader(cartFile, "4k"))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
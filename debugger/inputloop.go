@@ -26,6 +26,8 @@ import (
 	"github.com/jetsetilly/gopher2600/gui"
 	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
 	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/supercharger"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/linter"
 )
 
 // inputLoop has two modes, defined by the videoCycle argument. when videoCycle
@@ -110,9 +112,12 @@ func (dbg *Debugger) inputLoop(inputter terminal.Input, videoCycle bool) error {
 			stepTrapMessage = dbg.stepTraps.check("")
 		}
 
+		// source lines are only checked at the end of an instruction
+		stepSourceHalt := !videoCycle && dbg.checkStepSource()
+
 		// check for halt conditions
 		haltEmulation := stepTrapMessage != "" || breakMessage != "" ||
-			trapMessage != "" || watchMessage != "" ||
+			trapMessage != "" || watchMessage != "" || stepSourceHalt ||
 			dbg.lastStepError || dbg.haltImmediately
 
 		// expand halt to include step-once/many flag
@@ -126,6 +131,7 @@ func (dbg *Debugger) inputLoop(inputter terminal.Input, videoCycle bool) error {
 			// always clear steptraps. if the emulation has halted for any
 			// reason then any existing step trap is stale.
 			dbg.stepTraps.clear()
+			dbg.stepSource.active = false
 
 			// print and reset accumulated break/trap/watch messages
 			dbg.printLine(terminal.StyleFeedback, breakMessage)
@@ -135,8 +141,9 @@ func (dbg *Debugger) inputLoop(inputter terminal.Input, videoCycle bool) error {
 			trapMessage = ""
 			watchMessage = ""
 
-			// input has halted. print on halt command if it is defined
-			if dbg.commandOnHalt != nil {
+			// input has halted. print on halt command if it is defined and
+			// if the condition (if any) is met
+			if dbg.commandOnHalt != nil && (dbg.commandOnHaltCond == nil || dbg.commandOnHaltCond.test()) {
 				err := dbg.processTokenGroup(dbg.commandOnHalt)
 				if err != nil {
 					dbg.printLine(terminal.StyleError, "%s", err)
@@ -442,6 +449,28 @@ func (dbg *Debugger) contEmulation(inputter terminal.Input) error {
 			return err
 		}
 
+		// classify cartridge bytes accessed by the instruction
+		dbg.Disasm.Classify(dbg.lastBank, dbg.VCS.CPU.LastResult, dbg.VCS.Mem.LastAccessAddress, dbg.VCS.Mem.LastAccessWrite)
+
+		// record runtime cross references. does nothing if observation has
+		// not been turned on with XREF RUNTIME ON
+		dbg.Disasm.ObserveXref(dbg.lastBank, dbg.VCS.CPU.LastResult, dbg.VCS.Mem.LastAccessAddress, dbg.VCS.Mem.LastAccessWrite)
+
+		if dbg.profiler != nil {
+			dbg.profiler.Step(dbg.VCS.CPU.LastResult,
+				dbg.VCS.TV.GetState(signal.ReqFramenum),
+				dbg.VCS.TV.GetState(signal.ReqScanline),
+				dbg.VCS.TV.GetState(signal.ReqHorizPos))
+		}
+
+		if dbg.runtimeLinter != nil {
+			dbg.runtimeLinter.Step(dbg.VCS.CPU.LastResult, linter.Access{
+				Address: dbg.VCS.Mem.LastAccessAddress,
+				Value:   dbg.VCS.Mem.LastAccessValue,
+				Write:   dbg.VCS.Mem.LastAccessWrite,
+			})
+		}
+
 		// check validity of instruction result
 		err = dbg.VCS.CPU.LastResult.IsValid()
 		if err != nil {
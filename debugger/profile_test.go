@@ -0,0 +1,28 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testProfile() {
+	trm.sndInput("PROFILE ON")
+	trm.cmpOutput("profiler is on")
+
+	// the export argument requires a filename
+	trm.sndInput("PROFILE EXPORT")
+	trm.cmpOutput("profiler: no filename for export")
+
+	trm.sndInput("PROFILE OFF")
+	trm.cmpOutput("profiler is off")
+}
@@ -0,0 +1,102 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/debugger/terminal/commandline"
+	"github.com/jetsetilly/gopher2600/pngwriter"
+)
+
+// screenshot implements the SCREENSHOT command.
+func (dbg *Debugger) screenshot(tokens *commandline.Tokens) error {
+	arg, ok := tokens.Get()
+
+	if ok && strings.ToUpper(arg) == "AUTO" {
+		opts := dbg.pngWriter.Options()
+
+		arg, ok := tokens.Get()
+		if !ok {
+			dbg.printLine(terminal.StyleFeedback, opts.String())
+			return nil
+		}
+
+		if strings.ToUpper(arg) == "OFF" {
+			opts.Frame = 0
+			opts.Every = 0
+			opts.From = 0
+			opts.To = 0
+			dbg.pngWriter.SetOptions(opts)
+			dbg.printLine(terminal.StyleFeedback, "frames will not be saved automatically")
+			return nil
+		}
+
+		opts, err := pngwriter.ParseOptions(arg, opts)
+		if err != nil {
+			return err
+		}
+		if opts.Debug && dbg.reflect == nil {
+			return curated.Errorf("debug colors are not available")
+		}
+		dbg.pngWriter.SetOptions(opts)
+		dbg.printLine(terminal.StyleFeedback, opts.String())
+
+		return nil
+	}
+
+	crop := true
+	debug := false
+	crt := false
+	filename := ""
+
+	for ok {
+		switch strings.ToUpper(arg) {
+		case "FULL":
+			crop = false
+		case "DEBUG":
+			debug = true
+		case "CRT":
+			crt = true
+		default:
+			filename = arg
+		}
+		arg, ok = tokens.Get()
+	}
+
+	if debug && dbg.reflect == nil {
+		return curated.Errorf("debug colors are not available")
+	}
+
+	if filename == "" {
+		n := time.Now()
+		filename = fmt.Sprintf("screenshot_%s_%s.png",
+			dbg.cartload.ShortName(), fmt.Sprintf("%04d%02d%02d_%02d%02d%02d",
+				n.Year(), n.Month(), n.Day(), n.Hour(), n.Minute(), n.Second()))
+	}
+
+	err := dbg.pngWriter.Save(filename, crop, debug, crt)
+	if err != nil {
+		return err
+	}
+	dbg.printLine(terminal.StyleFeedback, "screenshot saved to %s", filename)
+
+	return nil
+}
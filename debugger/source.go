@@ -0,0 +1,203 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/symbols"
+)
+
+// the number of lines either side of the source line to print with the SOURCE
+// command.
+const sourceContext = 3
+
+// sourceLine returns the source line for the address. the bytes at the
+// address in the currently mapped bank are used to choose between source lines
+// in different banks.
+func (dbg *Debugger) sourceLine(address uint16) (*symbols.SourceLine, bool) {
+	if dbg.Disasm.Symbols == nil || dbg.Disasm.Symbols.Source == nil {
+		return nil, false
+	}
+
+	bytecode := make([]uint8, 0, 3)
+	for i := uint16(0); i < 3; i++ {
+		ai, err := dbg.dbgmem.peek(address + i)
+		if err != nil {
+			break // for loop
+		}
+		bytecode = append(bytecode, ai.data)
+	}
+
+	return dbg.Disasm.Symbols.Source.Lookup(address, bytecode)
+}
+
+// print the source line and the lines around it.
+func (dbg *Debugger) printSource(ln *symbols.SourceLine) {
+	dbg.printLine(terminal.StyleFeedback, "%s", ln)
+
+	for n := ln.LineNumber - sourceContext; n <= ln.LineNumber+sourceContext; n++ {
+		l := ln.File.Line(n)
+		if l == nil {
+			continue // for loop
+		}
+
+		marker := " "
+		if n == ln.LineNumber {
+			marker = ">"
+		}
+
+		dbg.printLine(terminal.StyleInstrument, "%s %5d  %s", marker, n, l.Text)
+	}
+}
+
+// the state of a STEP SOURCE command.
+type stepSource struct {
+	active bool
+
+	// the source line at the time the step was requested. can be nil if the
+	// emulation was not at a known source line
+	from *symbols.SourceLine
+}
+
+// begin stepping by source line.
+func (dbg *Debugger) startStepSource() error {
+	if dbg.Disasm.Symbols == nil || dbg.Disasm.Symbols.Source == nil {
+		return curated.Errorf(noSourceInformation)
+	}
+
+	dbg.stepSource.active = true
+	dbg.stepSource.from, _ = dbg.sourceLine(dbg.VCS.CPU.PC.Address())
+
+	return nil
+}
+
+// checkStepSource returns true if the emulation has reached a source line
+// that is different to the one at the start of the step. the step is over
+// once checkStepSource() has returned true.
+func (dbg *Debugger) checkStepSource() bool {
+	if !dbg.stepSource.active {
+		return false
+	}
+
+	ln, ok := dbg.sourceLine(dbg.VCS.CPU.PC.Address())
+	if !ok || ln == dbg.stepSource.from {
+		return false
+	}
+
+	dbg.stepSource.active = false
+	dbg.printLine(terminal.StyleFeedback, "%s %s", ln, strings.TrimSpace(ln.Text))
+
+	return true
+}
+
+// source error formatting, for consistency.
+const (
+	noSourceInformation = "no source information for cartridge"
+	noSourceLine        = "no source for %s"
+)
+
+// parse a source reference of the form "file:line" and return the address and
+// (if it can be determined) the bank of the code generated by the line. if the
+// line does not generate any code then the next line that does is used.
+//
+// the file part of the reference is matched without regard to case or
+// directory.
+//
+// the ok return value is false if the string is not in the form of a source
+// reference. in that case the error value will be nil.
+func (dbg *Debugger) parseSourceRef(ref string) (address uint16, bank int, ok bool, err error) {
+	i := strings.LastIndex(ref, ":")
+	if i <= 0 {
+		return 0, -1, false, nil
+	}
+
+	n, err := strconv.Atoi(ref[i+1:])
+	if err != nil {
+		return 0, -1, false, nil
+	}
+
+	if dbg.Disasm.Symbols == nil || dbg.Disasm.Symbols.Source == nil {
+		return 0, -1, true, curated.Errorf(noSourceInformation)
+	}
+	src := dbg.Disasm.Symbols.Source
+
+	var fl *symbols.SourceFile
+	for _, fn := range src.Filenames {
+		if strings.EqualFold(fn, ref[:i]) || strings.EqualFold(filepath.Base(fn), ref[:i]) {
+			fl = src.Files[fn]
+			break // for loop
+		}
+	}
+	if fl == nil {
+		return 0, -1, true, curated.Errorf(noSourceLine, ref)
+	}
+
+	for ; n <= len(fl.Lines); n++ {
+		ln := fl.Line(n)
+		if ln != nil && ln.HasAddress {
+			return ln.Address, dbg.sourceBank(ln), true, nil
+		}
+	}
+
+	return 0, -1, true, curated.Errorf(noSourceLine, ref)
+}
+
+// find the cartridge bank that contains the bytes generated by the source line.
+// returns -1 if the bank can't be determined, either because the source line
+// does not have any bytes or because the bytes appear at the same address in
+// more than one bank.
+func (dbg *Debugger) sourceBank(ln *symbols.SourceLine) int {
+	if len(ln.Bytes) == 0 || dbg.VCS.Mem.Cart.NumBanks() <= 1 {
+		return -1
+	}
+
+	banks, err := dbg.VCS.Mem.Cart.CopyBanks()
+	if err != nil {
+		return -1
+	}
+
+	bank := -1
+	for _, b := range banks {
+		for _, o := range b.Origins {
+			idx := int(ln.Address) - int(o)
+			if idx < 0 || idx+len(ln.Bytes) > len(b.Data) {
+				continue // for loop
+			}
+
+			match := true
+			for i := range ln.Bytes {
+				if b.Data[idx+i] != ln.Bytes[i] {
+					match = false
+					break // for loop
+				}
+			}
+
+			if match {
+				if bank != -1 && bank != b.Number {
+					return -1
+				}
+				bank = b.Number
+			}
+		}
+	}
+
+	return bank
+}
@@ -0,0 +1,151 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/debugger"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// the program in the symbols package listing fixture. the fourth byte of the
+// data table is different in each bank so that the line generating the table
+// can be qualified by bank.
+func sourceROM() []uint8 {
+	data := make([]uint8, 8192)
+	for b := 0; b < 2; b++ {
+		bank := data[b*4096 : (b+1)*4096]
+		copy(bank, []uint8{
+			0x78,       // f000 sei
+			0xa2, 0xff, // f001 ldx #$ff
+			0x9a,             // f003 txs
+			0x4c, 0x00, 0xf0, // f004 jmp start
+			0x01, 0x02, 0x03, 0x04, 0x05, // f007 .byte 1,2,3,4,5
+		})
+		bank[0xffc] = 0x00
+		bank[0xffd] = 0xf0
+		bank[0xffe] = 0x00
+		bank[0xfff] = 0xf0
+	}
+	data[0x000a] = 0xff
+	return data
+}
+
+func (trm *mockTerm) testSource() {
+	defer func() { trm.sndInput("QUIT") }()
+
+	// the filename in the listing is src/source.asm. the file part of the
+	// reference can be the basename and is not case sensitive
+	trm.sndInput("BREAK SRC/SOURCE.ASM:6")
+	trm.cmpOutput("")
+	trm.sndInput("LIST BREAKS")
+	trm.cmpOutput(" 0: PC->0x1001")
+
+	trm.sndInput("BREAK source.asm:8")
+	trm.cmpOutput("")
+	trm.sndInput("LIST BREAKS")
+	trm.cmpOutput(" 1: PC->0x1004")
+
+	// lines that don't generate code advance to the next line that does.
+	// line 7 is the txs at $f003
+	trm.sndInput("BREAK source.asm:7")
+	trm.cmpOutput("")
+	trm.sndInput("LIST BREAKS")
+	trm.cmpOutput(" 2: PC->0x1003")
+
+	// line 4 is the label for line 5 and line 3 is an org directive. the sei
+	// at line 5 is where the emulation is currently halted so the new break
+	// is reported immediately
+	trm.sndInput("BREAK source.asm:4")
+	trm.cmpOutput("break on PC->0x1000")
+	trm.sndInput("BREAK Source.Asm:3")
+	trm.cmpOutput("already exists (PC->0x1000)")
+
+	// the data table is only found in bank 1 so the breakpoint is qualified
+	// by bank
+	trm.sndInput("BREAK source.asm:9")
+	trm.cmpOutput("")
+	trm.sndInput("LIST BREAKS")
+	trm.cmpOutput(" 4: PC->0x1007 & Bank->1")
+
+	// no more lines generate code after the vectors
+	trm.sndInput("BREAK source.asm:13")
+	trm.cmpOutput("no source for source.asm:13")
+	trm.sndInput("BREAK other.asm:5")
+	trm.cmpOutput("no source for other.asm:5")
+
+	trm.sndInput("CLEAR BREAKS")
+	trm.cmpOutput("breakpoints cleared")
+
+	// step through the program by source line. the jmp returns to the start
+	// of the program
+	trm.sndInput("STEP SOURCE")
+	trm.cmpOutput("src/source.asm:6 ldx\t#$ff")
+	trm.sndInput("STEP SOURCE")
+	trm.cmpOutput("src/source.asm:7 txs")
+	trm.sndInput("STEP SOURCE")
+	trm.cmpOutput("src/source.asm:8 jmp\tstart")
+	trm.sndInput("STEP SOURCE")
+	trm.cmpOutput("src/source.asm:5 sei")
+}
+
+func TestDebugger_withSource(t *testing.T) {
+	prefs.DisableSaving = true
+
+	dir := t.TempDir()
+
+	// the cartridge file and listing must have the same base name
+	lst, err := os.ReadFile(filepath.Join("..", "symbols", "testdata", "source.lst"))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	lst = []byte(strings.Replace(string(lst), "FILE source.asm", "FILE src/source.asm", 1))
+
+	err = os.WriteFile(filepath.Join(dir, "source.lst"), lst, 0644)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	cartFile := filepath.Join(dir, "source.bin")
+	err = os.WriteFile(cartFile, sourceROM(), 0644)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	trm := newMockTerm(t)
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbg, err := debugger.NewDebugger(tv, &mockGUI{}, trm, false)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	go trm.testSource()
+
+	err = dbg.Start("", cartridgeloader.NewLoader(cartFile, "F8"))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
@@ -106,13 +106,33 @@ func parseTarget(dbg *Debugger, tokens *commandline.Tokens) (*target, error) {
 
 		case "SP":
 			trg = &target{
-				label: "X",
+				label: "SP",
 				currentValue: func() targetValue {
-					return int(dbg.VCS.CPU.Y.Value())
+					return int(dbg.VCS.CPU.SP.Value())
 				},
 				format: "%#02x",
 			}
 
+		case "STATUS", "P":
+			trg = &target{
+				label: "Status",
+				currentValue: func() targetValue {
+					return int(dbg.VCS.CPU.Status.Value())
+				},
+				format: "%#02x",
+			}
+
+		// the RDY flag is cleared by a write to WSYNC. the names of the
+		// following targets must not be the same as a TIA register symbol or
+		// expressions will not be able to refer to the register address
+		case "WSYNCWAIT":
+			trg = &target{
+				label: "WSYNC Wait",
+				currentValue: func() targetValue {
+					return !dbg.VCS.CPU.RdyFlg
+				},
+			}
+
 		// tv state
 		case "FRAMENUM", "FRAME", "FR":
 			trg = &target{
@@ -138,6 +158,39 @@ func parseTarget(dbg *Debugger, tokens *commandline.Tokens) (*target, error) {
 				},
 			}
 
+		case "TVVSYNC":
+			trg = &target{
+				label: "TV VSYNC",
+				currentValue: func() targetValue {
+					return dbg.VCS.TV.GetLastSignal().VSync
+				},
+			}
+
+		case "TVVBLANK":
+			trg = &target{
+				label: "TV VBLANK",
+				currentValue: func() targetValue {
+					return dbg.VCS.TV.GetLastSignal().VBlank
+				},
+			}
+
+		// tia state
+		case "HBLANK":
+			trg = &target{
+				label: "HBLANK",
+				currentValue: func() targetValue {
+					return dbg.VCS.TIA.Hblank
+				},
+			}
+
+		case "HMOVELATCH":
+			trg = &target{
+				label: "HMOVE Latch",
+				currentValue: func() targetValue {
+					return dbg.VCS.TIA.HmoveLatch
+				},
+			}
+
 		case "BANK":
 			trg = bankTarget(dbg)
 
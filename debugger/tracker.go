@@ -0,0 +1,119 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/debugger/terminal/commandline"
+	"github.com/jetsetilly/gopher2600/tracker"
+)
+
+// the number of entries printed by the TRACKER command when no arguments are
+// given.
+const trackerDefaultLast = 10
+
+// tracker implements the TRACKER command.
+func (dbg *Debugger) tracker(tokens *commandline.Tokens) error {
+	arg, ok := tokens.Get()
+	if !ok {
+		dbg.printTracker(trackerDefaultLast)
+		return nil
+	}
+
+	switch strings.ToUpper(arg) {
+	case "LAST":
+		arg, _ := tokens.Get()
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			n = trackerDefaultLast
+		}
+		dbg.printTracker(n)
+
+	case "CLEAR":
+		dbg.Tracker.Clear()
+		dbg.printLine(terminal.StyleFeedback, "audio tracker cleared")
+
+	case "SAVE":
+		return dbg.saveTracker(tokens)
+	}
+
+	return nil
+}
+
+func (dbg *Debugger) printTracker(n int) {
+	dbg.printLine(terminal.StyleFeedback, "%d audio register writes recorded", dbg.Tracker.Len())
+	for _, e := range dbg.Tracker.Recent(n) {
+		dbg.printLine(terminal.StyleInstrument, e.String())
+	}
+}
+
+func (dbg *Debugger) saveTracker(tokens *commandline.Tokens) (rerr error) {
+	var format tracker.Format
+	var formatSet bool
+	var filename string
+
+	arg, ok := tokens.Get()
+	if ok {
+		f, err := tracker.FormatFromString(arg)
+		if err == nil {
+			format = f
+			formatSet = true
+			arg, ok = tokens.Get()
+		}
+	}
+	if ok {
+		filename = arg
+		if !formatSet {
+			format = tracker.FormatFromFilename(filename)
+		}
+	}
+
+	if filename == "" {
+		n := time.Now()
+		filename = fmt.Sprintf("tracker_%s_%s%s",
+			dbg.cartload.ShortName(), fmt.Sprintf("%04d%02d%02d_%02d%02d%02d",
+				n.Year(), n.Month(), n.Day(), n.Hour(), n.Minute(), n.Second()),
+			format.Extension())
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return curated.Errorf("tracker: %v", err)
+	}
+	defer func() {
+		err := f.Close()
+		if err != nil {
+			rerr = curated.Errorf("tracker: %v", err)
+		}
+	}()
+
+	entries := dbg.Tracker.Copy()
+	err = tracker.Export(f, format, entries)
+	if err != nil {
+		return err
+	}
+
+	dbg.printLine(terminal.StyleFeedback, "%d audio register writes saved to %s", len(entries), filename)
+
+	return nil
+}
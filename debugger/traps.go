@@ -16,6 +16,9 @@
 // traps are used to halt execution of the emulator when the target *changes*
 // from its current value to any other value. compare to breakpoints which halt
 // execution when the target is *changed to* a specific value.
+//
+// a trap can also be defined with a condition, in which case execution will
+// halt when the value of the condition's expression changes.
 
 package debugger
 
@@ -36,12 +39,31 @@ type traps struct {
 type trapper struct {
 	target    *target
 	origValue interface{}
+
+	// a trapper with a condition has no target
+	cond *condition
 }
 
 func (tr trapper) String() string {
+	if tr.cond != nil {
+		return tr.cond.String()
+	}
 	return tr.target.Label()
 }
 
+// value returns the current value of the trapper's target or condition.
+// evaluation errors result in the original value being returned.
+func (tr trapper) value() interface{} {
+	if tr.cond != nil {
+		v, err := tr.cond.expr.Eval()
+		if err != nil {
+			return tr.origValue
+		}
+		return v
+	}
+	return tr.target.TargetValue()
+}
+
 // newTraps is the preferred method of initialisation for the traps type.
 func newTraps(dbg *Debugger) *traps {
 	tr := &traps{dbg: dbg}
@@ -84,10 +106,12 @@ func (tr *traps) check(previousResult string) string {
 	checkString := strings.Builder{}
 	checkString.WriteString(previousResult)
 	for i := range tr.traps {
-		trapValue := tr.traps[i].target.TargetValue()
+		trapValue := tr.traps[i].value()
 
 		if trapValue != tr.traps[i].origValue {
-			checkString.WriteString(fmt.Sprintf("trap on %s [%v->%v]\n", tr.traps[i].target.Label(), tr.traps[i].origValue, trapValue))
+			if tr.traps[i].cond == nil || tr.traps[i].cond.hit() {
+				checkString.WriteString(fmt.Sprintf("trap on %s [%v->%v]\n", tr.traps[i], tr.traps[i].origValue, trapValue))
+			}
 			tr.traps[i].origValue = trapValue
 		}
 	}
@@ -101,15 +125,40 @@ func (tr traps) list() {
 	} else {
 		tr.dbg.printLine(terminal.StyleFeedback, "traps:")
 		for i := range tr.traps {
-			tr.dbg.printLine(terminal.StyleFeedback, "% 2d: %s", i, tr.traps[i].target.Label())
+			if tr.traps[i].cond != nil {
+				tr.dbg.printLine(terminal.StyleFeedback, "% 2d: %s (hit %d times)", i, tr.traps[i], tr.traps[i].cond.hits)
+			} else {
+				tr.dbg.printLine(terminal.StyleFeedback, "% 2d: %s", i, tr.traps[i])
+			}
 		}
 	}
 }
 
-// parse tokens and add new trap.
+// parse tokens and add new trap. a conditional trap is specified with the IF
+// keyword, in which case the remainder of the tokens form the condition.
 func (tr *traps) parseCommand(tokens *commandline.Tokens) error {
-	_, present := tokens.Peek()
+	tok, present := tokens.Peek()
 	for present {
+		if strings.ToUpper(tok) == "IF" {
+			tokens.Get()
+			cnd, err := parseConditionTokens(tr.dbg, tokens)
+			if err != nil {
+				return err
+			}
+
+			nt := trapper{cond: cnd}
+			for _, t := range tr.traps {
+				if t.cond != nil && t.String() == nt.String() {
+					return curated.Errorf("trap already exists (%s)", t)
+				}
+			}
+
+			nt.origValue = nt.value()
+			tr.traps = append(tr.traps, nt)
+
+			return nil
+		}
+
 		tgt, err := parseTarget(tr.dbg, tokens)
 		if err != nil {
 			return err
@@ -117,7 +166,7 @@ func (tr *traps) parseCommand(tokens *commandline.Tokens) error {
 
 		addNewTrap := true
 		for _, t := range tr.traps {
-			if t.target.Label() == tgt.Label() {
+			if t.target != nil && t.target.Label() == tgt.Label() {
 				addNewTrap = false
 				tr.dbg.printLine(terminal.StyleError, fmt.Sprintf("trap already exists (%s)", t))
 				break // for loop
@@ -128,7 +177,7 @@ func (tr *traps) parseCommand(tokens *commandline.Tokens) error {
 			tr.traps = append(tr.traps, trapper{target: tgt, origValue: tgt.TargetValue()})
 		}
 
-		_, present = tokens.Peek()
+		tok, present = tokens.Peek()
 	}
 
 	return nil
@@ -31,4 +31,14 @@ func (trm *mockTerm) testTraps() {
 	// list traps. compare last line.
 	trm.sndInput("LIST TRAPS")
 	trm.cmpOutput(" 0: A")
+
+	// add conditional trap
+	trm.sndInput("TRAP IF A & 1")
+	trm.cmpOutput("")
+
+	trm.sndInput("TRAP IF A & 1")
+	trm.cmpOutput("trap already exists (if A & 1)")
+
+	trm.sndInput("LIST TRAPS")
+	trm.cmpOutput(" 1: if A & 1 (hit 0 times)")
 }
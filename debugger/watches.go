@@ -36,6 +36,9 @@ type watcher struct {
 	// wether to compare the address as used or whether to consider mirrored
 	// addresses too
 	mirrors bool
+
+	// the watch will only match if the condition is also met. can be nil
+	cond *condition
 }
 
 func (w watcher) String() string {
@@ -47,7 +50,11 @@ func (w watcher) String() string {
 	if w.ai.read {
 		event = "read"
 	}
-	return fmt.Sprintf("%s %s%s", w.ai, event, val)
+	cnd := ""
+	if w.cond != nil {
+		cnd = fmt.Sprintf(" %s", w.cond)
+	}
+	return fmt.Sprintf("%s %s%s%s", w.ai, event, val, cnd)
 }
 
 // the list of currently defined watches in the system.
@@ -117,8 +124,17 @@ func (wtc *watches) check(previousResult string) string {
 		// match watch event to the type of memory access
 		if (!wtc.watches[i].ai.read && wtc.dbg.VCS.Mem.LastAccessWrite) ||
 			(wtc.watches[i].ai.read && !wtc.dbg.VCS.Mem.LastAccessWrite) {
-			// match watched-for value to the value that was read/written to the
-			// watched address
+			// continue if the watched-for value doesn't match the value that
+			// was read/written to the watched address
+			if wtc.watches[i].matchValue && wtc.watches[i].value != wtc.dbg.VCS.Mem.LastAccessValue {
+				continue
+			}
+
+			// continue if the watch has a condition that is not met
+			if wtc.watches[i].cond != nil && !wtc.watches[i].cond.test() {
+				continue
+			}
+
 			if !wtc.watches[i].matchValue {
 				// prepare string according to event
 				if wtc.dbg.VCS.Mem.LastAccessWrite {
@@ -126,7 +142,7 @@ func (wtc *watches) check(previousResult string) string {
 				} else {
 					checkString.WriteString(fmt.Sprintf("watch (read) at %s\n", wtc.watches[i]))
 				}
-			} else if wtc.watches[i].matchValue && (wtc.watches[i].value == wtc.dbg.VCS.Mem.LastAccessValue) {
+			} else {
 				// prepare string according to event
 				if wtc.dbg.VCS.Mem.LastAccessWrite {
 					checkString.WriteString(fmt.Sprintf("watch (write) at %s %#02x\n", wtc.watches[i], wtc.dbg.VCS.Mem.LastAccessValue))
@@ -156,7 +172,9 @@ func (wtc *watches) list() {
 }
 
 // parse tokens and add new watch. unlike breakpoints and traps, only one watch
-// at a time can be specified on the command line.
+// at a time can be specified on the command line. a watch can be made
+// conditional with the IF keyword, in which case the remainder of the tokens
+// form the condition.
 func (wtc *watches) parseCommand(tokens *commandline.Tokens) error {
 	var event int
 	var mirrors bool
@@ -217,6 +235,10 @@ func (wtc *watches) parseCommand(tokens *commandline.Tokens) error {
 	var val uint64
 	var err error
 	v, useVal := tokens.Get()
+	if useVal && strings.ToUpper(v) == "IF" {
+		tokens.Unget()
+		useVal = false
+	}
 	if useVal {
 		val, err = strconv.ParseUint(v, 0, 8)
 		if err != nil {
@@ -224,11 +246,21 @@ func (wtc *watches) parseCommand(tokens *commandline.Tokens) error {
 		}
 	}
 
+	// get condition if possible
+	var cnd *condition
+	if v, ok := tokens.Get(); ok && strings.ToUpper(v) == "IF" {
+		cnd, err = parseConditionTokens(wtc.dbg, tokens)
+		if err != nil {
+			return err
+		}
+	}
+
 	nw := watcher{
 		ai:         *ai,
 		matchValue: useVal,
 		value:      uint8(val),
 		mirrors:    mirrors,
+		cond:       cnd,
 	}
 
 	// check to see if watch already exists
@@ -243,7 +275,9 @@ func (wtc *watches) parseCommand(tokens *commandline.Tokens) error {
 		// that only the larger set remains, it may confuse the user
 		if w.ai.address == nw.ai.address &&
 			w.ai.read == nw.ai.read &&
-			w.matchValue == nw.matchValue && w.value == nw.value {
+			w.matchValue == nw.matchValue && w.value == nw.value &&
+			(w.cond == nil) == (nw.cond == nil) &&
+			(w.cond == nil || w.cond.String() == nw.cond.String()) {
 			return curated.Errorf("already being watched (%s)", w)
 		}
 	}
@@ -68,4 +68,18 @@ func (trm *mockTerm) testWatches() {
 	// last item in list watches should be the new entry
 	trm.sndInput("LIST WATCHES")
 	trm.cmpOutput(" 1: 0x0000 (VSYNC) (TIA) write (value=0x01)")
+
+	// add conditional watch
+	trm.sndInput("WATCH WRITE VSYNC IF SL > 100")
+	trm.cmpOutput("")
+
+	trm.sndInput("LIST WATCHES")
+	trm.cmpOutput(" 2: 0x0000 (VSYNC) (TIA) write if SL > 100")
+
+	// add conditional watch with a specific value
+	trm.sndInput("WATCH WRITE VSYNC 0x1 IF SL > 100")
+	trm.cmpOutput("")
+
+	trm.sndInput("LIST WATCHES")
+	trm.cmpOutput(" 3: 0x0000 (VSYNC) (TIA) write (value=0x01) if SL > 100")
 }
@@ -0,0 +1,66 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/symbols"
+)
+
+// print every instruction that refers to the address. the address can be
+// given as any symbol or numerically.
+func (dbg *Debugger) printXrefs(arg string) error {
+	var address uint16
+
+	if ok, _, _, a := dbg.Disasm.Symbols.Search(arg, symbols.UnspecifiedSymTable); ok {
+		address = a
+	} else {
+		ai := dbg.dbgmem.mapAddress(arg, true)
+		if ai == nil {
+			return curated.Errorf("invalid address (%s)", arg)
+		}
+		address = ai.address
+	}
+
+	refs := dbg.Disasm.Xrefs(address)
+	if len(refs) == 0 {
+		dbg.printLine(terminal.StyleFeedback, "no references to %#04x", address)
+		return nil
+	}
+
+	for _, x := range refs {
+		s := strings.Builder{}
+		if x.Entry != nil {
+			s.WriteString(strings.TrimSpace(x.Entry.String()))
+		} else {
+			s.WriteString(fmt.Sprintf("$%04x", x.Address))
+		}
+		s.WriteString(fmt.Sprintf(" [%s]", x.Kind))
+		if l, _, ok := dbg.Disasm.Symbols.NearestLabel(x.Address); ok {
+			s.WriteString(fmt.Sprintf(" in %s", l))
+		}
+		if x.Runtime {
+			s.WriteString(" (runtime)")
+		}
+		dbg.printLine(terminal.StyleFeedback, "bank %d %s", x.Bank, s.String())
+	}
+
+	return nil
+}
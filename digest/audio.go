@@ -21,6 +21,7 @@ import (
 
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
 )
 
 // the length of the buffer we're using isn't really important. that said, it
@@ -73,8 +74,8 @@ func (dig *Audio) ResetDigest() {
 }
 
 // SetAudio implements the television.AudioMixer interface.
-func (dig *Audio) SetAudio(audioData uint8) error {
-	dig.buffer[dig.bufferCt] = audioData
+func (dig *Audio) SetAudio(sig signal.SignalAttributes) error {
+	dig.buffer[dig.bufferCt] = sig.AudioData
 
 	dig.bufferCt++
 
@@ -125,7 +125,8 @@ func (dig *Video) SetPixel(sig signal.SignalAttributes, _ bool) error {
 	i += sig.HorizPos * pixelDepth
 
 	if i <= len(dig.pixels)-pixelDepth {
-		col := dig.spec.GetColor(sig.Pixel)
+		// the digest must not depend on the palette preferences
+		col := dig.spec.GetCanonicalColor(sig.Pixel)
 
 		// setting every pixel regardless of vblank value
 		dig.pixels[i] = col.R
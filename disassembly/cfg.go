@@ -0,0 +1,551 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// CFGEdgeKind describes how control passes from one block to another.
+type CFGEdgeKind int
+
+// List of valid CFGEdgeKind values.
+const (
+	// execution continues with the next instruction
+	EdgeFallthrough CFGEdgeKind = iota
+
+	// a successful branch
+	EdgeBranch
+
+	// an absolute JMP
+	EdgeJump
+
+	// a JMP through a vector. if the vector is in the cartridge then the
+	// destination is known, otherwise the edge has no destination
+	EdgeIndirect
+
+	// a JSR to a subroutine
+	EdgeCall
+
+	// an RTS returning to the instruction after a JSR
+	EdgeReturn
+
+	// access of a bank switching hotspot. if the bank can't be determined
+	// from the hotspot then the edge has no destination
+	EdgeBankSwitch
+)
+
+func (k CFGEdgeKind) String() string {
+	switch k {
+	case EdgeFallthrough:
+		return "fallthrough"
+	case EdgeBranch:
+		return "branch"
+	case EdgeJump:
+		return "jump"
+	case EdgeIndirect:
+		return "indirect"
+	case EdgeCall:
+		return "call"
+	case EdgeReturn:
+		return "return"
+	case EdgeBankSwitch:
+		return "bankswitch"
+	}
+	return ""
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (k CFGEdgeKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+// CFGBlock is a basic block: a sequence of instructions with a single entry
+// point and a single exit point.
+type CFGBlock struct {
+	ID    string `json:"id"`
+	Bank  int    `json:"bank"`
+	Label string `json:"label,omitempty"`
+
+	// address of the first and last instruction in the block
+	Start uint16 `json:"start"`
+	End   uint16 `json:"end"`
+
+	// disassembly of each instruction in the block
+	Instructions []string `json:"instructions"`
+}
+
+// CFGEdge connects two blocks in the graph. To is the ID of the destination
+// block and will be empty if the destination is not known.
+type CFGEdge struct {
+	Kind CFGEdgeKind `json:"kind"`
+	From string      `json:"from"`
+	To   string      `json:"to,omitempty"`
+
+	// additional information about the edge. for example, the name of the
+	// hotspot for a bankswitch edge
+	Note string `json:"note,omitempty"`
+}
+
+// CFG is the control flow graph for one or more banks of the disassembly.
+type CFG struct {
+	Blocks []*CFGBlock `json:"blocks"`
+	Edges  []CFGEdge   `json:"edges"`
+}
+
+// the ID of the block beginning at the address in the bank.
+func cfgBlockID(bank int, address uint16) string {
+	return fmt.Sprintf("b%d_%04x", bank, address&memorymap.CartridgeBits)
+}
+
+// a JSR instruction and the address it returns to.
+type cfgCall struct {
+	from   *CFGBlock
+	target uint16
+	ret    uint16
+}
+
+// CFG creates the control flow graph for the specified bank. If bank is
+// negative then the graph will be for every bank in the cartridge.
+//
+// Only blessed entries are considered. Blocks end with flow control
+// instructions and with any access of a bank switching hotspot.
+func (dsm *Disassembly) CFG(bank int) (*CFG, error) {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	if bank >= len(dsm.entries) {
+		return nil, curated.Errorf("disassembly: no such bank (%d)", bank)
+	}
+
+	cfg := &CFG{
+		Blocks: make([]*CFGBlock, 0),
+		Edges:  make([]CFGEdge, 0),
+	}
+
+	for b := range dsm.entries {
+		if bank < 0 || b == bank {
+			dsm.cfgBank(cfg, b)
+		}
+	}
+
+	return cfg, nil
+}
+
+// the hotspot accessed by the entry, if any.
+func (dsm *Disassembly) cfgHotspot(e *Entry) (mapper.CartHotspotInfo, bool) {
+	if dsm.cart == nil {
+		return mapper.CartHotspotInfo{}, false
+	}
+
+	switch e.Result.Defn.AddressingMode {
+	case instructions.Absolute, instructions.AbsoluteIndexedX, instructions.AbsoluteIndexedY:
+	default:
+		return mapper.CartHotspotInfo{}, false
+	}
+
+	switch e.Result.Defn.Effect {
+	case instructions.Read, instructions.Write, instructions.RMW:
+	default:
+		return mapper.CartHotspotInfo{}, false
+	}
+
+	hb := dsm.cart.GetCartHotspots()
+	if hb == nil {
+		return mapper.CartHotspotInfo{}, false
+	}
+
+	var hotspots map[uint16]mapper.CartHotspotInfo
+	read := e.Result.Defn.Effect == instructions.Read
+	if read {
+		hotspots = hb.ReadHotspots()
+	} else {
+		hotspots = hb.WriteHotspots()
+	}
+
+	ma, area := memorymap.MapAddress(e.Result.InstructionData, read)
+	if area != memorymap.Cartridge {
+		return mapper.CartHotspotInfo{}, false
+	}
+
+	h, ok := hotspots[ma]
+	if !ok || h.Action != mapper.HotspotBankSwitch {
+		return mapper.CartHotspotInfo{}, false
+	}
+
+	return h, true
+}
+
+// the bank selected by a bank switching hotspot. hotspots for the common
+// mappers are named after the bank they select (eg. BANK1). returns -1 if the
+// bank can't be determined.
+func cfgHotspotBank(h mapper.CartHotspotInfo) int {
+	if !strings.HasPrefix(h.Symbol, "BANK") {
+		return -1
+	}
+	n, err := strconv.Atoi(h.Symbol[4:])
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// does the entry end a basic block.
+func (dsm *Disassembly) cfgEndsBlock(e *Entry) bool {
+	switch e.Result.Defn.Effect {
+	case instructions.Flow, instructions.Subroutine, instructions.Interrupt:
+		return true
+	}
+	_, ok := dsm.cfgHotspot(e)
+	return ok
+}
+
+// the address of a flow instruction's destination.
+func cfgDestination(e *Entry) uint16 {
+	if e.Result.Defn.IsBranch() {
+		return absoluteBranchDestination(e.Result.Address, e.Result.InstructionData)
+	}
+	return e.Result.InstructionData
+}
+
+// add blocks and edges for the bank to the graph. should be called from
+// within a critical section.
+func (dsm *Disassembly) cfgBank(cfg *CFG, bank int) {
+	entries := dsm.entries[bank]
+
+	blessed := func(idx uint16) *Entry {
+		e := entries[idx&memorymap.CartridgeBits]
+		if e == nil || e.Level < EntryLevelBlessed || e.IsData() || e.Result.Defn == nil {
+			return nil
+		}
+		return e
+	}
+
+	inCart := func(address uint16) bool {
+		_, area := memorymap.MapAddress(address, true)
+		return area == memorymap.Cartridge
+	}
+
+	// find the leaders (the first instruction of a block)
+	leaders := make(map[uint16]bool)
+	next := -1
+	for i := range entries {
+		e := blessed(uint16(i))
+		if e == nil {
+			continue // for loop
+		}
+
+		// instructions that don't follow on from the previous instruction
+		// are the start of a new block
+		if i != next {
+			leaders[uint16(i)] = true
+		}
+		next = i + e.Result.Defn.Bytes
+
+		if dsm.cfgEndsBlock(e) {
+			if next < len(entries) {
+				leaders[uint16(next)] = true
+			}
+			if e.Result.Defn.Effect == instructions.Flow || e.Result.Defn.Effect == instructions.Subroutine {
+				if d := cfgDestination(e); inCart(d) && e.Result.Defn.AddressingMode != instructions.Indirect {
+					leaders[d&memorymap.CartridgeBits] = true
+				}
+			}
+		}
+	}
+
+	// create blocks
+	blocks := make(map[uint16]*CFGBlock)
+	lastEntry := make(map[*CFGBlock]*Entry)
+	order := make([]*CFGBlock, 0)
+
+	var blk *CFGBlock
+	for i := range entries {
+		// non-contiguous instructions will have been marked as leaders so we
+		// don't need to end the block when there is no instruction
+		e := blessed(uint16(i))
+		if e == nil {
+			continue // for loop
+		}
+
+		if blk == nil || leaders[uint16(i)] {
+			blk = &CFGBlock{
+				ID:    cfgBlockID(bank, e.Result.Address),
+				Bank:  bank,
+				Start: e.Result.Address,
+			}
+			if dsm.Symbols != nil {
+				if l, ok := dsm.Symbols.LookupLabel(bank, e.Result.Address); ok {
+					blk.Label = l
+				}
+			}
+			blocks[uint16(i)] = blk
+			order = append(order, blk)
+		}
+
+		blk.End = e.Result.Address
+		blk.Instructions = append(blk.Instructions, fmt.Sprintf("%s %s %s", e.Address, e.Mnemonic, e.Operand.String()))
+		lastEntry[blk] = e
+
+		if dsm.cfgEndsBlock(e) {
+			blk = nil
+		}
+	}
+
+	// edges
+	edges := make([]CFGEdge, 0)
+	calls := make([]cfgCall, 0)
+	successors := make(map[*CFGBlock][]*CFGBlock)
+
+	edge := func(kind CFGEdgeKind, from *CFGBlock, toBank int, to uint16, note string) {
+		var id string
+		if toBank == bank {
+			if !inCart(to) {
+				return
+			}
+			t, ok := blocks[to&memorymap.CartridgeBits]
+			if !ok {
+				// destination is not a blessed instruction in this bank
+				return
+			}
+			id = t.ID
+			if kind != EdgeCall {
+				successors[from] = append(successors[from], t)
+			}
+		} else if toBank >= 0 {
+			id = cfgBlockID(toBank, to)
+		}
+		edges = append(edges, CFGEdge{Kind: kind, From: from.ID, To: id, Note: note})
+	}
+
+	for _, blk := range order {
+		e := lastEntry[blk]
+		defn := e.Result.Defn
+		nextAddress := e.Result.Address + uint16(defn.Bytes)
+
+		if h, ok := dsm.cfgHotspot(e); ok {
+			// execution continues at the next address but in the new bank
+			b := cfgHotspotBank(h)
+			if b == bank {
+				edge(EdgeFallthrough, blk, bank, nextAddress, "")
+			} else if b >= 0 && b < len(dsm.entries) {
+				edge(EdgeBankSwitch, blk, b, nextAddress, h.Symbol)
+			} else {
+				edge(EdgeBankSwitch, blk, -1, 0, h.Symbol)
+			}
+			continue // for loop
+		}
+
+		switch defn.Effect {
+		case instructions.Flow:
+			if defn.IsBranch() {
+				edge(EdgeBranch, blk, bank, cfgDestination(e), "")
+				edge(EdgeFallthrough, blk, bank, nextAddress, "")
+			} else if defn.AddressingMode == instructions.Indirect {
+				vector := e.Result.InstructionData
+				if d, ok := dsm.cfgVector(bank, vector); ok {
+					edge(EdgeIndirect, blk, bank, d, fmt.Sprintf("($%04x)", vector))
+				} else {
+					edge(EdgeIndirect, blk, -1, 0, fmt.Sprintf("($%04x)", vector))
+				}
+			} else {
+				edge(EdgeJump, blk, bank, cfgDestination(e), "")
+			}
+
+		case instructions.Subroutine:
+			if defn.Mnemonic == "JSR" {
+				edge(EdgeCall, blk, bank, cfgDestination(e), "")
+				calls = append(calls, cfgCall{from: blk, target: cfgDestination(e), ret: nextAddress})
+			}
+
+		case instructions.Interrupt:
+			// BRK and RTI. the destination of neither is known
+
+		default:
+			edge(EdgeFallthrough, blk, bank, nextAddress, "")
+		}
+	}
+
+	// RTS instructions return to the instruction after the JSR. for each JSR
+	// we follow the blocks from the subroutine until an RTS is found. JSR
+	// instructions inside the subroutine are stepped over
+	returns := make(map[*CFGBlock]uint16)
+	for _, c := range calls {
+		returns[c.from] = c.ret
+	}
+
+	done := make(map[string]bool)
+	for _, c := range calls {
+		start, ok := blocks[c.target&memorymap.CartridgeBits]
+		if !ok {
+			continue // for loop
+		}
+
+		visited := make(map[*CFGBlock]bool)
+		queue := []*CFGBlock{start}
+		for len(queue) > 0 {
+			blk := queue[0]
+			queue = queue[1:]
+			if visited[blk] {
+				continue // for loop
+			}
+			visited[blk] = true
+
+			if lastEntry[blk].Result.Defn.Mnemonic == "RTS" {
+				if t, ok := blocks[c.ret&memorymap.CartridgeBits]; ok {
+					k := blk.ID + t.ID
+					if !done[k] {
+						done[k] = true
+						edges = append(edges, CFGEdge{Kind: EdgeReturn, From: blk.ID, To: t.ID})
+					}
+				}
+				continue // for loop
+			}
+
+			queue = append(queue, successors[blk]...)
+			if ret, ok := returns[blk]; ok {
+				if t, ok := blocks[ret&memorymap.CartridgeBits]; ok {
+					queue = append(queue, t)
+				}
+			}
+		}
+	}
+
+	cfg.Blocks = append(cfg.Blocks, order...)
+	cfg.Edges = append(cfg.Edges, edges...)
+}
+
+// the destination of an indirect JMP if the vector is in the cartridge.
+// should be called from within a critical section.
+func (dsm *Disassembly) cfgVector(bank int, vector uint16) (uint16, bool) {
+	// the high byte of the vector is subject to the page wrapping bug
+	lo := vector
+	hi := (vector & 0xff00) | ((vector + 1) & 0x00ff)
+
+	read := func(address uint16) (uint8, bool) {
+		if _, area := memorymap.MapAddress(address, true); area != memorymap.Cartridge {
+			return 0, false
+		}
+		e := dsm.entries[bank][address&memorymap.CartridgeBits]
+		if e == nil || e.Result.Defn == nil {
+			return 0, false
+		}
+
+		// every address in the cartridge has been decoded so the opcode of
+		// the entry is the byte at the address
+		return e.Result.Defn.OpCode, true
+	}
+
+	l, ok := read(lo)
+	if !ok {
+		return 0, false
+	}
+	h, ok := read(hi)
+	if !ok {
+		return 0, false
+	}
+
+	return uint16(h)<<8 | uint16(l), true
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Each block is drawn
+// as a box containing its instructions.
+func (cfg *CFG) WriteDOT(output io.Writer) error {
+	s := &strings.Builder{}
+
+	s.WriteString("digraph cfg {\n")
+	s.WriteString("\tnode [shape=box fontname=monospace];\n")
+
+	escape := func(s string) string {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+		return strings.ReplaceAll(s, `"`, `\"`)
+	}
+
+	for _, blk := range cfg.Blocks {
+		l := &strings.Builder{}
+		l.WriteString(fmt.Sprintf("bank %d", blk.Bank))
+		if blk.Label != "" {
+			l.WriteString(fmt.Sprintf(" %s", escape(blk.Label)))
+		}
+		l.WriteString(`\l`)
+		for _, i := range blk.Instructions {
+			l.WriteString(escape(i))
+			l.WriteString(`\l`)
+		}
+		s.WriteString(fmt.Sprintf("\t%s [label=\"%s\"];\n", blk.ID, l.String()))
+	}
+
+	// edges without a destination are drawn to an unknown node
+	unknown := 0
+
+	for _, e := range cfg.Edges {
+		to := e.To
+		if to == "" {
+			to = fmt.Sprintf("unknown%d", unknown)
+			unknown++
+			s.WriteString(fmt.Sprintf("\t%s [label=\"?\" shape=circle];\n", to))
+		}
+
+		attr := fmt.Sprintf("label=\"%s\"", e.Kind)
+		if e.Note != "" {
+			attr = fmt.Sprintf("label=\"%s %s\"", e.Kind, escape(e.Note))
+		}
+
+		switch e.Kind {
+		case EdgeCall, EdgeReturn:
+			attr += " style=dashed"
+		case EdgeBankSwitch:
+			attr += " style=bold color=red"
+		case EdgeIndirect:
+			attr += " style=dotted"
+		}
+
+		s.WriteString(fmt.Sprintf("\t%s -> %s [%s];\n", e.From, to, attr))
+	}
+
+	s.WriteString("}\n")
+
+	_, err := output.Write([]byte(s.String()))
+	if err != nil {
+		return curated.Errorf("disassembly: %v", err)
+	}
+
+	return nil
+}
+
+// WriteJSON writes the graph as JSON.
+func (cfg *CFG) WriteJSON(output io.Writer) error {
+	b, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return curated.Errorf("disassembly: %v", err)
+	}
+
+	_, err = output.Write(b)
+	if err != nil {
+		return curated.Errorf("disassembly: %v", err)
+	}
+
+	return nil
+}
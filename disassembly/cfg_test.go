@@ -0,0 +1,142 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+)
+
+// edges as strings in the form "from kind to".
+func cfgEdges(cfg *disassembly.CFG) map[string]bool {
+	edges := make(map[string]bool)
+	for _, e := range cfg.Edges {
+		edges[fmt.Sprintf("%s %s %s", e.From, e.Kind, e.To)] = true
+	}
+	return edges
+}
+
+func TestCFG(t *testing.T) {
+	data := testROM(t, []uint8{
+		0xa5, 0x80, // f000 LDA $80
+		0xd0, 0x03, // f002 BNE $f007
+		0x20, 0x0d, 0xf0, // f004 JSR $f00d
+		0x6c, 0x10, 0xf0, // f007 JMP ($f010)
+		0x00, 0x00, 0x00,
+		0xe6, 0x81, // f00d INC $81
+		0x60,       // f00f RTS
+		0x00, 0xf0, // f010 vector to $f000
+	})
+
+	dsm, err := disassembly.FromCartridge(cartridgeloader.Loader{
+		Filename: "test_cfg",
+		Mapping:  "4k",
+		Data:     data,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := dsm.CFG(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	blocks := make(map[string]*disassembly.CFGBlock)
+	for _, b := range cfg.Blocks {
+		blocks[b.ID] = b
+	}
+
+	for _, id := range []string{"b0_0000", "b0_0004", "b0_0007", "b0_000d"} {
+		if _, ok := blocks[id]; !ok {
+			t.Errorf("expected block %s", id)
+		}
+	}
+	if b, ok := blocks["b0_0000"]; ok && len(b.Instructions) != 2 {
+		t.Errorf("expected two instructions in first block (%d)", len(b.Instructions))
+	}
+
+	edges := cfgEdges(cfg)
+	for _, e := range []string{
+		"b0_0000 branch b0_0007",
+		"b0_0000 fallthrough b0_0004",
+		"b0_0004 call b0_000d",
+		"b0_000d return b0_0007",
+		"b0_0007 indirect b0_0000",
+	} {
+		if !edges[e] {
+			t.Errorf("expected edge: %s", e)
+		}
+	}
+
+	dot := &bytes.Buffer{}
+	err = cfg.WriteDOT(dot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(dot.String(), "digraph cfg {") || !strings.Contains(dot.String(), "b0_0004 -> b0_000d") {
+		t.Errorf("unexpected DOT output")
+	}
+
+	js := &bytes.Buffer{}
+	err = cfg.WriteJSON(js)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(js.Bytes(), &v); err != nil {
+		t.Errorf("invalid JSON output: %v", err)
+	}
+}
+
+func TestCFGBankSwitch(t *testing.T) {
+	// bank 0 switches to bank 1. the instruction following the hotspot
+	// access is in bank 1
+	data := testROM(t, []uint8{
+		0xad, 0xf9, 0x1f, // f000 LDA BANK1
+	}, []uint8{
+		0xea, 0xea, 0xea, // f000 NOP NOP NOP
+		0x4c, 0x03, 0xf0, // f003 JMP $f003
+	})
+
+	dsm, err := disassembly.FromCartridge(cartridgeloader.Loader{
+		Filename: "test_cfg_bankswitch",
+		Mapping:  "F8",
+		Data:     data,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := dsm.CFG(-1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	edges := cfgEdges(cfg)
+	if !edges["b0_0000 bankswitch b1_0003"] {
+		t.Errorf("expected bankswitch edge")
+	}
+	if !edges["b1_0003 jump b1_0003"] {
+		t.Errorf("expected jump edge in bank 1")
+	}
+}
@@ -0,0 +1,350 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/paths"
+)
+
+// Class records how a byte in the cartridge has been accessed during
+// emulation. A byte can belong to more than one class.
+type Class uint8
+
+// List of valid Class flags.
+const (
+	// the byte has been executed as part of an instruction
+	ClassCode Class = 0x01 << iota
+
+	// the byte has been read by an instruction that is not an immediate
+	// instruction. for example, LDA $f800,X
+	ClassData
+
+	// the byte has been read and then written to one of the TIA graphics
+	// registers
+	ClassGFX
+
+	// the byte has been used as the vector for an indirect JMP
+	ClassVector
+)
+
+// String returns the name of the dominant class. Code takes precedence over
+// all other classes because a data table can overlap executable code.
+func (c Class) String() string {
+	switch {
+	case c&ClassCode == ClassCode:
+		return "CODE"
+	case c&ClassGFX == ClassGFX:
+		return "GFX"
+	case c&ClassVector == ClassVector:
+		return "VECTOR"
+	case c&ClassData == ClassData:
+		return "DATA"
+	}
+	return ""
+}
+
+// the subdirectory in the resource path where classification files are stored
+const classificationPath = "classification"
+
+// TIA registers that take graphics data. values written to these registers
+// from a cartridge address will cause the address to be classified as GFX.
+var gfxRegisters = map[uint16]bool{
+	addresses.WriteAddress["GRP0"]: true,
+	addresses.WriteAddress["GRP1"]: true,
+	addresses.WriteAddress["PF0"]:  true,
+	addresses.WriteAddress["PF1"]:  true,
+	addresses.WriteAddress["PF2"]:  true,
+}
+
+// the source of a value currently in a CPU register
+type classSource struct {
+	valid bool
+	bank  int
+	idx   uint16
+}
+
+// tracks the source of the values in the A, X and Y registers
+type classTrace struct {
+	a classSource
+	x classSource
+	y classSource
+}
+
+// Classify the bytes accessed by the most recently executed instruction. The
+// accessAddress and accessWrite arguments describe the final memory access of
+// the instruction.
+//
+// Should be called after every instruction, after ExecutedEntry().
+func (dsm *Disassembly) Classify(bank mapper.BankInfo, result execution.Result, accessAddress uint16, accessWrite bool) {
+	if result.Defn == nil || !result.Final {
+		return
+	}
+
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	// executed bytes. instructions executing from RAM are not classified
+	if !bank.NonCart && !bank.IsRAM && bank.Number < len(dsm.entries) {
+		for i := 0; i < result.Defn.Bytes; i++ {
+			dsm.mark(bank.Number, result.Address+uint16(i), ClassCode)
+		}
+	}
+
+	defn := result.Defn
+
+	// indirect JMP. the high byte of the vector is subject to the page
+	// wrapping bug
+	if defn.AddressingMode == instructions.Indirect {
+		lo := result.InstructionData
+		hi := (lo & 0xff00) | ((lo + 1) & 0x00ff)
+		if src, ok := dsm.cartSource(lo); ok {
+			dsm.mark(src.bank, src.idx, ClassVector)
+		}
+		if src, ok := dsm.cartSource(hi); ok {
+			dsm.mark(src.bank, src.idx, ClassVector)
+		}
+		return
+	}
+
+	// the source of any value read by the instruction. immediate and implied
+	// instructions do not read data
+	var src classSource
+	switch defn.AddressingMode {
+	case instructions.Implied, instructions.Immediate, instructions.Relative:
+	default:
+		if defn.Effect == instructions.Read && !accessWrite {
+			if s, ok := dsm.cartSource(accessAddress); ok {
+				src = s
+				dsm.mark(src.bank, src.idx, ClassData)
+			}
+		}
+	}
+
+	switch defn.Mnemonic {
+	case "LDA":
+		dsm.trace.a = src
+	case "LDX":
+		dsm.trace.x = src
+	case "LDY":
+		dsm.trace.y = src
+	case "lax":
+		dsm.trace.a = src
+		dsm.trace.x = src
+	case "ORA", "AND", "EOR":
+		// the result of the logical operation retains the source of the
+		// value already in A unless the operand is itself from the cartridge
+		if src.valid {
+			dsm.trace.a = src
+		}
+	case "TAX":
+		dsm.trace.x = dsm.trace.a
+	case "TAY":
+		dsm.trace.y = dsm.trace.a
+	case "TXA":
+		dsm.trace.a = dsm.trace.x
+	case "TYA":
+		dsm.trace.a = dsm.trace.y
+	case "ADC", "SBC", "PLA":
+		dsm.trace.a = classSource{}
+	case "INX", "DEX", "TSX":
+		dsm.trace.x = classSource{}
+	case "INY", "DEY":
+		dsm.trace.y = classSource{}
+	case "STA":
+		dsm.classifyStore(dsm.trace.a, accessAddress, accessWrite)
+	case "STX":
+		dsm.classifyStore(dsm.trace.x, accessAddress, accessWrite)
+	case "STY":
+		dsm.classifyStore(dsm.trace.y, accessAddress, accessWrite)
+	}
+}
+
+// if a register with a cartridge source is written to a graphics register
+// then the source is classified as GFX.
+func (dsm *Disassembly) classifyStore(src classSource, accessAddress uint16, accessWrite bool) {
+	if !src.valid || !accessWrite {
+		return
+	}
+	ma, area := memorymap.MapAddress(accessAddress, false)
+	if area != memorymap.TIA {
+		return
+	}
+	if gfxRegisters[ma] {
+		dsm.mark(src.bank, src.idx, ClassGFX)
+	}
+}
+
+// returns the bank and index of a cartridge ROM address. the second return
+// value is false if the address is not in cartridge ROM.
+func (dsm *Disassembly) cartSource(address uint16) (classSource, bool) {
+	if _, area := memorymap.MapAddress(address, true); area != memorymap.Cartridge {
+		return classSource{}, false
+	}
+
+	bank := dsm.cart.GetBank(address)
+	if bank.NonCart || bank.IsRAM || bank.Number >= len(dsm.entries) {
+		return classSource{}, false
+	}
+
+	return classSource{
+		valid: true,
+		bank:  bank.Number,
+		idx:   address & memorymap.CartridgeBits,
+	}, true
+}
+
+// add class to the entry at the bank/address. should be called from within a
+// critical section.
+func (dsm *Disassembly) mark(bank int, address uint16, class Class) {
+	e := dsm.entries[bank][address&memorymap.CartridgeBits]
+	if e != nil {
+		e.Class |= class
+	}
+}
+
+// ClassificationSummary returns the number of bytes in each class.
+func (dsm *Disassembly) ClassificationSummary() map[Class]int {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	sum := make(map[Class]int)
+	for b := range dsm.entries {
+		for _, e := range dsm.entries[b] {
+			if e == nil || e.Class == 0 {
+				continue
+			}
+			for _, c := range []Class{ClassCode, ClassData, ClassGFX, ClassVector} {
+				if e.Class&c == c {
+					sum[c]++
+				}
+			}
+		}
+	}
+
+	return sum
+}
+
+// ResetClassification forgets the classification of every byte.
+func (dsm *Disassembly) ResetClassification() {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	for b := range dsm.entries {
+		for _, e := range dsm.entries[b] {
+			if e != nil {
+				e.Class = 0
+			}
+		}
+	}
+
+	dsm.trace = classTrace{}
+}
+
+// the name of the file used to store the classification for the current
+// cartridge. returns the empty string if the cartridge has no hash.
+func (dsm *Disassembly) classificationFile() (string, error) {
+	if dsm.cart == nil || dsm.cart.Hash == "" {
+		return "", nil
+	}
+	return paths.ResourcePath(classificationPath, dsm.cart.Hash)
+}
+
+// SaveClassification writes the classification for the current cartridge to
+// disk. The file is keyed by the cartridge hash so the classification can be
+// reloaded the next time the same ROM is used.
+func (dsm *Disassembly) SaveClassification() error {
+	pth, err := dsm.classificationFile()
+	if err != nil {
+		return curated.Errorf("disassembly: %v", err)
+	}
+	if pth == "" {
+		return nil
+	}
+
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	s := &strings.Builder{}
+	for b := range dsm.entries {
+		for i, e := range dsm.entries[b] {
+			if e != nil && e.Class != 0 {
+				s.WriteString(fmt.Sprintf("%d %04x %02x\n", b, i, uint8(e.Class)))
+			}
+		}
+	}
+
+	// don't create a file if there is nothing to save
+	if s.Len() == 0 {
+		return nil
+	}
+
+	err = os.WriteFile(pth, []byte(s.String()), 0600)
+	if err != nil {
+		return curated.Errorf("disassembly: %v", err)
+	}
+
+	return nil
+}
+
+// load classification for current cartridge. a missing file is not an error.
+func (dsm *Disassembly) loadClassification() error {
+	pth, err := dsm.classificationFile()
+	if err != nil {
+		return curated.Errorf("disassembly: %v", err)
+	}
+	if pth == "" {
+		return nil
+	}
+
+	f, err := os.Open(pth)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return curated.Errorf("disassembly: %v", err)
+	}
+	defer f.Close()
+
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var b int
+		var idx uint16
+		var c uint8
+
+		// ignore malformed lines and lines that refer to non-existant banks
+		_, err := fmt.Sscanf(scanner.Text(), "%d %x %x", &b, &idx, &c)
+		if err != nil || b < 0 || b >= len(dsm.entries) {
+			continue
+		}
+		dsm.mark(b, idx, Class(c))
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/paths"
+)
+
+func TestClassify(t *testing.T) {
+	data := testROM(t, nil)
+	data[0x805] = 0x3c
+
+	cartload := cartridgeloader.Loader{
+		Filename: "test_classify",
+		Mapping:  "4k",
+		Data:     data,
+		Hash:     "test_classify",
+	}
+
+	pth, err := paths.ResourcePath("classification", cartload.Hash)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(pth)
+
+	dsm, err := disassembly.FromCartridge(cartload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defns := instructions.GetDefinitions()
+	bank := mapper.BankInfo{Number: 0}
+
+	step := func(opcode uint8, address uint16, operand uint16, accessAddress uint16, accessWrite bool) {
+		dsm.Classify(bank, execution.Result{
+			Defn:            defns[opcode],
+			Address:         address,
+			InstructionData: operand,
+			Final:           true,
+		}, accessAddress, accessWrite)
+	}
+
+	step(0xbd, 0xf000, 0xf800, 0xf805, false) // LDA $f800,X
+	step(0xaa, 0xf003, 0x0000, 0xf004, false) // TAX
+	step(0x86, 0xf004, 0x001b, 0x001b, true)  // STX GRP0
+	step(0xad, 0xf006, 0xf810, 0xf810, false) // LDA $f810
+	step(0x69, 0xf009, 0x0001, 0xf00a, false) // ADC #$01
+	step(0x85, 0xf00b, 0x001c, 0x001c, true)  // STA GRP1
+	step(0x6c, 0xf00d, 0xf9ff, 0xf900, false) // JMP ($f9ff)
+
+	expected := map[uint16]disassembly.Class{
+		0xf000: disassembly.ClassCode,
+		0xf002: disassembly.ClassCode,
+		0xf805: disassembly.ClassData | disassembly.ClassGFX,
+		0xf810: disassembly.ClassData,
+		0xf9ff: disassembly.ClassVector,
+		0xf900: disassembly.ClassVector,
+		0xfa00: 0,
+	}
+
+	check := func(dsm *disassembly.Disassembly) {
+		t.Helper()
+		for addr, c := range expected {
+			e := dsm.GetEntryByAddress(addr)
+			if e.Class != c {
+				t.Errorf("unexpected class for %#04x: %#02x (expected %#02x)", addr, e.Class, c)
+			}
+		}
+	}
+	check(dsm)
+
+	// data is written as data in the listing. graphics data has a bitmap
+	s := &strings.Builder{}
+	err = dsm.WriteBank(s, disassembly.WriteAttr{}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(s.String(), ".byte $3c ; GFX ..XXXX..") {
+		t.Errorf("expected graphics data in listing")
+	}
+	if !strings.Contains(s.String(), ".byte $00 ; VECTOR") {
+		t.Errorf("expected vector data in listing")
+	}
+
+	// classification should be restored for the same cartridge
+	err = dsm.SaveClassification()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dsm, err = disassembly.FromCartridge(cartload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	check(dsm)
+
+	dsm.ResetClassification()
+	if len(dsm.ClassificationSummary()) != 0 {
+		t.Errorf("expected no classification after reset")
+	}
+}
@@ -0,0 +1,256 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// CoverageSummary is returned by the Coverage() function and summarises the
+// coverage information for a single bank.
+type CoverageSummary struct {
+	Bank int
+
+	// the number of blessed (or executed) entries in the bank
+	Entries int
+
+	// the number of those entries that have been executed at least once
+	Executed int
+
+	// the total number of executions and cycles for all entries in the bank
+	ExecutionCount  int
+	ExecutionCycles int
+}
+
+func (cov CoverageSummary) String() string {
+	pct := 0.0
+	if cov.Entries > 0 {
+		pct = float64(cov.Executed) / float64(cov.Entries) * 100
+	}
+	return fmt.Sprintf("bank %d: %d of %d entries executed (%.1f%%) %d instructions %d cycles",
+		cov.Bank, cov.Executed, cov.Entries, pct, cov.ExecutionCount, cov.ExecutionCycles)
+}
+
+// Coverage returns a summary of the execution coverage for every bank in the
+// cartridge.
+func (dsm *Disassembly) Coverage() []CoverageSummary {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	cov := make([]CoverageSummary, len(dsm.entries))
+
+	for b := range dsm.entries {
+		cov[b].Bank = b
+		for _, e := range dsm.entries[b] {
+			if e == nil || e.Level < EntryLevelBlessed {
+				continue
+			}
+			cov[b].Entries++
+			if e.ExecutionCount > 0 {
+				cov[b].Executed++
+				cov[b].ExecutionCount += e.ExecutionCount
+				cov[b].ExecutionCycles += e.ExecutionCycles
+			}
+		}
+	}
+
+	return cov
+}
+
+// ResetCoverage sets the execution count and cycles of every entry to zero.
+func (dsm *Disassembly) ResetCoverage() {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	for b := range dsm.entries {
+		for _, e := range dsm.entries[b] {
+			if e != nil {
+				e.ExecutionCount = 0
+				e.ExecutionCycles = 0
+			}
+		}
+	}
+}
+
+// HotEntries returns the n entries that have consumed the most cycles, in
+// descending order. The returned entries are copies of the disassembly
+// entries.
+//
+// A negative value for n is treated as zero.
+func (dsm *Disassembly) HotEntries(n int) []*Entry {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	if n < 0 {
+		n = 0
+	}
+
+	hot := make([]*Entry, 0)
+	for b := range dsm.entries {
+		for _, e := range dsm.entries[b] {
+			if e != nil && e.ExecutionCount > 0 {
+				hot = append(hot, makeCopyofEntry(*e))
+			}
+		}
+	}
+
+	sort.SliceStable(hot, func(i, j int) bool {
+		return hot[i].ExecutionCycles > hot[j].ExecutionCycles
+	})
+
+	if n < len(hot) {
+		hot = hot[:n]
+	}
+
+	return hot
+}
+
+// DeadEntries returns the blessed entries in the bank that have never been
+// executed. The returned entries are copies of the disassembly entries.
+func (dsm *Disassembly) DeadEntries(bank int) ([]*Entry, error) {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	if bank < 0 || bank > len(dsm.entries)-1 {
+		return nil, curated.Errorf("disassembly: no such bank (%d)", bank)
+	}
+
+	dead := make([]*Entry, 0)
+	for _, e := range dsm.entries[bank] {
+		if e != nil && e.Level >= EntryLevelBlessed && e.ExecutionCount == 0 {
+			dead = append(dead, makeCopyofEntry(*e))
+		}
+	}
+
+	return dead, nil
+}
+
+// CoverageFormat specifies the output format of WriteCoverage().
+type CoverageFormat int
+
+// List of valid CoverageFormat values.
+const (
+	// human readable listing.
+	CoverageText CoverageFormat = iota
+
+	// a format similar to the tracefile format used by LCOV. Each bank is
+	// treated as a source file and the address of each entry is used as the
+	// line number.
+	CoverageLCOV
+)
+
+// WriteCoverage writes the coverage report for the entire cartridge to
+// io.Writer.
+func (dsm *Disassembly) WriteCoverage(output io.Writer, format CoverageFormat) error {
+	cov := dsm.Coverage()
+
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	switch format {
+	case CoverageText:
+		for b := range dsm.entries {
+			dsm.writeCoverageText(output, cov[b])
+		}
+	case CoverageLCOV:
+		output.Write([]byte(fmt.Sprintf("TN:%s\n", dsm.cart.Hash)))
+		for b := range dsm.entries {
+			dsm.writeCoverageLCOV(output, b)
+		}
+	default:
+		return curated.Errorf("disassembly: unknown coverage format")
+	}
+
+	return nil
+}
+
+func (dsm *Disassembly) writeCoverageText(output io.Writer, cov CoverageSummary) {
+	output.Write([]byte(fmt.Sprintf("--- bank %d ---\n", cov.Bank)))
+	output.Write([]byte(fmt.Sprintf("%s\n", cov)))
+
+	for _, e := range dsm.entries[cov.Bank] {
+		if e == nil || e.Level < EntryLevelBlessed {
+			continue
+		}
+
+		if e.Label.String() != "" {
+			output.Write([]byte(e.GetField(FldLabel)))
+			output.Write([]byte("\n"))
+		}
+
+		if e.ExecutionCount > 0 {
+			output.Write([]byte(fmt.Sprintf("%10d %10d ", e.ExecutionCount, e.ExecutionCycles)))
+		} else {
+			output.Write([]byte(fmt.Sprintf("%10s %10s ", "-", "-")))
+		}
+
+		output.Write([]byte(e.GetField(FldAddress)))
+		output.Write([]byte(" "))
+		output.Write([]byte(e.GetField(FldMnemonic)))
+		output.Write([]byte(" "))
+		output.Write([]byte(strings.TrimSpace(e.GetField(FldOperand))))
+		output.Write([]byte("\n"))
+	}
+}
+
+// the address of an entry that has been executed is the address used by the
+// CPU, which may be any of the cartridge mirrors. line numbers in the LCOV
+// output are normalised to the cartridge origin so that they are stable.
+func lcovLine(e *Entry) uint16 {
+	return e.Result.Address&memorymap.CartridgeBits | memorymap.OriginCart
+}
+
+func (dsm *Disassembly) writeCoverageLCOV(output io.Writer, bank int) {
+	output.Write([]byte(fmt.Sprintf("SF:%s/bank%d\n", dsm.cart.Filename, bank)))
+
+	// labels are treated as functions
+	var fnFound, fnHit int
+	for _, e := range dsm.entries[bank] {
+		if e == nil || e.Level < EntryLevelBlessed || e.Label.String() == "" {
+			continue
+		}
+		output.Write([]byte(fmt.Sprintf("FN:%d,%s\n", lcovLine(e), e.Label)))
+		output.Write([]byte(fmt.Sprintf("FNDA:%d,%s\n", e.ExecutionCount, e.Label)))
+		fnFound++
+		if e.ExecutionCount > 0 {
+			fnHit++
+		}
+	}
+	output.Write([]byte(fmt.Sprintf("FNF:%d\n", fnFound)))
+	output.Write([]byte(fmt.Sprintf("FNH:%d\n", fnHit)))
+
+	var found, hit int
+	for _, e := range dsm.entries[bank] {
+		if e == nil || e.Level < EntryLevelBlessed {
+			continue
+		}
+		output.Write([]byte(fmt.Sprintf("DA:%d,%d\n", lcovLine(e), e.ExecutionCount)))
+		found++
+		if e.ExecutionCount > 0 {
+			hit++
+		}
+	}
+	output.Write([]byte(fmt.Sprintf("LF:%d\n", found)))
+	output.Write([]byte(fmt.Sprintf("LH:%d\n", hit)))
+
+	output.Write([]byte("end_of_record\n"))
+}
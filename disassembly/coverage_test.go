@@ -0,0 +1,172 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+)
+
+func TestCoverage(t *testing.T) {
+	dsm, err := disassembly.FromCartridge(cartridgeloader.Loader{
+		Filename: "test_coverage",
+		Mapping:  "4k",
+		Data:     testROM(t),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	execute := func(opcode uint8, address uint16, data uint16, bytes int, cycles int) {
+		t.Helper()
+		result := execution.Result{
+			Defn:            instructions.GetDefinitions()[opcode],
+			Address:         address,
+			InstructionData: data,
+			ByteCount:       bytes,
+			Cycles:          cycles,
+			Final:           true,
+		}
+		_, err := dsm.ExecutedEntry(mapper.BankInfo{Number: 0}, result, address+uint16(bytes))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// the number of blessed entries depends on the decoding process. we're
+	// only interested in how many of those entries are executed
+
+	// nothing executed yet
+	cov := dsm.Coverage()
+	if len(cov) != 1 {
+		t.Fatalf("unexpected number of banks in coverage (%d)", len(cov))
+	}
+	if cov[0].Entries == 0 || cov[0].Executed != 0 || cov[0].ExecutionCount != 0 {
+		t.Errorf("unexpected coverage before execution (%s)", cov[0])
+	}
+
+	execute(0xa5, 0xf000, 0x80, 2, 3)
+	execute(0x85, 0xf002, 0x02, 2, 3)
+	execute(0xa5, 0xf000, 0x80, 2, 3)
+	execute(0xbd, 0xf010, 0xf800, 3, 5)
+
+	cov = dsm.Coverage()
+	if cov[0].Executed != 3 || cov[0].ExecutionCount != 4 || cov[0].ExecutionCycles != 14 {
+		t.Errorf("unexpected coverage after execution (%s)", cov[0])
+	}
+
+	// hot entries are ordered by the number of cycles consumed
+	hot := dsm.HotEntries(10)
+	if len(hot) != 3 {
+		t.Fatalf("unexpected number of hot entries (%d)", len(hot))
+	}
+	if hot[0].Result.Address != 0xf000 || hot[0].ExecutionCount != 2 || hot[0].ExecutionCycles != 6 {
+		t.Errorf("unexpected first hot entry (%#04x %d %d)", hot[0].Result.Address, hot[0].ExecutionCount, hot[0].ExecutionCycles)
+	}
+	if hot[1].Result.Address != 0xf010 || hot[1].ExecutionCycles != 5 {
+		t.Errorf("unexpected second hot entry (%#04x %d)", hot[1].Result.Address, hot[1].ExecutionCycles)
+	}
+	if len(dsm.HotEntries(1)) != 1 {
+		t.Errorf("hot entries not limited to requested number")
+	}
+	if len(dsm.HotEntries(0)) != 0 {
+		t.Errorf("hot entries not limited to zero entries")
+	}
+	if len(dsm.HotEntries(-5)) != 0 {
+		t.Errorf("negative number of hot entries should return no entries")
+	}
+
+	// dead entries are the blessed entries that have not been executed
+	dead, err := dsm.DeadEntries(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dead) != cov[0].Entries-cov[0].Executed {
+		t.Errorf("unexpected number of dead entries (%d)", len(dead))
+	}
+	for _, e := range dead {
+		if e.ExecutionCount != 0 {
+			t.Errorf("dead entry has been executed (%#04x)", e.Result.Address)
+		}
+	}
+	if _, err := dsm.DeadEntries(1); err == nil {
+		t.Errorf("expected error for non-existent bank")
+	}
+	if _, err := dsm.DeadEntries(-1); err == nil {
+		t.Errorf("expected error for negative bank")
+	}
+
+	// text export
+	var txt bytes.Buffer
+	err = dsm.WriteCoverage(&txt, disassembly.CoverageText)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(txt.String(), "--- bank 0 ---\n") {
+		t.Errorf("text coverage missing bank header")
+	}
+	if !strings.Contains(txt.String(), cov[0].String()) {
+		t.Errorf("text coverage missing bank summary")
+	}
+	if !strings.Contains(txt.String(), "         2          6 ") {
+		t.Errorf("text coverage missing execution counts")
+	}
+	if strings.Count(txt.String(), "         -          - ") != cov[0].Entries-cov[0].Executed {
+		t.Errorf("text coverage has unexpected number of unexecuted entries")
+	}
+
+	// lcov export. addresses are used as line numbers
+	var lcov bytes.Buffer
+	err = dsm.WriteCoverage(&lcov, disassembly.CoverageLCOV)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range []string{
+		"TN:", "SF:test_coverage/bank0\n",
+		"DA:4096,2\n", "DA:4098,1\n", "DA:4112,1\n", "DA:4100,0\n",
+		fmt.Sprintf("LF:%d\n", cov[0].Entries), "LH:3\n", "end_of_record\n",
+	} {
+		if !strings.Contains(lcov.String(), s) {
+			t.Errorf("lcov coverage missing %q", s)
+		}
+	}
+	if !strings.HasPrefix(lcov.String(), "TN:") || !strings.HasSuffix(lcov.String(), "end_of_record\n") {
+		t.Errorf("lcov coverage is malformed")
+	}
+
+	// unknown format
+	if err := dsm.WriteCoverage(&lcov, disassembly.CoverageFormat(-1)); err == nil {
+		t.Errorf("expected error for unknown coverage format")
+	}
+
+	// reset
+	dsm.ResetCoverage()
+	cov = dsm.Coverage()
+	if cov[0].Executed != 0 || cov[0].ExecutionCount != 0 || cov[0].ExecutionCycles != 0 {
+		t.Errorf("unexpected coverage after reset (%s)", cov[0])
+	}
+	if len(dsm.HotEntries(10)) != 0 {
+		t.Errorf("unexpected hot entries after reset")
+	}
+}
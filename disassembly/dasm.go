@@ -0,0 +1,482 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// the maximum number of data bytes on a single line of DASM output.
+const dasmBytesPerLine = 8
+
+// the kind of a dasmUnit.
+type dasmUnitKind int
+
+const (
+	// an instruction that can be reassembled from its mnemonic and operand
+	dasmInstruction dasmUnitKind = iota
+
+	// an instruction that must be output as data bytes. for example,
+	// undocumented opcodes, which DASM may assemble to a different opcode
+	dasmRawInstruction
+
+	// a single byte of data
+	dasmData
+)
+
+// a dasmUnit is a single instruction or byte of data in the DASM output.
+type dasmUnit struct {
+	kind  dasmUnitKind
+	e     *Entry
+	bytes []uint8
+}
+
+// dasmBank is the information required to output a single bank in DASM
+// format.
+type dasmBank struct {
+	number int
+
+	// offset of the bank in the ROM file
+	offset int
+
+	// the address at which the bank has been assembled (ie. the RORG
+	// address). this takes into account the mirror used by the program
+	rorg uint16
+
+	// units indexed by offset from the start of the bank. units that are not
+	// at the start of an instruction are nil
+	units []*dasmUnit
+
+	// labels indexed by address
+	labels map[uint16]string
+}
+
+// WriteDASM writes the disassembly as source code suitable for reassembly
+// with DASM. Reassembling the source code with DASM (using the -f3 flag) will
+// produce a binary identical to the ROM that has been disassembled.
+//
+// Blessed entries are output as instructions, with labels generated for the
+// targets of branch, JMP and JSR instructions. Everything else is output as
+// data. Undocumented opcodes are always output as data because there is no
+// guarantee that DASM will choose the same opcode when reassembling.
+//
+// The cartridge data that is output is the data returned by the mapper's
+// CopyBanks() function. Some cartridge formats contain data that is not
+// reported by CopyBanks() and for those formats the reassembled binary will
+// not be complete.
+func (dsm *Disassembly) WriteDASM(output io.Writer) error {
+	if dsm.cart == nil {
+		return curated.Errorf("disassembly: %v", "no cartridge to disassemble")
+	}
+
+	copiedBanks, err := dsm.cart.CopyBanks()
+	if err != nil {
+		return curated.Errorf("disassembly: %v", err)
+	}
+
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	// prepare every bank before writing anything
+	banks := make([]*dasmBank, 0, len(copiedBanks))
+	offset := 0
+	for _, bank := range copiedBanks {
+		if bank.Number >= len(dsm.entries) {
+			return curated.Errorf("disassembly: no such bank (%d)", bank.Number)
+		}
+
+		db := dsm.prepareDASMBank(bank, offset, len(copiedBanks) > 1)
+		banks = append(banks, db)
+		offset += len(bank.Data)
+	}
+
+	// equates for every TIA and RIOT symbol used
+	equates := make(map[string]uint16)
+	for _, db := range banks {
+		for _, u := range db.units {
+			if u != nil && u.kind == dasmInstruction {
+				if s, ok := dasmSymbol(u.e.Result.Defn, u.operand()); ok {
+					equates[s] = u.operand()
+				}
+			}
+		}
+	}
+
+	output.Write([]byte(fmt.Sprintf("; %s\n", dsm.cart.Filename)))
+	output.Write([]byte(fmt.Sprintf("; disassembled by Gopher2600 (%s)\n", dsm.cart.ID())))
+	output.Write([]byte("\n\tprocessor 6502\n"))
+
+	if len(equates) > 0 {
+		names := make([]string, 0, len(equates))
+		for s := range equates {
+			names = append(names, s)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if equates[names[i]] == equates[names[j]] {
+				return names[i] < names[j]
+			}
+			return equates[names[i]] < equates[names[j]]
+		})
+
+		output.Write([]byte("\n"))
+		for _, s := range names {
+			if equates[s] <= 0xff {
+				output.Write([]byte(fmt.Sprintf("%-8s = $%02X\n", s, equates[s])))
+			} else {
+				output.Write([]byte(fmt.Sprintf("%-8s = $%04X\n", s, equates[s])))
+			}
+		}
+	}
+
+	for _, db := range banks {
+		db.write(output)
+	}
+
+	return nil
+}
+
+// prepareDASMBank decides which entries in the bank are to be output as
+// instructions and which as data.
+func (dsm *Disassembly) prepareDASMBank(bank mapper.BankContent, offset int, multiBank bool) *dasmBank {
+	db := &dasmBank{
+		number: bank.Number,
+		offset: offset,
+		units:  make([]*dasmUnit, len(bank.Data)),
+		labels: make(map[uint16]string),
+	}
+
+	// index into the entries array of the first byte in the bank
+	var origin uint16
+	if len(bank.Origins) > 0 {
+		origin = bank.Origins[0] & memorymap.CartridgeBits
+	}
+
+	entries := dsm.entries[bank.Number]
+
+	// split bank into units
+	for i := 0; i < len(bank.Data); {
+		var e *Entry
+		if idx := int(origin) + i; idx < len(entries) {
+			e = entries[idx]
+		}
+
+		if e != nil && e.Level >= EntryLevelBlessed && e.Result.Defn != nil &&
+			e.Result.Defn.OpCode == bank.Data[i] && i+e.Result.Defn.Bytes <= len(bank.Data) {
+			u := &dasmUnit{
+				kind:  dasmInstruction,
+				e:     e,
+				bytes: bank.Data[i : i+e.Result.Defn.Bytes],
+			}
+			if e.Result.Defn.IsUndocumented() {
+				u.kind = dasmRawInstruction
+			}
+			db.units[i] = u
+			i += e.Result.Defn.Bytes
+			continue
+		}
+
+		db.units[i] = &dasmUnit{kind: dasmData, bytes: bank.Data[i : i+1]}
+		i++
+	}
+
+	db.rorg = dsm.dasmRORG(db, origin)
+
+	// generate labels for targets in this bank. the label name includes the
+	// bank number if there is more than one bank because DASM labels are
+	// global
+	for i, u := range db.units {
+		if u == nil || u.kind != dasmInstruction {
+			continue
+		}
+
+		defn := u.e.Result.Defn
+		address := db.rorg + uint16(i)
+
+		var target uint16
+		switch {
+		case defn.IsBranch():
+			// branches that wrap around the top of memory can't be
+			// reassembled. output as data instead
+			t := int(address) + 2 + int(int8(u.bytes[1]))
+			if t < 0 || t > 0xffff {
+				u.kind = dasmRawInstruction
+				continue
+			}
+			target = uint16(t)
+		case defn.Mnemonic == "JSR":
+			target = u.operand()
+		case defn.Mnemonic == "JMP" && defn.AddressingMode == instructions.Absolute:
+			target = u.operand()
+		default:
+			continue
+		}
+
+		if t, ok := db.index(target); ok && db.units[t] != nil {
+			if multiBank {
+				db.labels[target] = fmt.Sprintf("B%d_%04X", db.number, target)
+			} else {
+				db.labels[target] = fmt.Sprintf("L%04X", target)
+			}
+		}
+	}
+
+	return db
+}
+
+// dasmRORG decides on the RORG address for the bank. the cartridge can be
+// addressed through any of the cartridge mirrors and we want the address
+// used by the program itself, otherwise the generated labels will produce
+// different values to the original binary.
+//
+// the mirror is decided by the most common address used by the JMP and JSR
+// instructions that target the bank. the preferred mirror is used if there
+// are no JMP or JSR instructions.
+func (dsm *Disassembly) dasmRORG(db *dasmBank, origin uint16) uint16 {
+	size := len(db.units)
+
+	rorg := dsm.Prefs.mirrorOrigin&^memorymap.CartridgeBits | origin
+
+	// banks that are not a power of two in size can not be easily mapped to
+	// a mirror
+	if size == 0 || size&(size-1) != 0 {
+		return rorg
+	}
+
+	count := make(map[uint16]int)
+	for i, u := range db.units {
+		if u == nil || u.kind != dasmInstruction {
+			continue
+		}
+
+		defn := u.e.Result.Defn
+		if defn.Mnemonic != "JSR" && !(defn.Mnemonic == "JMP" && defn.AddressingMode == instructions.Absolute) {
+			continue
+		}
+
+		// target must be a cartridge address
+		target := u.operand()
+		if target&memorymap.OriginCart != memorymap.OriginCart {
+			continue
+		}
+
+		// target must be at the start of a unit
+		base := target &^ uint16(size-1)
+		t := int(target - base)
+		if db.units[t] == nil {
+			continue
+		}
+
+		// ignore JMP instructions to the instruction itself. they tell us
+		// nothing about the mirror
+		if t == i {
+			continue
+		}
+
+		count[base]++
+	}
+
+	best := 0
+	for b, n := range count {
+		if n > best || (n == best && b > rorg) {
+			rorg = b
+			best = n
+		}
+	}
+
+	return rorg
+}
+
+// index returns the offset into the bank of the address. returns false if the
+// address is not in the bank.
+func (db *dasmBank) index(address uint16) (int, bool) {
+	i := int(address) - int(db.rorg)
+	if i < 0 || i >= len(db.units) {
+		return 0, false
+	}
+	return i, true
+}
+
+// operand returns the operand of the instruction as read from the bank data.
+func (u *dasmUnit) operand() uint16 {
+	switch len(u.bytes) {
+	case 2:
+		return uint16(u.bytes[1])
+	case 3:
+		return uint16(u.bytes[1]) | uint16(u.bytes[2])<<8
+	}
+	return 0
+}
+
+func (db *dasmBank) write(output io.Writer) {
+	output.Write([]byte(fmt.Sprintf("\n\tSEG bank%d\n", db.number)))
+	output.Write([]byte(fmt.Sprintf("\tORG $%04X\n", db.offset)))
+	output.Write([]byte(fmt.Sprintf("\tRORG $%04X\n", db.rorg)))
+
+	// data bytes are collected and output together
+	data := make([]string, 0, dasmBytesPerLine)
+	dataAddress := uint16(0)
+	flush := func() {
+		if len(data) > 0 {
+			output.Write([]byte(fmt.Sprintf("\t.byte %-32s ; $%04X\n", strings.Join(data, ","), dataAddress)))
+			data = data[:0]
+		}
+	}
+
+	for i, u := range db.units {
+		if u == nil {
+			continue
+		}
+
+		address := db.rorg + uint16(i)
+
+		if l, ok := db.labels[address]; ok {
+			flush()
+			output.Write([]byte(fmt.Sprintf("%s\n", l)))
+		}
+
+		switch u.kind {
+		case dasmData:
+			if len(data) == 0 {
+				dataAddress = address
+			}
+			data = append(data, fmt.Sprintf("$%02X", u.bytes[0]))
+			if len(data) >= dasmBytesPerLine {
+				flush()
+			}
+
+		case dasmRawInstruction:
+			flush()
+			b := make([]string, len(u.bytes))
+			for j := range u.bytes {
+				b[j] = fmt.Sprintf("$%02X", u.bytes[j])
+			}
+			output.Write([]byte(fmt.Sprintf("\t.byte %-32s ; $%04X %s %s\n",
+				strings.Join(b, ","), address, u.e.Result.Defn.Mnemonic, u.e.Operand.nonSymbolic)))
+
+		case dasmInstruction:
+			flush()
+			output.Write([]byte(fmt.Sprintf("\t%-38s ; $%04X\n", db.instruction(u, address), address)))
+		}
+	}
+
+	flush()
+}
+
+// instruction returns the DASM source for the instruction.
+func (db *dasmBank) instruction(u *dasmUnit, address uint16) string {
+	defn := u.e.Result.Defn
+	operand := u.operand()
+
+	// the operand as it will appear in the source. symbols are preferred,
+	// followed by labels
+	var s string
+	if sym, ok := dasmSymbol(defn, operand); ok {
+		s = sym
+	} else if l, ok := db.labels[operand]; ok && !defn.IsBranch() && defn.AddressingMode == instructions.Absolute {
+		s = l
+	} else if len(u.bytes) == 3 {
+		s = fmt.Sprintf("$%04X", operand)
+	} else {
+		s = fmt.Sprintf("$%02X", operand)
+	}
+
+	mnemonic := defn.Mnemonic
+
+	switch defn.AddressingMode {
+	case instructions.Implied:
+		return mnemonic
+
+	case instructions.Immediate:
+		return fmt.Sprintf("%s #%s", mnemonic, s)
+
+	case instructions.Relative:
+		target := uint16(int(address) + 2 + int(int8(u.bytes[1])))
+		if l, ok := db.labels[target]; ok {
+			return fmt.Sprintf("%s %s", mnemonic, l)
+		}
+		return fmt.Sprintf("%s $%04X", mnemonic, target)
+
+	case instructions.Indirect:
+		return fmt.Sprintf("%s (%s)", mnemonic, s)
+
+	case instructions.IndexedIndirect:
+		return fmt.Sprintf("%s (%s,X)", mnemonic, s)
+
+	case instructions.IndirectIndexed:
+		return fmt.Sprintf("%s (%s),Y", mnemonic, s)
+
+	case instructions.ZeroPageIndexedX:
+		return fmt.Sprintf("%s %s,X", mnemonic, s)
+
+	case instructions.ZeroPageIndexedY:
+		return fmt.Sprintf("%s %s,Y", mnemonic, s)
+
+	case instructions.ZeroPage:
+		return fmt.Sprintf("%s %s", mnemonic, s)
+	}
+
+	// DASM will choose zero page addressing if the operand is small enough.
+	// the .w extension forces absolute addressing
+	if operand <= 0xff {
+		mnemonic = fmt.Sprintf("%s.w", mnemonic)
+	}
+
+	switch defn.AddressingMode {
+	case instructions.AbsoluteIndexedX:
+		return fmt.Sprintf("%s %s,X", mnemonic, s)
+	case instructions.AbsoluteIndexedY:
+		return fmt.Sprintf("%s %s,Y", mnemonic, s)
+	}
+
+	return fmt.Sprintf("%s %s", mnemonic, s)
+}
+
+// dasmSymbol returns the canonical TIA or RIOT symbol for the operand of the
+// instruction. only exact matches are returned, meaning that the value of the
+// symbol is the same as the operand.
+func dasmSymbol(defn *instructions.Definition, operand uint16) (string, bool) {
+	switch defn.AddressingMode {
+	case instructions.ZeroPage:
+	case instructions.ZeroPageIndexedX:
+	case instructions.ZeroPageIndexedY:
+	case instructions.Absolute:
+	case instructions.AbsoluteIndexedX:
+	case instructions.AbsoluteIndexedY:
+	default:
+		return "", false
+	}
+
+	var s string
+	var ok bool
+
+	switch defn.Effect {
+	case instructions.Read:
+		s, ok = addresses.ReadSymbols[operand]
+	case instructions.Write, instructions.RMW:
+		s, ok = addresses.WriteSymbols[operand]
+	}
+
+	return s, ok
+}
@@ -0,0 +1,616 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+)
+
+// dasm is a minimal assembler for the subset of DASM syntax produced by
+// WriteDASM(). it follows DASM's rules for choosing between zero page and
+// absolute addressing.
+type dasm struct {
+	symbols map[string]int
+	out     []byte
+}
+
+func (asm *dasm) value(s string) (int, bool) {
+	if strings.HasPrefix(s, "$") {
+		v, err := strconv.ParseUint(s[1:], 16, 16)
+		return int(v), err == nil
+	}
+	v, ok := asm.symbols[s]
+	return v, ok
+}
+
+func (asm *dasm) opcode(mnemonic string, mode instructions.AddressingMode) (uint8, bool) {
+	for _, defn := range instructions.GetDefinitions() {
+		if defn != nil && !defn.IsUndocumented() && defn.Mnemonic == mnemonic && defn.AddressingMode == mode {
+			return defn.OpCode, true
+		}
+	}
+	return 0, false
+}
+
+// assemble the source in two passes. the first pass resolves labels and the
+// second pass produces the output.
+func (asm *dasm) assemble(src string) ([]byte, error) {
+	asm.symbols = make(map[string]int)
+
+	for pass := 0; pass < 2; pass++ {
+		final := pass == 1
+		asm.out = asm.out[:0]
+		loc := 0
+		pc := 0
+
+		emit := func(b ...uint8) {
+			for len(asm.out) < loc+len(b) {
+				asm.out = append(asm.out, 0)
+			}
+			copy(asm.out[loc:], b)
+			loc += len(b)
+			pc += len(b)
+		}
+
+		for n, line := range strings.Split(src, "\n") {
+			if i := strings.Index(line, ";"); i >= 0 {
+				line = line[:i]
+			}
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+
+			// equates and labels
+			if line[0] != '\t' && line[0] != ' ' {
+				f := strings.Fields(line)
+				if len(f) == 3 && f[1] == "=" {
+					v, ok := asm.value(f[2])
+					if !ok {
+						return nil, fmt.Errorf("line %d: bad equate", n)
+					}
+					asm.symbols[f[0]] = v
+				} else {
+					asm.symbols[f[0]] = pc
+				}
+				continue
+			}
+
+			f := strings.Fields(line)
+			mnemonic := strings.ToUpper(f[0])
+			operand := strings.Join(f[1:], "")
+
+			switch mnemonic {
+			case "PROCESSOR", "SEG":
+				continue
+			case "ORG":
+				v, _ := asm.value(operand)
+				loc = v
+				pc = v
+				continue
+			case "RORG":
+				v, _ := asm.value(operand)
+				pc = v
+				continue
+			case ".BYTE":
+				for _, s := range strings.Split(operand, ",") {
+					v, ok := asm.value(s)
+					if !ok {
+						return nil, fmt.Errorf("line %d: bad byte", n)
+					}
+					emit(uint8(v))
+				}
+				continue
+			}
+
+			force := strings.HasSuffix(mnemonic, ".W")
+			mnemonic = strings.TrimSuffix(mnemonic, ".W")
+
+			// operand value. unknown labels are assumed to be 16bit in the
+			// first pass
+			val := func(s string) (int, error) {
+				v, ok := asm.value(s)
+				if !ok {
+					if final {
+						return 0, fmt.Errorf("line %d: unknown symbol %s", n, s)
+					}
+					return 0xffff, nil
+				}
+				return v, nil
+			}
+
+			var mode instructions.AddressingMode
+			var v int
+			var err error
+
+			// zero page or absolute addressing
+			zpOrAbs := func(s string, zp instructions.AddressingMode, abs instructions.AddressingMode) {
+				v, err = val(s)
+				mode = abs
+				if !force && v <= 0xff {
+					if _, ok := asm.opcode(mnemonic, zp); ok {
+						mode = zp
+					}
+				}
+			}
+
+			switch {
+			case operand == "":
+				mode = instructions.Implied
+			case strings.HasPrefix(operand, "#"):
+				mode = instructions.Immediate
+				v, err = val(operand[1:])
+			case strings.HasSuffix(operand, ",X)"):
+				mode = instructions.IndexedIndirect
+				v, err = val(strings.TrimSuffix(operand[1:], ",X)"))
+			case strings.HasSuffix(operand, "),Y"):
+				mode = instructions.IndirectIndexed
+				v, err = val(strings.TrimSuffix(operand[1:], "),Y"))
+			case strings.HasPrefix(operand, "("):
+				mode = instructions.Indirect
+				v, err = val(strings.TrimSuffix(operand[1:], ")"))
+			case strings.HasSuffix(operand, ",X"):
+				zpOrAbs(strings.TrimSuffix(operand, ",X"), instructions.ZeroPageIndexedX, instructions.AbsoluteIndexedX)
+			case strings.HasSuffix(operand, ",Y"):
+				zpOrAbs(strings.TrimSuffix(operand, ",Y"), instructions.ZeroPageIndexedY, instructions.AbsoluteIndexedY)
+			default:
+				if _, ok := asm.opcode(mnemonic, instructions.Relative); ok {
+					mode = instructions.Relative
+					v, err = val(operand)
+				} else {
+					zpOrAbs(operand, instructions.ZeroPage, instructions.Absolute)
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			op, ok := asm.opcode(mnemonic, mode)
+			if !ok {
+				return nil, fmt.Errorf("line %d: cannot assemble %s", n, line)
+			}
+
+			switch mode {
+			case instructions.Implied:
+				emit(op)
+			case instructions.Relative:
+				d := v - (pc + 2)
+				if final && (d < -128 || d > 127) {
+					return nil, fmt.Errorf("line %d: branch out of range", n)
+				}
+				emit(op, uint8(d))
+			case instructions.Immediate, instructions.ZeroPage, instructions.ZeroPageIndexedX,
+				instructions.ZeroPageIndexedY, instructions.IndexedIndirect, instructions.IndirectIndexed:
+				emit(op, uint8(v))
+			default:
+				emit(op, uint8(v), uint8(v>>8))
+			}
+		}
+	}
+
+	return asm.out, nil
+}
+
+// a short program that exercises labels, symbols and forced absolute
+// addressing. hi is the high byte of the address the program is assembled
+// for.
+func program(hi uint8) []uint8 {
+	return []uint8{
+		0x78,       // SEI
+		0xd8,       // CLD
+		0xa2, 0xff, // LDX #$FF
+		0x9a,       // TXS
+		0x85, 0x02, // STA WSYNC
+		0x8d, 0x02, 0x00, // STA.w WSYNC
+		0xad, 0x84, 0x02, // LDA INTIM
+		0xd0, 0xfb, // BNE -5
+		0xbd, 0x00, hi + 1, // LDA $x100,X
+		0xa7, 0x80, // lax $80
+		0x20, 0x20, hi, // JSR $xx20
+		0x4c, 0x05, hi, // JMP $xx05
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06,
+		0x60, // RTS
+	}
+}
+
+// make a ROM of random data with the test program at the start of every
+// bank. the reset vector of every bank points to the program.
+func makeROM(seed int64, banks int, size int, hi uint8) []uint8 {
+	rnd := rand.New(rand.NewSource(seed))
+	data := make([]uint8, banks*size)
+	rnd.Read(data)
+
+	for b := 0; b < banks; b++ {
+		bank := data[b*size : (b+1)*size]
+		copy(bank, program(hi))
+		bank[size-4] = 0x00
+		bank[size-3] = hi
+	}
+
+	return data
+}
+
+func TestDASM(t *testing.T) {
+	roms := []struct {
+		mapping string
+		banks   int
+		size    int
+		hi      uint8
+	}{
+		{mapping: "2k", banks: 1, size: 2048, hi: 0xf8},
+		{mapping: "4k", banks: 1, size: 4096, hi: 0xf0},
+		{mapping: "F8", banks: 2, size: 4096, hi: 0xd0},
+		{mapping: "F6", banks: 4, size: 4096, hi: 0xf0},
+	}
+
+	for _, r := range roms {
+		for seed := int64(0); seed < 5; seed++ {
+			data := makeROM(seed, r.banks, r.size, r.hi)
+
+			cartload := cartridgeloader.Loader{
+				Filename: fmt.Sprintf("test_%s_%d", r.mapping, seed),
+				Mapping:  r.mapping,
+				Data:     data,
+			}
+
+			dsm, err := disassembly.FromCartridge(cartload)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			src := &strings.Builder{}
+			err = dsm.WriteDASM(src)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			asm := &dasm{}
+			bin, err := asm.assemble(src.String())
+			if err != nil {
+				t.Fatalf("%s: reassembly failed: %v", cartload.Filename, err)
+			}
+
+			if !bytes.Equal(bin, data) {
+				for i := range data {
+					if i >= len(bin) || bin[i] != data[i] {
+						t.Fatalf("%s: reassembled binary differs at offset %#04x", cartload.Filename, i)
+					}
+				}
+				t.Fatalf("%s: reassembled binary is the wrong length (%d)", cartload.Filename, len(bin))
+			}
+
+			// check that the program has been disassembled as expected
+			for _, s := range []string{"STA WSYNC", "STA.w WSYNC", "LDA INTIM", ".byte $A7,$80", "JMP "} {
+				if !strings.Contains(src.String(), s) {
+					t.Errorf("%s: expected %q in output", cartload.Filename, s)
+				}
+			}
+			if r.banks == 1 && !strings.Contains(src.String(), fmt.Sprintf("JSR L%02X20", r.hi)) {
+				t.Errorf("%s: expected generated label for JSR", cartload.Filename)
+			}
+		}
+	}
+}
+
+// an instruction or data directive from a DASM listing.
+type listingEntry struct {
+	address uint16
+	bytes   []uint8
+	source  string
+}
+
+var listingLine = regexp.MustCompile(`^\s*(\d+)  ([0-9a-f]{4,5})(.*)$`)
+
+// read a DASM listing and return the binary that was assembled, along with
+// every line that generated bytes. the binary is built by following the ORG
+// and RORG directives in the same way as DASM does with the -f3 flag. listed
+// lines are limited to four bytes so every line in the fixture has been
+// written to generate no more than that.
+func readListing(filename string, size int) ([]uint8, []listingEntry, error) {
+	f, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bin := make([]uint8, size)
+	entries := make([]listingEntry, 0)
+
+	var first, org, rorg int
+	first = -1
+
+	for _, s := range strings.Split(string(f), "\n") {
+		m := listingLine.FindStringSubmatch(s)
+		if m == nil {
+			continue
+		}
+
+		address, _ := strconv.ParseUint(m[2], 16, 32)
+		if address > 0xffff {
+			continue
+		}
+
+		rest := strings.TrimLeft(m[3], "\t ")
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToLower(fields[0]) {
+		case "org":
+			v, _ := strconv.ParseUint(strings.TrimPrefix(fields[1], "$"), 16, 16)
+			org = int(v)
+			rorg = org
+			if first == -1 {
+				first = org
+			}
+			continue
+		case "rorg":
+			v, _ := strconv.ParseUint(strings.TrimPrefix(fields[1], "$"), 16, 16)
+			rorg = int(v)
+			continue
+		}
+
+		// lines without bytes are labels
+		b := make([]uint8, 0, 4)
+		for len(fields) > 0 {
+			v, err := strconv.ParseUint(strings.TrimSuffix(fields[0], "*"), 16, 8)
+			if err != nil || len(fields[0]) < 2 || len(fields[0]) > 3 {
+				break
+			}
+			b = append(b, uint8(v))
+			fields = fields[1:]
+		}
+		if len(b) == 0 {
+			continue
+		}
+
+		offset := org - first + int(address) - rorg
+		if offset < 0 || offset+len(b) > size {
+			return nil, nil, fmt.Errorf("listing address out of range (%#04x)", address)
+		}
+		copy(bin[offset:], b)
+
+		entries = append(entries, listingEntry{
+			address: uint16(address),
+			bytes:   b,
+			source:  strings.Join(fields, " "),
+		})
+	}
+
+	return bin, entries, nil
+}
+
+// the listings in testdata are for hand written programs in the style
+// expected by DASM, in the format produced by DASM's -l option. the binary
+// assembled from the listing is disassembled and the DASM output compared to
+// a golden file. the golden file is also checked against the listing: every
+// instruction must be output at the same address, with the same mnemonic and
+// with the same choice of zero page or absolute addressing (the .w
+// extension).
+func TestDASMListing(t *testing.T) {
+	fixtures := []struct {
+		listing string
+		golden  string
+		mapping string
+		size    int
+	}{
+		{listing: "dasm_4k.lst", golden: "dasm_4k.asm", mapping: "4k", size: 4096},
+		{listing: "dasm_f8.lst", golden: "dasm_f8.asm", mapping: "F8", size: 8192},
+	}
+
+	for _, fx := range fixtures {
+		data, listing, err := readListing(filepath.Join("testdata", fx.listing), fx.size)
+		if err != nil {
+			t.Fatalf("%s: %v", fx.listing, err)
+		}
+
+		cartload := cartridgeloader.Loader{
+			Filename: fx.golden,
+			Mapping:  fx.mapping,
+			Data:     data,
+		}
+
+		dsm, err := disassembly.FromCartridge(cartload)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		src := &strings.Builder{}
+		err = dsm.WriteDASM(src)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		golden, err := os.ReadFile(filepath.Join("testdata", fx.golden))
+		if err != nil {
+			t.Fatalf("%s: %v", fx.golden, err)
+		}
+
+		out := strings.Split(src.String(), "\n")
+		for i, s := range strings.Split(string(golden), "\n") {
+			if i >= len(out) {
+				t.Fatalf("%s: output is too short (%d lines)", fx.golden, len(out))
+			}
+			if s != out[i] {
+				t.Fatalf("%s: line %d differs\n  expected: %q\n       got: %q", fx.golden, i+1, s, out[i])
+			}
+		}
+		if len(out) != len(strings.Split(string(golden), "\n")) {
+			t.Fatalf("%s: output is too long (%d lines)", fx.golden, len(out))
+		}
+
+		// output lines indexed by the address in the trailing comment
+		addressed := make(map[string]string)
+		for _, s := range out {
+			if i := strings.Index(s, "; $"); i != -1 {
+				f := strings.Fields(s[i+2:])
+				addressed[f[0]] = strings.TrimSpace(s[:i])
+			}
+		}
+
+		for _, l := range listing {
+			f := strings.Fields(l.source)
+			mnemonic := strings.ToUpper(f[0])
+
+			// data directives and undocumented opcodes are output as .byte
+			// directives. undocumented opcodes are lower case in the
+			// instruction table
+			expected := mnemonic
+			if mnemonic == ".BYTE" || mnemonic == ".WORD" || instructions.GetDefinitions()[l.bytes[0]].IsUndocumented() {
+				expected = ".BYTE"
+			}
+
+			s, ok := addressed[fmt.Sprintf("$%04X", l.address)]
+			if !ok {
+				// data is grouped so only the first byte of a group has an
+				// address
+				if expected == ".BYTE" {
+					continue
+				}
+				t.Errorf("%s: no output for %s at $%04X", fx.golden, l.source, l.address)
+				continue
+			}
+
+			if got := strings.ToUpper(strings.Fields(s)[0]); got != expected {
+				t.Errorf("%s: expected %s at $%04X, got %s", fx.golden, expected, l.address, got)
+			}
+		}
+
+		// and the golden file reassembles to the listing binary
+		asm := &dasm{}
+		bin, err := asm.assemble(string(golden))
+		if err != nil {
+			t.Fatalf("%s: reassembly failed: %v", fx.golden, err)
+		}
+		if !bytes.Equal(bin, data) {
+			t.Errorf("%s: reassembled binary differs from listing", fx.golden)
+		}
+	}
+}
+
+// assemble the output of WriteDASM() with the real DASM assembler and check
+// that the binary is identical to the original ROM. the test is skipped if
+// DASM is not installed.
+func TestDASMReassembly(t *testing.T) {
+	dasmPath, err := exec.LookPath("dasm")
+	if err != nil {
+		t.Skip("dasm not found")
+	}
+
+	type rom struct {
+		name    string
+		mapping string
+		data    []uint8
+	}
+
+	var roms []rom
+
+	for _, r := range []struct {
+		mapping string
+		banks   int
+		size    int
+		hi      uint8
+	}{
+		{mapping: "2k", banks: 1, size: 2048, hi: 0xf8},
+		{mapping: "4k", banks: 1, size: 4096, hi: 0xf0},
+		{mapping: "F8", banks: 2, size: 4096, hi: 0xd0},
+		{mapping: "F6", banks: 4, size: 4096, hi: 0xf0},
+	} {
+		for seed := int64(0); seed < 5; seed++ {
+			roms = append(roms, rom{
+				name:    fmt.Sprintf("test_%s_%d", r.mapping, seed),
+				mapping: r.mapping,
+				data:    makeROM(seed, r.banks, r.size, r.hi),
+			})
+		}
+	}
+
+	for _, fx := range []struct {
+		listing string
+		mapping string
+		size    int
+	}{
+		{listing: "dasm_4k.lst", mapping: "4k", size: 4096},
+		{listing: "dasm_f8.lst", mapping: "F8", size: 8192},
+	} {
+		data, _, err := readListing(filepath.Join("testdata", fx.listing), fx.size)
+		if err != nil {
+			t.Fatalf("%s: %v", fx.listing, err)
+		}
+		roms = append(roms, rom{name: fx.listing, mapping: fx.mapping, data: data})
+	}
+
+	dir := t.TempDir()
+
+	for _, r := range roms {
+		dsm, err := disassembly.FromCartridge(cartridgeloader.Loader{
+			Filename: r.name,
+			Mapping:  r.mapping,
+			Data:     r.data,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		src := &strings.Builder{}
+		err = dsm.WriteDASM(src)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		asmFile := filepath.Join(dir, r.name+".asm")
+		binFile := filepath.Join(dir, r.name+".bin")
+		err = os.WriteFile(asmFile, []byte(src.String()), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := exec.Command(dasmPath, asmFile, "-f3", "-o"+binFile).CombinedOutput()
+		if err != nil {
+			t.Errorf("%s: dasm failed: %v\n%s", r.name, err, out)
+			continue
+		}
+
+		bin, err := os.ReadFile(binFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(bin, r.data) {
+			for i := range r.data {
+				if i >= len(bin) || bin[i] != r.data[i] {
+					t.Errorf("%s: dasm binary differs at offset %#04x", r.name, i)
+					break
+				}
+			}
+			if len(bin) > len(r.data) {
+				t.Errorf("%s: dasm binary is the wrong length (%d)", r.name, len(bin))
+			}
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/hardware/cpu"
 	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
 	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
 	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
 	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
@@ -53,10 +54,19 @@ type Disassembly struct {
 	// whether a sync.Mutex is the best low level synchronisation method is
 	// another question.
 	crit sync.Mutex
+
+	// the source of the values in the CPU registers. used by Classify()
+	trace classTrace
+
+	// references observed during emulation. see xref.go
+	xrefObserve bool
+	xrefRuntime xrefRuntime
 }
 
 func NewDisassembly() (*Disassembly, error) {
-	dsm := &Disassembly{}
+	dsm := &Disassembly{
+		xrefRuntime: make(xrefRuntime),
+	}
 
 	var err error
 
@@ -72,6 +82,13 @@ func NewDisassembly() (*Disassembly, error) {
 // from the supplied cartridge filename. Useful for one-shot disassemblies,
 // like the gopher2600 "disasm" mode.
 func FromCartridge(cartload cartridgeloader.Loader) (*Disassembly, error) {
+	return FromCartridgeWithSymbols(cartload, "")
+}
+
+// FromCartridgeWithSymbols is the same as FromCartridge() except that the
+// symbols file can be specified. If symbolsFile is empty then the symbols file
+// for the cartridge is looked for in the usual way.
+func FromCartridgeWithSymbols(cartload cartridgeloader.Loader, symbolsFile string) (*Disassembly, error) {
 	dsm, err := NewDisassembly()
 	if err != nil {
 		return nil, err
@@ -86,7 +103,7 @@ func FromCartridge(cartload cartridgeloader.Loader) (*Disassembly, error) {
 
 	// ignore errors caused by loading of symbols table - we always get a
 	// standard symbols table even in the event of an error
-	symbols, _ := symbols.ReadSymbolsFile(cart)
+	symbols, _ := symbols.ReadSymbolsFileFrom(cart, symbolsFile)
 
 	err = dsm.FromMemory(cart, symbols)
 	if err != nil {
@@ -116,6 +133,7 @@ func (dsm *Disassembly) FromMemory(cart *cartridge.Cartridge, symbols *symbols.S
 	for b := 0; b < len(dsm.entries); b++ {
 		dsm.entries[b] = make([]*Entry, memorymap.CartridgeBits+1)
 	}
+	dsm.xrefRuntime = make(xrefRuntime)
 	dsm.crit.Unlock()
 
 	// exit early if cartridge memory self reports as being ejected
@@ -136,6 +154,13 @@ func (dsm *Disassembly) FromMemory(cart *cartridge.Cartridge, symbols *symbols.S
 		return curated.Errorf("disassembly: %v", err)
 	}
 
+	// classification from previous sessions with the same cartridge
+	dsm.trace = classTrace{}
+	err = dsm.loadClassification()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -169,6 +194,62 @@ func (dsm *Disassembly) GetEntryByAddress(address uint16) *Entry {
 	return dsm.entries[bank.Number][address&memorymap.CartridgeBits]
 }
 
+// PatchedEntry replaces the entry at the bank/address with the instruction in
+// data. The data should be the bytes of a single instruction, starting with
+// the opcode, that have just been patched into cartridge memory. The entry is
+// blessed and the entries for the operand bytes are demoted so that they are
+// not mistaken for instructions.
+//
+// Does nothing if bank is not a cartridge ROM bank.
+func (dsm *Disassembly) PatchedEntry(bank mapper.BankInfo, address uint16, data []uint8) error {
+	if bank.NonCart || bank.IsRAM || bank.Number >= len(dsm.entries) || len(data) == 0 {
+		return nil
+	}
+
+	defn := instructions.GetDefinitions()[data[0]]
+	if defn == nil || defn.Bytes != len(data) {
+		return curated.Errorf("disassembly: patched data is not an instruction")
+	}
+
+	result := execution.Result{
+		Address:   address,
+		Defn:      defn,
+		ByteCount: len(data),
+		Final:     true,
+	}
+	switch len(data) {
+	case 2:
+		result.InstructionData = uint16(data[1])
+	case 3:
+		result.InstructionData = uint16(data[1]) | uint16(data[2])<<8
+	}
+
+	ne, err := dsm.FormatResult(bank, result, EntryLevelBlessed)
+	if err != nil {
+		return curated.Errorf("disassembly: %v", err)
+	}
+
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	idx := address & memorymap.CartridgeBits
+
+	// classification belongs to the address and not to the instruction
+	if e := dsm.entries[bank.Number][idx]; e != nil {
+		ne.Class = e.Class
+	}
+	dsm.entries[bank.Number][idx] = ne
+
+	for i := 1; i < len(data); i++ {
+		idx = (address + uint16(i)) & memorymap.CartridgeBits
+		if e := dsm.entries[bank.Number][idx]; e != nil && e.Level > EntryLevelDecoded {
+			e.Level = EntryLevelDecoded
+		}
+	}
+
+	return nil
+}
+
 // ExecutedEntry creates an Entry from a cpu result that has actually been
 // executed. The newly created Entry replaces the previous equivalent entry in
 // the disassembly.
@@ -200,15 +281,28 @@ func (dsm *Disassembly) ExecutedEntry(bank mapper.BankInfo, result execution.Res
 	defer dsm.crit.Unlock()
 
 	if e == nil || e.Result.Defn.OpCode != result.Defn.OpCode {
-		var err error
-		dsm.entries[bank.Number][idx], err = dsm.FormatResult(bank, result, EntryLevelExecuted)
+		ne, err := dsm.FormatResult(bank, result, EntryLevelExecuted)
 		if err != nil {
 			return nil, curated.Errorf("disassembly: %v", err)
 		}
+
+		// classification belongs to the address and not to the instruction
+		if e != nil {
+			ne.Class = e.Class
+		}
+
+		dsm.entries[bank.Number][idx] = ne
 	} else if e.Level < EntryLevelExecuted {
 		e.updateExecutionEntry(result)
 	}
 
+	// the entry may have been replaced above
+	e = dsm.entries[bank.Number][idx]
+
+	// update coverage information
+	e.ExecutionCount++
+	e.ExecutionCycles += result.Cycles
+
 	// bless next entry in case it was missed by the original decoding. there's
 	// no guarantee that the bank for the next address will be the same as the
 	// current bank, so we have to call the GetBank() function.
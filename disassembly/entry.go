@@ -88,6 +88,30 @@ type Entry struct {
 	//
 	// should be empty if EntryLevel != EntryLevelExecuted
 	ExecutionNotes string
+
+	// the number of times the entry has been executed and the total number of
+	// cycles consumed by those executions. see coverage.go
+	ExecutionCount  int
+	ExecutionCycles int
+
+	// how the byte at the entry's address has been accessed during emulation.
+	// see classify.go
+	Class Class
+}
+
+// IsData returns true if the byte at the entry's address has been accessed as
+// data but never executed.
+func (e *Entry) IsData() bool {
+	return e.Class != 0 && e.Class&ClassCode != ClassCode
+}
+
+// Comment returns any user comment for the entry. See symbols.SetComment().
+func (e *Entry) Comment() string {
+	if e.dsm == nil || e.dsm.Symbols == nil {
+		return ""
+	}
+	a, _ := e.dsm.Symbols.Annotation(e.Bank.Number, e.Result.Address)
+	return a.Comment
 }
 
 // String returns a very basic representation of an Entry. Provided for
@@ -112,8 +136,8 @@ func (dsm *Disassembly) FormatResult(bank mapper.BankInfo, result execution.Resu
 		Result:  result,
 		Level:   level,
 		Bank:    bank,
-		Label:   Label{dsm: dsm, result: result},
-		Operand: Operand{dsm: dsm, result: result},
+		Label:   Label{dsm: dsm, result: result, bank: bank.Number},
+		Operand: Operand{dsm: dsm, result: result, bank: bank.Number},
 	}
 
 	// address of instruction
@@ -297,6 +321,9 @@ func absoluteBranchDestination(addr uint16, operand uint16) uint16 {
 type Label struct {
 	dsm    *Disassembly
 	result execution.Result
+
+	// user labels are specific to a bank
+	bank int
 }
 
 func (l Label) String() string {
@@ -308,8 +335,7 @@ func (l Label) String() string {
 // if a symbol is not available then the the bool return value will be false.
 func (l Label) checkString() (string, bool) {
 	if l.dsm.Prefs.Symbols.Get().(bool) {
-		ma, _ := memorymap.MapAddress(l.result.Address, true)
-		if v, ok := l.dsm.Symbols.Label.Entries[ma]; ok {
+		if v, ok := l.dsm.Symbols.LookupLabel(l.bank, l.result.Address); ok {
 			return v, true
 		}
 	}
@@ -324,6 +350,10 @@ type Operand struct {
 	nonSymbolic string
 	dsm         *Disassembly
 	result      execution.Result
+
+	// user labels are specific to a bank. flow instructions are assumed to
+	// stay in the same bank
+	bank int
 }
 
 func (l Operand) String() string {
@@ -355,11 +385,13 @@ func (l Operand) checkString() (string, bool) {
 			if l.result.Defn.IsBranch() {
 				operand = absoluteBranchDestination(l.result.Address, operand)
 
-				// look up mock program counter value in symbol table
-				if v, ok := l.dsm.Symbols.Label.Entries[operand]; ok {
+				// look up mock program counter value in symbol table. a
+				// branch never leaves the bank so only user labels from the
+				// same bank are considered
+				if v, ok := l.dsm.Symbols.LookupLabel(l.bank, operand); ok {
 					s = v
 				}
-			} else if v, ok := l.dsm.Symbols.Label.Entries[operand]; ok {
+			} else if v, ok := l.dsm.Symbols.LookupLabel(l.bank, operand); ok {
 				s = addrModeDecoration(v, l.result.Defn.AddressingMode)
 			}
 		case instructions.Read:
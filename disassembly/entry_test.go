@@ -0,0 +1,76 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+)
+
+func TestOperandBankLabels(t *testing.T) {
+	// the same program in both banks
+	program := []uint8{
+		0xea,       // f000 NOP
+		0xd0, 0xfd, // f001 BNE $f000
+		0x4c, 0x00, 0xf0, // f003 JMP $f000
+	}
+
+	dsm, err := disassembly.FromCartridge(cartridgeloader.Loader{
+		Filename: "test_operand_bank_labels",
+		Mapping:  "F8",
+		Data:     testROM(t, program, program),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := dsm.Prefs.Symbols.Set(true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a different user label for the same address in each bank
+	if err := dsm.Symbols.SetLabel(0, 0xf000, "zero"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := dsm.Symbols.SetLabel(1, 0xf000, "one"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer dsm.Symbols.SetLabel(0, 0xf000, "")
+	defer dsm.Symbols.SetLabel(1, 0xf000, "")
+
+	// the operands of the branch and the jump use the label from their own bank
+	for bank, label := range []string{"zero", "one"} {
+		bitr, err := dsm.NewBankIteration(disassembly.EntryLevelBlessed, bank)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		n := 0
+		for _, e := bitr.Start(); e != nil; _, e = bitr.Next() {
+			if e.Result.Address&0x0fff == 0x0001 || e.Result.Address&0x0fff == 0x0003 {
+				n++
+				if e.Operand.String() != label {
+					t.Errorf("unexpected operand in bank %d at %#04x (%s, expected %s)", bank, e.Result.Address, e.Operand.String(), label)
+				}
+			}
+		}
+		if n != 2 {
+			t.Errorf("expected branch and jump entries in bank %d", bank)
+		}
+	}
+}
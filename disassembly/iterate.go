@@ -74,6 +74,7 @@ func (citr *IterateCart) next() (int, bool) {
 type IterateBank struct {
 	dsm       *Disassembly
 	minLevel  EntryLevel
+	withData  bool
 	bank      int
 	idx       int
 	lastEntry *Entry
@@ -83,6 +84,10 @@ type IterateBank struct {
 
 	// the number of those entries with a label
 	LabelCount int
+
+	// the highest ExecutionCount of those entries. useful for normalising the
+	// execution count when presenting coverage information
+	MaxExecutionCount int
 }
 
 // NewBankIteration initialises a new iteration of a dissasembly bank. The minLevel
@@ -96,6 +101,17 @@ type IterateBank struct {
 // iterate through entries of EntryLevelNaive *and* EntryLevelDecode. A
 // minLevel of EntryLevelDead will iterate through *all* Entries.
 func (dsm *Disassembly) NewBankIteration(minLevel EntryLevel, bank int) (*IterateBank, error) {
+	return dsm.newBankIteration(minLevel, bank, false)
+}
+
+// NewBankIterationWithData is the same as NewBankIteration except that
+// entries that have been classified as data are included in the iteration
+// whatever their level.
+func (dsm *Disassembly) NewBankIterationWithData(minLevel EntryLevel, bank int) (*IterateBank, error) {
+	return dsm.newBankIteration(minLevel, bank, true)
+}
+
+func (dsm *Disassembly) newBankIteration(minLevel EntryLevel, bank int, withData bool) (*IterateBank, error) {
 	dsm.crit.Lock()
 	defer dsm.crit.Unlock()
 
@@ -110,6 +126,7 @@ func (dsm *Disassembly) NewBankIteration(minLevel EntryLevel, bank int) (*Iterat
 	bitr := &IterateBank{
 		dsm:      dsm,
 		minLevel: minLevel,
+		withData: withData,
 		bank:     bank,
 	}
 
@@ -119,14 +136,19 @@ func (dsm *Disassembly) NewBankIteration(minLevel EntryLevel, bank int) (*Iterat
 			return nil, curated.Errorf("disassembly not complete")
 		}
 
-		// count the number of entries of the minimum level
-		if a.Level >= minLevel {
+		// count the number of entries of the minimum level (and data entries if
+		// requested)
+		if bitr.include(a) {
 			bitr.EntryCount++
 
 			// count entries (of the minimum level) with a label
 			if a.Label.String() != "" {
 				bitr.LabelCount++
 			}
+
+			if a.ExecutionCount > bitr.MaxExecutionCount {
+				bitr.MaxExecutionCount = a.ExecutionCount
+			}
 		}
 	}
 
@@ -174,7 +196,7 @@ func (bitr *IterateBank) next() (int, *Entry) {
 
 	bitr.idx++
 
-	for bitr.idx < len(bitr.dsm.entries[bitr.bank]) && bitr.dsm.entries[bitr.bank][bitr.idx].Level < bitr.minLevel {
+	for bitr.idx < len(bitr.dsm.entries[bitr.bank]) && !bitr.include(bitr.dsm.entries[bitr.bank][bitr.idx]) {
 		bitr.idx++
 	}
 
@@ -187,6 +209,11 @@ func (bitr *IterateBank) next() (int, *Entry) {
 	return bitr.idx, makeCopyofEntry(*bitr.lastEntry)
 }
 
+// whether the entry should be included in the iteration
+func (bitr *IterateBank) include(e *Entry) bool {
+	return e.Level >= bitr.minLevel || (bitr.withData && e.IsData())
+}
+
 // we don't want to return the actual entry in the disassembly because it will
 // result in a race condition erorr if the entry is updated at the same time as
 // we're dealing with the iteration.
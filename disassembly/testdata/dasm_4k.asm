@@ -0,0 +1,551 @@
+; dasm_4k.asm
+; disassembled by Gopher2600 (4k)
+
+	processor 6502
+
+VSYNC    = $00
+WSYNC    = $02
+COLUBK   = $09
+INTIM    = $0284
+
+	SEG bank0
+	ORG $0000
+	RORG $F000
+	SEI                                    ; $F000
+	CLD                                    ; $F001
+	LDX #$FF                               ; $F002
+	TXS                                    ; $F004
+	LDA #$00                               ; $F005
+LF007
+	STA VSYNC,X                            ; $F007
+	DEX                                    ; $F009
+	BNE LF007                              ; $F00A
+LF00C
+	STA WSYNC                              ; $F00C
+	LDA $80                                ; $F00E
+	LDA.w $0080,X                          ; $F010
+	STA.w $0081                            ; $F013
+LF016
+	LDA INTIM                              ; $F016
+	BNE LF016                              ; $F019
+	JSR LF025                              ; $F01B
+	JMP LF00C                              ; $F01E
+	.byte $01,$02                          ; $F021
+	.byte $04,$08                          ; $F023 nop $08
+LF025
+	.byte $A7,$80                          ; $F025 lax $80
+	LDA ($82),Y                            ; $F027
+	LDA ($84,X)                            ; $F029
+	STA $0100,Y                            ; $F02B
+	LDX $86,Y                              ; $F02E
+	ASL                                    ; $F030
+	STA COLUBK                             ; $F031
+	RTS                                    ; $F033
+	BRK                                    ; $F034
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F035
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F03D
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F045
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F04D
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F055
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F05D
+	.byte $00,$00,$00,$00,$00,$00          ; $F065
+	BRK                                    ; $F06B
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F06C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F074
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F07C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F084
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F08C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F094
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F09C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0A4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0AC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0B4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0BC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0C4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0CC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0D4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0DC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0E4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0EC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0F4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0FC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F104
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F10C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F114
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F11C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F124
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F12C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F134
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F13C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F144
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F14C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F154
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F15C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F164
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F16C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F174
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F17C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F184
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F18C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F194
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F19C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1A4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1AC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1B4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1BC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1C4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1CC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1D4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1DC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1E4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1EC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1F4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1FC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F204
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F20C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F214
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F21C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F224
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F22C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F234
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F23C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F244
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F24C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F254
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F25C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F264
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F26C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F274
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F27C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F284
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F28C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F294
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F29C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2A4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2AC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2B4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2BC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2C4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2CC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2D4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2DC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2E4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2EC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2F4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2FC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F304
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F30C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F314
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F31C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F324
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F32C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F334
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F33C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F344
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F34C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F354
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F35C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F364
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F36C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F374
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F37C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F384
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F38C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F394
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F39C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3A4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3AC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3B4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3BC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3C4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3CC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3D4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3DC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3E4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3EC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3F4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3FC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F404
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F40C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F414
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F41C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F424
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F42C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F434
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F43C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F444
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F44C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F454
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F45C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F464
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F46C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F474
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F47C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F484
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F48C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F494
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F49C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4A4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4AC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4B4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4BC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4C4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4CC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4D4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4DC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4E4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4EC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4F4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4FC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F504
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F50C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F514
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F51C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F524
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F52C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F534
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F53C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F544
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F54C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F554
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F55C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F564
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F56C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F574
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F57C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F584
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F58C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F594
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F59C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5A4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5AC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5B4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5BC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5C4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5CC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5D4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5DC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5E4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5EC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5F4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5FC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F604
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F60C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F614
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F61C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F624
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F62C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F634
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F63C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F644
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F64C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F654
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F65C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F664
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F66C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F674
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F67C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F684
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F68C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F694
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F69C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6A4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6AC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6B4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6BC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6C4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6CC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6D4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6DC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6E4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6EC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6F4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6FC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F704
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F70C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F714
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F71C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F724
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F72C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F734
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F73C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F744
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F74C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F754
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F75C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F764
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F76C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F774
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F77C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F784
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F78C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F794
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F79C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7A4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7AC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7B4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7BC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7C4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7CC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7D4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7DC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7E4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7EC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7F4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7FC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F804
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F80C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F814
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F81C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F824
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F82C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F834
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F83C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F844
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F84C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F854
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F85C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F864
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F86C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F874
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F87C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F884
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F88C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F894
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F89C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8A4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8AC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8B4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8BC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8C4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8CC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8D4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8DC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8E4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8EC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8F4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8FC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F904
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F90C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F914
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F91C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F924
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F92C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F934
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F93C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F944
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F94C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F954
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F95C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F964
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F96C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F974
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F97C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F984
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F98C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F994
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F99C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9A4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9AC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9B4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9BC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9C4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9CC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9D4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9DC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9E4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9EC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9F4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9FC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA04
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA0C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA14
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA1C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA24
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA2C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA34
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA3C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA44
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA4C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA54
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA5C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA64
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA6C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA74
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA7C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA84
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA8C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA94
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA9C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAA4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAAC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAB4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FABC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAC4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FACC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAD4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FADC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAE4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAEC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAF4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAFC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB04
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB0C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB14
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB1C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB24
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB2C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB34
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB3C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB44
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB4C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB54
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB5C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB64
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB6C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB74
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB7C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB84
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB8C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB94
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB9C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBA4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBAC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBB4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBBC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBC4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBCC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBD4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBDC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBE4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBEC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBF4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBFC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC04
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC0C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC14
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC1C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC24
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC2C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC34
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC3C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC44
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC4C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC54
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC5C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC64
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC6C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC74
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC7C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC84
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC8C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC94
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC9C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCA4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCAC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCB4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCBC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCC4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCCC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCD4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCDC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCE4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCEC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCF4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCFC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD04
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD0C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD14
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD1C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD24
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD2C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD34
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD3C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD44
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD4C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD54
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD5C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD64
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD6C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD74
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD7C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD84
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD8C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD94
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD9C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDA4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDAC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDB4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDBC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDC4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDCC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDD4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDDC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDE4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDEC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDF4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDFC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE04
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE0C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE14
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE1C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE24
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE2C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE34
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE3C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE44
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE4C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE54
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE5C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE64
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE6C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE74
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE7C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE84
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE8C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE94
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE9C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEA4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEAC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEB4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEBC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEC4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FECC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FED4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEDC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEE4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEEC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEF4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEFC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF04
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF0C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF14
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF1C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF24
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF2C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF34
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF3C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF44
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF4C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF54
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF5C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF64
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF6C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF74
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF7C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF84
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF8C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF94
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF9C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFA4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFAC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFB4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFBC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFC4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFCC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFD4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFDC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFE4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFEC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFF4
+	.byte $00,$F0,$00,$F0                  ; $FFFC
@@ -0,0 +1,1065 @@
+; dasm_f8.asm
+; disassembled by Gopher2600 (F8)
+
+	processor 6502
+
+WSYNC    = $02
+COLUBK   = $09
+
+	SEG bank0
+	ORG $0000
+	RORG $D000
+B0_D000
+	SEI                                    ; $D000
+	JSR B0_D00A                            ; $D001
+	STA $1FF9                              ; $D004
+	JMP B0_D000                            ; $D007
+B0_D00A
+	LDA #$0E                               ; $D00A
+	STA COLUBK                             ; $D00C
+	RTS                                    ; $D00E
+	BRK                                    ; $D00F
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D010
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D018
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D020
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D028
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D030
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D038
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D040
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D048
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D050
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D058
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D060
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D068
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D070
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D078
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D080
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D088
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D090
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D098
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D0F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D100
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D108
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D110
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D118
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D120
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D128
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D130
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D138
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D140
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D148
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D150
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D158
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D160
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D168
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D170
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D178
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D180
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D188
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D190
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D198
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D1F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D200
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D208
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D210
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D218
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D220
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D228
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D230
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D238
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D240
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D248
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D250
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D258
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D260
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D268
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D270
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D278
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D280
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D288
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D290
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D298
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D2F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D300
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D308
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D310
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D318
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D320
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D328
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D330
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D338
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D340
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D348
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D350
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D358
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D360
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D368
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D370
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D378
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D380
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D388
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D390
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D398
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D3F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D400
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D408
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D410
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D418
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D420
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D428
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D430
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D438
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D440
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D448
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D450
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D458
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D460
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D468
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D470
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D478
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D480
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D488
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D490
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D498
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D4F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D500
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D508
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D510
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D518
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D520
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D528
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D530
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D538
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D540
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D548
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D550
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D558
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D560
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D568
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D570
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D578
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D580
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D588
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D590
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D598
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D5F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D600
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D608
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D610
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D618
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D620
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D628
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D630
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D638
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D640
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D648
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D650
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D658
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D660
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D668
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D670
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D678
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D680
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D688
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D690
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D698
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D6F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D700
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D708
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D710
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D718
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D720
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D728
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D730
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D738
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D740
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D748
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D750
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D758
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D760
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D768
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D770
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D778
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D780
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D788
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D790
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D798
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D7F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D800
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D808
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D810
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D818
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D820
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D828
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D830
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D838
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D840
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D848
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D850
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D858
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D860
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D868
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D870
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D878
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D880
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D888
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D890
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D898
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D8F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D900
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D908
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D910
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D918
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D920
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D928
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D930
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D938
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D940
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D948
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D950
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D958
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D960
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D968
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D970
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D978
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D980
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D988
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D990
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D998
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $D9F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA88
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA90
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DA98
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAA0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAA8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAB0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAB8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAC0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAC8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAD0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAD8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAE0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAE8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAF0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DAF8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB88
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB90
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DB98
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBA0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBA8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBB0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBB8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBC0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBC8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBD0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBD8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBE0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBE8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBF0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DBF8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC88
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC90
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DC98
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCA0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCA8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCB0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCB8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCC0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCC8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCD0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCD8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCE0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCE8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCF0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DCF8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD88
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD90
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DD98
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDA0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDA8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDB0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDB8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDC0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDC8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDD0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDD8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDE0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDE8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDF0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DDF8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE88
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE90
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DE98
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DEA0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DEA8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DEB0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DEB8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DEC0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DEC8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DED0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DED8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DEE0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DEE8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DEF0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DEF8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF88
+	.byte $00,$00                          ; $DF90
+	BRK                                    ; $DF92
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF93
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DF9B
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DFA3
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DFAB
+	.byte $00                              ; $DFB3
+	BRK                                    ; $DFB4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DFB5
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DFBD
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DFC5
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DFCD
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DFD5
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DFDD
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DFE5
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DFED
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $DFF5
+	.byte $D0,$00,$D0                      ; $DFFD
+
+	SEG bank1
+	ORG $1000
+	RORG $F000
+	SEI                                    ; $F000
+	CLD                                    ; $F001
+	LDX #$FF                               ; $F002
+	TXS                                    ; $F004
+	NOP                                    ; $F005
+	NOP                                    ; $F006
+B1_F007
+	JSR B1_F00D                            ; $F007
+	JMP B1_F007                            ; $F00A
+B1_F00D
+	STA WSYNC                              ; $F00D
+	RTS                                    ; $F00F
+	BRK                                    ; $F010
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F011
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F019
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F021
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F029
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F031
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F039
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F041
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F049
+	.byte $00,$00,$00,$00,$00,$00          ; $F051
+	BRK                                    ; $F057
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F058
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F060
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F068
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F070
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F078
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F080
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F088
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F090
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F098
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F0F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F100
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F108
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F110
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F118
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F120
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F128
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F130
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F138
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F140
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F148
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F150
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F158
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F160
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F168
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F170
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F178
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F180
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F188
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F190
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F198
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F1F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F200
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F208
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F210
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F218
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F220
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F228
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F230
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F238
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F240
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F248
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F250
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F258
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F260
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F268
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F270
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F278
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F280
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F288
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F290
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F298
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F2F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F300
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F308
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F310
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F318
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F320
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F328
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F330
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F338
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F340
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F348
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F350
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F358
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F360
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F368
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F370
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F378
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F380
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F388
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F390
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F398
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F3F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F400
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F408
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F410
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F418
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F420
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F428
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F430
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F438
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F440
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F448
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F450
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F458
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F460
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F468
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F470
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F478
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F480
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F488
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F490
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F498
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F4F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F500
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F508
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F510
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F518
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F520
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F528
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F530
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F538
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F540
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F548
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F550
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F558
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F560
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F568
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F570
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F578
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F580
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F588
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F590
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F598
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F5F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F600
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F608
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F610
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F618
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F620
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F628
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F630
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F638
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F640
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F648
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F650
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F658
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F660
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F668
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F670
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F678
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F680
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F688
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F690
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F698
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F6F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F700
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F708
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F710
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F718
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F720
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F728
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F730
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F738
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F740
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F748
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F750
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F758
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F760
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F768
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F770
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F778
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F780
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F788
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F790
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F798
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F7F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F800
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F808
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F810
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F818
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F820
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F828
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F830
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F838
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F840
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F848
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F850
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F858
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F860
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F868
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F870
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F878
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F880
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F888
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F890
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F898
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F8F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F900
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F908
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F910
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F918
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F920
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F928
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F930
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F938
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F940
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F948
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F950
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F958
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F960
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F968
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F970
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F978
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F980
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F988
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F990
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F998
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9A0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9A8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9B0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9B8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9C0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9C8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9D0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9D8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9E0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9E8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9F0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $F9F8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA88
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA90
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FA98
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAA0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAA8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAB0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAB8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAC0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAC8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAD0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAD8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAE0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAE8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAF0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FAF8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB88
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB90
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FB98
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBA0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBA8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBB0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBB8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBC0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBC8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBD0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBD8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBE0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBE8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBF0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FBF8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC88
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC90
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FC98
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCA0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCA8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCB0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCB8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCC0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCC8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCD0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCD8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCE0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCE8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCF0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FCF8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD88
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD90
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FD98
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDA0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDA8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDB0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDB8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDC0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDC8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDD0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDD8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDE0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDE8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDF0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FDF8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE88
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE90
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FE98
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEA0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEA8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEB0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEB8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEC0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEC8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FED0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FED8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEE0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEE8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEF0
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FEF8
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF00
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF08
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF10
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF18
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF20
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF28
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF30
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF38
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF40
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF48
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF50
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF58
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF60
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF68
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF70
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF78
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF80
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF88
+	.byte $00,$00,$00                      ; $FF90
+	BRK                                    ; $FF93
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF94
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FF9C
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFA4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFAC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFB4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFBC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFC4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFCC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFD4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFDC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFE4
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFEC
+	.byte $00,$00,$00,$00,$00,$00,$00,$00  ; $FFF4
+	.byte $00,$F0,$00,$F0                  ; $FFFC
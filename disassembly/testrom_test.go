@@ -0,0 +1,62 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// the test cartridge in testdata is a 4k cartridge with the following program
+// at the reset address ($f000). the file is also used by tests in other
+// packages.
+//
+//	f000 LDA $80
+//	f002 STA WSYNC
+//	f004 INC $81
+//	f006 BNE $f000
+//	f008 JSR $f010
+//	f00b JMP $f000
+//	f010 LDA $f800,X
+//	f013 RTS
+var testROMFile = filepath.Join("testdata", "test_4k.bin")
+
+// testROM returns the data of the test cartridge. if any programs are
+// specified then the program in the test cartridge is replaced and a 4k bank
+// is created for each program. the reset vector in every bank points to $f000.
+func testROM(t *testing.T, programs ...[]uint8) []uint8 {
+	t.Helper()
+
+	rom, err := os.ReadFile(testROMFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(programs) == 0 {
+		return rom
+	}
+
+	data := make([]uint8, 0, len(programs)*len(rom))
+	for _, p := range programs {
+		bank := make([]uint8, len(rom))
+		copy(bank, p)
+		copy(bank[0xffc:], rom[0xffc:])
+		data = append(data, bank...)
+	}
+
+	return data
+}
@@ -61,6 +61,13 @@ func (dsm *Disassembly) WriteEntry(output io.Writer, attr WriteAttr, e *Entry) {
 		return
 	}
 
+	// bytes that have been accessed only as data are written as such,
+	// whatever the level of the entry
+	if e.IsData() {
+		dsm.writeData(output, attr, e)
+		return
+	}
+
 	if e.Level < EntryLevelBlessed {
 		return
 	}
@@ -83,5 +90,53 @@ func (dsm *Disassembly) WriteEntry(output io.Writer, attr WriteAttr, e *Entry) {
 	output.Write([]byte(" "))
 	output.Write([]byte(e.GetField(FldDefnCycles)))
 
+	if c := e.Comment(); c != "" {
+		output.Write([]byte(fmt.Sprintf(" ; %s", c)))
+	}
+
+	output.Write([]byte("\n"))
+}
+
+// writes a single data byte. graphics data is accompanied by a bitmap.
+func (dsm *Disassembly) writeData(output io.Writer, attr WriteAttr, e *Entry) {
+	if e.Result.Defn == nil {
+		return
+	}
+
+	// every address in the cartridge has been decoded so the opcode of the
+	// entry is the data byte
+	v := e.Result.Defn.OpCode
+
+	if attr.ByteCode {
+		output.Write([]byte(fmt.Sprintf("%-*s ", widthBytecode, fmt.Sprintf("%02x", v))))
+	}
+
+	output.Write([]byte(e.GetField(FldAddress)))
+	output.Write([]byte(fmt.Sprintf(" .byte $%02x ; %s", v, e.Class)))
+
+	if e.Class&ClassGFX == ClassGFX {
+		output.Write([]byte(" "))
+		output.Write([]byte(Bitmap(v)))
+	}
+
+	if c := e.Comment(); c != "" {
+		output.Write([]byte(fmt.Sprintf(" ; %s", c)))
+	}
+
 	output.Write([]byte("\n"))
 }
+
+// Bitmap returns a string representation of a graphics byte. Set bits are
+// represented by the 'X' character and unset bits by the '.' character. The
+// most significant bit is on the left.
+func Bitmap(v uint8) string {
+	b := make([]byte, 8)
+	for i := 0; i < 8; i++ {
+		if v&(0x80>>i) != 0 {
+			b[i] = 'X'
+		} else {
+			b[i] = '.'
+		}
+	}
+	return string(b)
+}
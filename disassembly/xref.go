@@ -0,0 +1,298 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"sort"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// XrefKind describes how an instruction refers to an address.
+type XrefKind int
+
+// List of valid XrefKind values.
+const (
+	XrefRead XrefKind = iota
+	XrefWrite
+	XrefReadWrite
+	XrefJump
+	XrefBranch
+)
+
+func (k XrefKind) String() string {
+	switch k {
+	case XrefRead:
+		return "read"
+	case XrefWrite:
+		return "write"
+	case XrefReadWrite:
+		return "read/write"
+	case XrefJump:
+		return "jump"
+	case XrefBranch:
+		return "branch"
+	}
+	return ""
+}
+
+// Xref is a single reference to an address.
+type Xref struct {
+	Kind XrefKind
+
+	// the instruction that refers to the address. Entry is a copy of the
+	// disassembly entry at the time Xrefs() was called
+	Bank    int
+	Address uint16
+	Entry   *Entry
+
+	// the reference was observed during emulation rather than found in the
+	// disassembly. for example, an indexed instruction that reaches beyond
+	// the base address in its operand
+	Runtime bool
+}
+
+// the instruction making a reference. the address is the address of the
+// instruction with the cartridge origin bits removed.
+type xrefKey struct {
+	bank    int
+	address uint16
+}
+
+// the address being referred to. read and write addresses are mapped
+// differently so the write flag is part of the key.
+type xrefTargetKey struct {
+	address uint16
+	write   bool
+}
+
+// runtime references. the value of the inner map is the reference kind.
+type xrefRuntime map[xrefTargetKey]map[xrefKey]XrefKind
+
+// the kind and target address of the reference made by an instruction. the
+// bool return value is false if the instruction does not refer to an address.
+func xrefTarget(result execution.Result) (XrefKind, uint16, bool) {
+	defn := result.Defn
+	if defn == nil || result.ByteCount < 2 {
+		return 0, 0, false
+	}
+
+	switch defn.AddressingMode {
+	case instructions.Implied, instructions.Immediate:
+		return 0, 0, false
+	}
+
+	operand := result.InstructionData
+
+	switch defn.Effect {
+	case instructions.Read:
+		return XrefRead, operand, true
+	case instructions.Write:
+		return XrefWrite, operand, true
+	case instructions.RMW:
+		return XrefReadWrite, operand, true
+	case instructions.Flow:
+		if defn.IsBranch() {
+			return XrefBranch, absoluteBranchDestination(result.Address, operand), true
+		}
+
+		// the operand of an indirect JMP is the address of the vector. the
+		// vector is read by the instruction
+		if defn.AddressingMode == instructions.Indirect {
+			return XrefRead, operand, true
+		}
+		return XrefJump, operand, true
+	case instructions.Subroutine:
+		// JSR is the only subroutine instruction with an operand
+		return XrefJump, operand, true
+	}
+
+	return 0, 0, false
+}
+
+// compare addresses according to how they are accessed by the reference
+// kind. read and write addresses are mapped differently.
+func xrefMatch(kind XrefKind, target uint16, address uint16) bool {
+	switch kind {
+	case XrefWrite:
+		a, _ := memorymap.MapAddress(target, false)
+		b, _ := memorymap.MapAddress(address, false)
+		return a == b
+	case XrefReadWrite:
+		a, _ := memorymap.MapAddress(target, false)
+		b, _ := memorymap.MapAddress(address, false)
+		if a == b {
+			return true
+		}
+	}
+	a, _ := memorymap.MapAddress(target, true)
+	b, _ := memorymap.MapAddress(address, true)
+	return a == b
+}
+
+// Xrefs returns every instruction that refers to the address. References are
+// found by looking at the operand of every blessed instruction in the
+// disassembly. In addition, any references observed during emulation are
+// included (see SetXrefObservation()).
+//
+// The list is sorted by bank and then by the address of the instruction.
+func (dsm *Disassembly) Xrefs(address uint16) []Xref {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	refs := make([]Xref, 0)
+	found := make(map[xrefKey]bool)
+
+	for b := range dsm.entries {
+		for _, e := range dsm.entries[b] {
+			if e == nil || e.Level < EntryLevelBlessed || e.IsData() {
+				continue // for loop
+			}
+
+			kind, target, ok := xrefTarget(e.Result)
+			if !ok || !xrefMatch(kind, target, address) {
+				continue // for loop
+			}
+
+			c := *e
+			refs = append(refs, Xref{
+				Kind:    kind,
+				Bank:    b,
+				Address: e.Result.Address,
+				Entry:   &c,
+			})
+			found[xrefKey{bank: b, address: e.Result.Address & memorymap.CartridgeBits}] = true
+		}
+	}
+
+	// add runtime references that weren't found in the disassembly
+	ma, _ := memorymap.MapAddress(address, true)
+	mw, _ := memorymap.MapAddress(address, false)
+	for _, t := range []xrefTargetKey{{address: ma}, {address: mw, write: true}} {
+		for k, kind := range dsm.xrefRuntime[t] {
+			if found[k] {
+				continue // for loop
+			}
+			found[k] = true
+
+			var c *Entry
+			if k.bank < len(dsm.entries) {
+				if e := dsm.entries[k.bank][k.address]; e != nil {
+					c = &Entry{}
+					*c = *e
+				}
+			}
+
+			addr := k.address | memorymap.OriginCart
+			if c != nil {
+				addr = c.Result.Address
+			}
+
+			refs = append(refs, Xref{
+				Kind:    kind,
+				Bank:    k.bank,
+				Address: addr,
+				Entry:   c,
+				Runtime: true,
+			})
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Bank == refs[j].Bank {
+			return refs[i].Address&memorymap.CartridgeBits < refs[j].Address&memorymap.CartridgeBits
+		}
+		return refs[i].Bank < refs[j].Bank
+	})
+
+	return refs
+}
+
+// SetXrefObservation turns the recording of runtime references on or off.
+// Runtime references augment the references found in the disassembly with the
+// effective address of indexed and indirect instructions.
+func (dsm *Disassembly) SetXrefObservation(on bool) {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+	dsm.xrefObserve = on
+}
+
+// IsObservingXrefs returns true if runtime references are being recorded.
+func (dsm *Disassembly) IsObservingXrefs() bool {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+	return dsm.xrefObserve
+}
+
+// ResetXrefs forgets all runtime references.
+func (dsm *Disassembly) ResetXrefs() {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+	dsm.xrefRuntime = make(xrefRuntime)
+}
+
+// ObserveXref records the reference made by the most recently executed
+// instruction. The accessAddress and accessWrite arguments describe the final
+// memory access of the instruction. Only instructions in the cartridge are
+// recorded.
+//
+// Does nothing unless runtime references are being observed. Should be called
+// after every instruction, after ExecutedEntry().
+func (dsm *Disassembly) ObserveXref(bank mapper.BankInfo, result execution.Result, accessAddress uint16, accessWrite bool) {
+	if result.Defn == nil || !result.Final {
+		return
+	}
+
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	if !dsm.xrefObserve {
+		return
+	}
+
+	if bank.NonCart || bank.IsRAM || bank.Number >= len(dsm.entries) {
+		return
+	}
+
+	var kind XrefKind
+	switch result.Defn.AddressingMode {
+	case instructions.Implied, instructions.Immediate, instructions.Relative:
+		return
+	}
+	switch result.Defn.Effect {
+	case instructions.Read:
+		kind = XrefRead
+	case instructions.Write:
+		kind = XrefWrite
+	case instructions.RMW:
+		kind = XrefReadWrite
+	default:
+		return
+	}
+
+	ma, _ := memorymap.MapAddress(accessAddress, !accessWrite)
+	t := xrefTargetKey{address: ma, write: accessWrite}
+
+	m, ok := dsm.xrefRuntime[t]
+	if !ok {
+		m = make(map[xrefKey]XrefKind)
+		dsm.xrefRuntime[t] = m
+	}
+	m[xrefKey{bank: bank.Number, address: result.Address & memorymap.CartridgeBits}] = kind
+}
@@ -0,0 +1,89 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+)
+
+func TestXrefs(t *testing.T) {
+	dsm, err := disassembly.FromCartridge(cartridgeloader.Loader{
+		Filename: "test_xref",
+		Mapping:  "4k",
+		Data:     testROM(t),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	check := func(address uint16, expected ...disassembly.Xref) {
+		t.Helper()
+
+		refs := dsm.Xrefs(address)
+		if len(refs) != len(expected) {
+			t.Errorf("unexpected number of references to %#04x (%d, expected %d)", address, len(refs), len(expected))
+			return
+		}
+		for i := range refs {
+			if refs[i].Kind != expected[i].Kind || refs[i].Address != expected[i].Address || refs[i].Runtime != expected[i].Runtime {
+				t.Errorf("unexpected reference to %#04x (%s from %#04x)", address, refs[i].Kind, refs[i].Address)
+			}
+		}
+	}
+
+	check(0x80, disassembly.Xref{Kind: disassembly.XrefRead, Address: 0x1000})
+	check(0x02, disassembly.Xref{Kind: disassembly.XrefWrite, Address: 0x1002})
+	check(0x81, disassembly.Xref{Kind: disassembly.XrefReadWrite, Address: 0x1004})
+	check(0xf000,
+		disassembly.Xref{Kind: disassembly.XrefBranch, Address: 0x1006},
+		disassembly.Xref{Kind: disassembly.XrefJump, Address: 0x100b},
+	)
+
+	// addresses in the disassembly are normalised to the 0x1000 origin but
+	// any mirror of the address can be used to find references
+	check(0x1010, disassembly.Xref{Kind: disassembly.XrefJump, Address: 0x1008})
+	check(0xf800, disassembly.Xref{Kind: disassembly.XrefRead, Address: 0x1010})
+
+	// the effective address of an indexed instruction is only known at runtime
+	lda := execution.Result{
+		Defn:            instructions.GetDefinitions()[0xbd],
+		Address:         0xf010,
+		InstructionData: 0xf800,
+		ByteCount:       3,
+		Final:           true,
+	}
+
+	dsm.ObserveXref(mapper.BankInfo{Number: 0}, lda, 0xf805, false)
+	check(0xf805)
+
+	dsm.SetXrefObservation(true)
+	dsm.ObserveXref(mapper.BankInfo{Number: 0}, lda, 0xf805, false)
+	check(0xf805, disassembly.Xref{Kind: disassembly.XrefRead, Address: 0x1010, Runtime: true})
+
+	// runtime references that are also found in the disassembly are not
+	// duplicated
+	dsm.ObserveXref(mapper.BankInfo{Number: 0}, lda, 0xf800, false)
+	check(0xf800, disassembly.Xref{Kind: disassembly.XrefRead, Address: 0x1010})
+
+	dsm.ResetXrefs()
+	check(0xf805)
+}
@@ -0,0 +1,135 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package framebuffer keeps a copy of the full television signal. It is
+// intended to be used by implementations of the television.PixelRenderer
+// interface that write the television image to disk.
+//
+// The buffer records the color signal of each pixel rather than the color.
+// The color is decided by the television specification when the pixel is
+// read.
+package framebuffer
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// Buffer is a copy of the full television signal, including the horizontal
+// and vertical blanking areas.
+type Buffer struct {
+	// the most recent values given to Resize()
+	Spec    specification.Spec
+	Top     int
+	Visible int
+
+	// color signal for every pixel. VBLANK is recorded as signal.VideoBlack
+	signals []signal.ColorSignal
+}
+
+// Width returns the width of the buffer. This is the number of color clocks
+// in a scanline.
+func (buf *Buffer) Width() int {
+	return specification.HorizClksScanline
+}
+
+// Height returns the height of the buffer. This is the total number of
+// scanlines for the specification.
+func (buf *Buffer) Height() int {
+	return buf.Spec.ScanlinesTotal
+}
+
+// Resize should be called from the Resize() function of the PixelRenderer.
+// The buffer is emptied if the number of scanlines has changed. Returns true
+// if the buffer was emptied.
+func (buf *Buffer) Resize(spec specification.Spec, topScanline, visibleScanlines int) bool {
+	buf.Top = topScanline
+	buf.Visible = visibleScanlines
+
+	if buf.signals != nil && spec.ScanlinesTotal == buf.Spec.ScanlinesTotal {
+		buf.Spec = spec
+		return false
+	}
+
+	buf.Spec = spec
+	buf.signals = make([]signal.ColorSignal, specification.HorizClksScanline*spec.ScanlinesTotal)
+	buf.Reset()
+
+	return true
+}
+
+// SetPixel should be called from the SetPixel() function of the PixelRenderer.
+func (buf *Buffer) SetPixel(sig signal.SignalAttributes) {
+	i, ok := buf.index(sig.HorizPos, sig.Scanline)
+	if !ok {
+		return
+	}
+
+	// handle VBLANK by setting pixels to black
+	if sig.VBlank {
+		buf.signals[i] = signal.VideoBlack
+	} else {
+		buf.signals[i] = sig.Pixel
+	}
+}
+
+// Reset sets every pixel in the buffer to video black.
+func (buf *Buffer) Reset() {
+	for i := range buf.signals {
+		buf.signals[i] = signal.VideoBlack
+	}
+}
+
+func (buf *Buffer) index(x int, y int) (int, bool) {
+	if x < 0 || x >= specification.HorizClksScanline {
+		return 0, false
+	}
+	i := y*specification.HorizClksScanline + x
+	if i < 0 || i >= len(buf.signals) {
+		return 0, false
+	}
+	return i, true
+}
+
+// Signal returns the color signal of the pixel at the coordinates. Returns
+// signal.VideoBlack if the coordinates are outside the buffer.
+func (buf *Buffer) Signal(x int, y int) signal.ColorSignal {
+	i, ok := buf.index(x, y)
+	if !ok {
+		return signal.VideoBlack
+	}
+	return buf.signals[i]
+}
+
+// Color returns the color of the pixel at the coordinates using the palette
+// of the specification. Returns video black if the coordinates are outside
+// the buffer.
+func (buf *Buffer) Color(x int, y int) color.RGBA {
+	return buf.Spec.GetColor(buf.Signal(x, y))
+}
+
+// Image returns a new image of the entire buffer.
+func (buf *Buffer) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, buf.Width(), buf.Height()))
+	for y := 0; y < buf.Height(); y++ {
+		for x := 0; x < buf.Width(); x++ {
+			img.SetRGBA(x, y, buf.Color(x, y))
+		}
+	}
+	return img
+}
@@ -0,0 +1,81 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package framebuffer_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/framebuffer"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+func TestBuffer(t *testing.T) {
+	var buf framebuffer.Buffer
+
+	spec := specification.SpecNTSC
+	if !buf.Resize(spec, spec.AtariSafeTop, spec.AtariSafeBottom-spec.AtariSafeTop) {
+		t.Fatalf("expected new buffer")
+	}
+	if buf.Width() != specification.HorizClksScanline || buf.Height() != spec.ScanlinesTotal {
+		t.Errorf("unexpected buffer size (%dx%d)", buf.Width(), buf.Height())
+	}
+
+	buf.SetPixel(signal.SignalAttributes{Pixel: 0x0e, HorizPos: 100, Scanline: 50})
+	if buf.Signal(100, 50) != 0x0e {
+		t.Errorf("unexpected signal (%d)", buf.Signal(100, 50))
+	}
+	if buf.Color(100, 50) != spec.GetColor(0x0e) {
+		t.Errorf("unexpected color (%v)", buf.Color(100, 50))
+	}
+	if c := buf.Image().RGBAAt(100, 50); c != spec.GetColor(0x0e) {
+		t.Errorf("unexpected color in image (%v)", c)
+	}
+
+	// VBLANK is recorded as video black
+	buf.SetPixel(signal.SignalAttributes{Pixel: 0x0e, HorizPos: 100, Scanline: 51, VBlank: true})
+	if buf.Signal(100, 51) != signal.VideoBlack {
+		t.Errorf("VBLANK pixel not recorded as video black")
+	}
+
+	// pixels outside the buffer are ignored and read as video black
+	buf.SetPixel(signal.SignalAttributes{Pixel: 0x0e, HorizPos: specification.HorizClksScanline, Scanline: 50})
+	if buf.Signal(0, 51) != signal.VideoBlack {
+		t.Errorf("pixel outside buffer wrapped onto next scanline")
+	}
+	if buf.Signal(-1, 0) != signal.VideoBlack || buf.Signal(0, spec.ScanlinesTotal) != signal.VideoBlack {
+		t.Errorf("expected video black outside buffer")
+	}
+
+	// resizing to the same number of scanlines keeps the buffer
+	if buf.Resize(spec, 10, 200) || buf.Signal(100, 50) != 0x0e {
+		t.Errorf("buffer should not be emptied by resize")
+	}
+	if buf.Top != 10 || buf.Visible != 200 {
+		t.Errorf("unexpected visible area (%d, %d)", buf.Top, buf.Visible)
+	}
+
+	// a different number of scanlines empties the buffer
+	if !buf.Resize(specification.SpecPAL, 10, 200) || buf.Signal(100, 50) != signal.VideoBlack {
+		t.Errorf("buffer should be emptied by resize")
+	}
+
+	buf.SetPixel(signal.SignalAttributes{Pixel: 0x0e, HorizPos: 100, Scanline: 50})
+	buf.Reset()
+	if buf.Signal(100, 50) != signal.VideoBlack {
+		t.Errorf("buffer not reset")
+	}
+}
@@ -25,6 +25,8 @@ import (
 	"time"
 
 	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/clipwriter"
+	"github.com/jetsetilly/gopher2600/crtfilter"
 	"github.com/jetsetilly/gopher2600/debugger"
 	"github.com/jetsetilly/gopher2600/debugger/terminal"
 	"github.com/jetsetilly/gopher2600/debugger/terminal/colorterm"
@@ -36,12 +38,16 @@ import (
 	"github.com/jetsetilly/gopher2600/hiscore"
 	"github.com/jetsetilly/gopher2600/logger"
 	"github.com/jetsetilly/gopher2600/modalflag"
+	"github.com/jetsetilly/gopher2600/patch"
 	"github.com/jetsetilly/gopher2600/paths"
 	"github.com/jetsetilly/gopher2600/performance"
 	"github.com/jetsetilly/gopher2600/playmode"
+	"github.com/jetsetilly/gopher2600/pngwriter"
 	"github.com/jetsetilly/gopher2600/recorder"
 	"github.com/jetsetilly/gopher2600/regression"
+	"github.com/jetsetilly/gopher2600/resampler"
 	"github.com/jetsetilly/gopher2600/statsview"
+	"github.com/jetsetilly/gopher2600/videowriter"
 	"github.com/jetsetilly/gopher2600/wavwriter"
 )
 
@@ -209,7 +215,7 @@ func launch(sync *mainSync) {
 	md := &modalflag.Modes{Output: os.Stdout}
 	md.NewArgs(os.Args[1:])
 	md.NewMode()
-	md.AddSubModes("RUN", "PLAY", "DEBUG", "DISASM", "PERFORMANCE", "REGRESS", "HISCORE")
+	md.AddSubModes("RUN", "PLAY", "DEBUG", "DISASM", "PERFORMANCE", "REGRESS", "HISCORE", "PATCH")
 
 	p, err := md.Parse()
 	switch p {
@@ -245,6 +251,9 @@ func launch(sync *mainSync) {
 
 	case "HISCORE":
 		err = hiscoreServer(md)
+
+	case "PATCH":
+		err = createPatch(md)
 	}
 
 	if err != nil {
@@ -260,11 +269,16 @@ func play(md *modalflag.Modes, sync *mainSync) error {
 	md.NewMode()
 
 	mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping")
-	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL")
+	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL, PAL60, PAL-M, PAL-N, SECAM")
 	crt := md.AddBool("crt", true, "apply CRT post-processing")
 	fpsCap := md.AddBool("fpscap", true, "cap fps to specification")
 	record := md.AddBool("record", false, "record user input to a file")
 	wav := md.AddString("wav", "", "record audio to wav file")
+	wavStereo := md.AddBool("wavstereo", false, "record wav file in stereo (channel 0 left, channel 1 right)")
+	video := md.AddString("video", "", "record video and audio to avi or y4m file")
+	videoCRT := md.AddBool("videocrt", false, "apply CRT/NTSC filter to recorded video")
+	screenshot := md.AddString("screenshot", "", "write frames to png files (eg. frame=600 or every=10,range=100-200)")
+	clip := md.AddInt("clip", clipwriter.DefaultLength, "seconds of video kept for the clip hotkey (F9 for gif, shift-F9 for png)")
 	patchFile := md.AddString("patch", "", "patch file to apply (cartridge args only)")
 	hiscore := md.AddBool("hiscore", false, "contact hiscore server [EXPERIMENTAL]")
 	log := md.AddBool("log", false, "echo debugging log to stdout")
@@ -305,13 +319,48 @@ func play(md *modalflag.Modes, sync *mainSync) error {
 
 		// add wavwriter mixer if wav argument has been specified
 		if *wav != "" {
-			aw, err := wavwriter.New(*wav)
+			var aw *wavwriter.WavWriter
+			if *wavStereo {
+				aw, err = wavwriter.NewStereo(*wav, 1.0)
+			} else {
+				aw, err = wavwriter.New(*wav)
+			}
 			if err != nil {
 				return err
 			}
+
+			// resample according to user preferences
+			rp, err := resampler.NewPreferences()
+			if err != nil {
+				return err
+			}
+			aw.Resample(rp.Rate(), rp.LowPass.Get().(bool))
+
 			tv.AddAudioMixer(aw)
 		}
 
+		// add video writer if video argument has been specified
+		if *video != "" {
+			vw, err := videowriter.New(tv, *video)
+			if err != nil {
+				return err
+			}
+
+			if *videoCRT {
+				filter, err := newCRTFilter()
+				if err != nil {
+					return err
+				}
+				vw.SetFilter(filter)
+			}
+		}
+
+		// add png writer if screenshot argument has been specified
+		err = addPNGWriter(tv, cartload, *screenshot)
+		if err != nil {
+			return err
+		}
+
 		// create gui
 		sync.creator <- func() (GuiCreator, error) {
 			return sdlimgui.NewSdlImgui(tv, true)
@@ -347,7 +396,7 @@ func play(md *modalflag.Modes, sync *mainSync) error {
 		// end playback recordings gracefully
 		sync.state <- stateRequest{req: reqNoIntSig}
 
-		err = playmode.Play(tv, scr, *record, cartload, *patchFile, *hiscore, *useSavekey)
+		err = playmode.Play(tv, scr, *record, cartload, *patchFile, *hiscore, *useSavekey, *clip)
 		if err != nil {
 			return err
 		}
@@ -378,11 +427,13 @@ func debug(md *modalflag.Modes, sync *mainSync) error {
 	}
 
 	mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping")
-	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL")
+	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL, PAL60, PAL-M, PAL-N, SECAM")
 	termType := md.AddString("term", "IMGUI", "terminal type to use in debug mode: IMGUI, COLOR, PLAIN")
 	initScript := md.AddString("initscript", defInitScript, "script to run on debugger start")
 	profile := md.AddBool("profile", false, "run debugger through cpu profiler")
 	useSavekey := md.AddBool("savekey", false, "use savekey in player 1 port")
+	symbolsFile := md.AddString("symbols", "", "symbols file: DASM .sym or .lst, ca65 .dbg, batari Basic .symbol.txt or .list.txt")
+	hotReload := md.AddBool("hotreload", false, "reload cartridge when the cartridge or symbols file changes")
 
 	stats := &[]bool{false}[0]
 	if statsview.Available() {
@@ -455,6 +506,8 @@ func debug(md *modalflag.Modes, sync *mainSync) error {
 	if err != nil {
 		return err
 	}
+	dbg.SymbolsFile = *symbolsFile
+	dbg.HotReload = *hotReload
 
 	switch len(md.RemainingArgs()) {
 	case 0:
@@ -510,6 +563,8 @@ func disasm(md *modalflag.Modes) error {
 	mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping")
 	bytecode := md.AddBool("bytecode", false, "include bytecode in disassembly")
 	bank := md.AddInt("bank", -1, "show disassembly for a specific bank")
+	dasm := md.AddBool("dasm", false, "output DASM compatible source code")
+	symbolsFile := md.AddString("symbols", "", "symbols file: DASM .sym or .lst, ca65 .dbg, batari Basic .symbol.txt or .list.txt")
 
 	p, err := md.Parse()
 	if err != nil || p != modalflag.ParseContinue {
@@ -526,7 +581,7 @@ func disasm(md *modalflag.Modes) error {
 
 		cartload := cartridgeloader.NewLoader(md.GetArg(0), *mapping)
 
-		dsm, err := disassembly.FromCartridge(cartload)
+		dsm, err := disassembly.FromCartridgeWithSymbols(cartload, *symbolsFile)
 		if err != nil {
 			// print what disassembly output we do have
 			if dsm != nil {
@@ -537,7 +592,9 @@ func disasm(md *modalflag.Modes) error {
 		}
 
 		// output entire disassembly or just a specific bank
-		if *bank < 0 {
+		if *dasm {
+			err = dsm.WriteDASM(md.Output)
+		} else if *bank < 0 {
 			err = dsm.Write(md.Output, attr)
 		} else {
 			err = dsm.WriteBank(md.Output, attr, *bank)
@@ -557,11 +614,14 @@ func perform(md *modalflag.Modes, sync *mainSync) error {
 	md.NewMode()
 
 	mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping")
-	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL")
+	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL, PAL60, PAL-M, PAL-N, SECAM")
 	display := md.AddBool("display", false, "display TV output")
 	fpsCap := md.AddBool("fpscap", true, "cap FPS to specification (only valid if -display=true)")
 	duration := md.AddString("duration", "5s", "run duration (note: there is a 2s overhead)")
 	profile := md.AddBool("profile", false, "produce cpu and memory profiling reports")
+	screenshot := md.AddString("screenshot", "", "write frames to png files (eg. frame=600 or every=10,range=100-200)")
+	video := md.AddString("video", "", "record video and audio to avi or y4m file")
+	videoCRT := md.AddBool("videocrt", false, "apply CRT/NTSC filter to recorded video")
 
 	p, err := md.Parse()
 	if err != nil || p != modalflag.ParseContinue {
@@ -583,6 +643,28 @@ func perform(md *modalflag.Modes, sync *mainSync) error {
 		// fpscap for tv (see below for gui vsync option)
 		tv.SetFPSCap(*fpsCap)
 
+		// add video writer if video argument has been specified
+		if *video != "" {
+			vw, err := videowriter.New(tv, *video)
+			if err != nil {
+				return err
+			}
+
+			if *videoCRT {
+				filter, err := newCRTFilter()
+				if err != nil {
+					return err
+				}
+				vw.SetFilter(filter)
+			}
+		}
+
+		// add png writer if screenshot argument has been specified
+		err = addPNGWriter(tv, cartload, *screenshot)
+		if err != nil {
+			return err
+		}
+
 		if *display {
 			// create gui
 			sync.creator <- func() (GuiCreator, error) {
@@ -617,6 +699,49 @@ func perform(md *modalflag.Modes, sync *mainSync) error {
 	return nil
 }
 
+// add a png writer to the television if the screenshot options are not empty.
+func addPNGWriter(tv *television.Television, cartload cartridgeloader.Loader, screenshot string) error {
+	if screenshot == "" {
+		return nil
+	}
+
+	opts := pngwriter.DefaultOptions
+	opts.Prefix = fmt.Sprintf("screenshot_%s", cartload.ShortName())
+
+	opts, err := pngwriter.ParseOptions(screenshot, opts)
+	if err != nil {
+		return err
+	}
+
+	// debug colors require a reflection monitor, which is only available in
+	// the debugger
+	if opts.Debug {
+		return fmt.Errorf("debug colors are only available in the debugger")
+	}
+
+	w := pngwriter.New(tv, opts)
+
+	// the crt option requires a filter
+	if opts.CRT {
+		filter, err := newCRTFilter()
+		if err != nil {
+			return err
+		}
+		w.SetFilter(filter)
+	}
+
+	return nil
+}
+
+// create a CRT filter using the user's saved preferences.
+func newCRTFilter() (*crtfilter.Filter, error) {
+	p, err := crtfilter.NewPreferences()
+	if err != nil {
+		return nil, err
+	}
+	return crtfilter.NewFilter(p), nil
+}
+
 func regress(md *modalflag.Modes, sync *mainSync) error {
 	md.NewMode()
 	md.AddSubModes("RUN", "LIST", "DELETE", "ADD", "REDUX")
@@ -729,7 +854,7 @@ func regressAdd(md *modalflag.Modes) error {
 	mode := md.AddString("mode", "", "type of regression entry")
 	notes := md.AddString("notes", "", "additional annotation for the database")
 	mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping [non-playback]")
-	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL [non-playback]")
+	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL, PAL60, PAL-M, PAL-N, SECAM [non-playback]")
 	numframes := md.AddInt("frames", 10, "number of frames to run [non-playback]")
 	state := md.AddString("state", "", "record emulator state at every CPU step [non-playback]")
 	log := md.AddBool("log", false, "echo debugging log to stdout")
@@ -739,7 +864,7 @@ func regressAdd(md *modalflag.Modes) error {
 recorded playback file. For playback files, the flags marked [non-playback] do not make
 sense and will be ignored.
 
-Available modes are VIDEO, PLAYBACK and LOG. If not mode is explicitly given then
+Available modes are VIDEO, PLAYBACK, LOG and AUDIO. If not mode is explicitly given then
 VIDEO will be used for ROM files and PLAYBACK will be used for playback recordings.
 
 Value for the -state flag can be one of TV, PORTS, TIMER, CPU and can be used
@@ -812,6 +937,15 @@ with the LOG mode. Note that asking for log output will suppress regression prog
 				NumFrames: *numframes,
 				Notes:     *notes,
 			}
+		case "AUDIO":
+			cartload := cartridgeloader.NewLoader(md.GetArg(0), *mapping)
+
+			reg = &regression.AudioRegression{
+				CartLoad:  cartload,
+				TVtype:    strings.ToUpper(*spec),
+				NumFrames: *numframes,
+				Notes:     *notes,
+			}
 		}
 
 		err := regression.RegressAdd(md.Output, reg)
@@ -828,6 +962,30 @@ with the LOG mode. Note that asking for log output will suppress regression prog
 	return nil
 }
 
+func createPatch(md *modalflag.Modes) error {
+	md.NewMode()
+	md.AdditionalHelp("Create a patch file by comparing an original and a modified cartridge file. The format\nof the patch is decided by the extension of the patch file: .ips, .bps or text for anything else.")
+
+	p, err := md.Parse()
+	if err != nil || p != modalflag.ParseContinue {
+		return err
+	}
+
+	switch len(md.RemainingArgs()) {
+	case 0, 1, 2:
+		return fmt.Errorf("original cartridge, modified cartridge and patch file required for %s mode", md)
+	case 3:
+		err = patch.DiffFiles(md.GetArg(0), md.GetArg(1), md.GetArg(2))
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("too many arguments for %s mode", md)
+	}
+
+	return nil
+}
+
 func hiscoreServer(md *modalflag.Modes) error {
 	md.NewMode()
 	md.AddSubModes("ABOUT", "SETSERVER", "LOGIN", "LOGOFF")
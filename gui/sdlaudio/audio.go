@@ -17,9 +17,13 @@ package sdlaudio
 
 import (
 	"fmt"
+	"sync/atomic"
 
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
 	"github.com/jetsetilly/gopher2600/hardware/tia/audio"
 	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/resampler"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
@@ -42,6 +46,18 @@ const critQueueLength = 64
 // if queued audio ever exceeds this value then clip the audio.
 const maxQueueLength = 8192
 
+// the audio device is always opened in stereo. the queue lengths above are
+// measured in samples for each channel.
+const numChannels = 2
+
+// the stereo and low-pass settings are changed by the GUI and read by the
+// emulation so we store them in an atomic value.
+type stereoMode struct {
+	stereo     bool
+	separation float32
+	lowPass    bool
+}
+
 // Audio outputs sound using SDL.
 type Audio struct {
 	id   sdl.AudioDeviceID
@@ -49,22 +65,69 @@ type Audio struct {
 
 	buffer   []uint8
 	bufferCt int
+
+	Prefs *Preferences
+	mode  atomic.Value
+
+	// resampling preferences. changes to the sample rate will take effect
+	// the next time the audio device is opened
+	Resampling *resampler.Preferences
+	resampler  *resampler.Resampler
+	in         [numChannels]float32
 }
 
 // NewAudio is the preferred method of initialisatoin for the Audio Type.
 func NewAudio() (*Audio, error) {
 	aud := &Audio{
-		buffer: make([]uint8, bufferLength),
+		buffer: make([]uint8, bufferLength*numChannels),
+	}
+
+	var err error
+
+	aud.Prefs, err = NewPreferences()
+	if err != nil {
+		return nil, err
 	}
 
+	aud.Resampling, err = resampler.NewPreferences()
+	if err != nil {
+		return nil, err
+	}
+
+	aud.mode.Store(stereoMode{
+		stereo:     aud.Prefs.Stereo.Get().(bool),
+		separation: float32(aud.Prefs.Separation.Get().(float64)),
+		lowPass:    aud.Resampling.LowPass.Get().(bool),
+	})
+
+	aud.Prefs.Stereo.RegisterCallback(func(v prefs.Value) error {
+		m := aud.mode.Load().(stereoMode)
+		m.stereo = v.(bool)
+		aud.mode.Store(m)
+		return nil
+	})
+
+	aud.Prefs.Separation.RegisterCallback(func(v prefs.Value) error {
+		m := aud.mode.Load().(stereoMode)
+		m.separation = float32(v.(float64))
+		aud.mode.Store(m)
+		return nil
+	})
+
+	aud.Resampling.LowPass.RegisterCallback(func(v prefs.Value) error {
+		m := aud.mode.Load().(stereoMode)
+		m.lowPass = v.(bool)
+		aud.mode.Store(m)
+		return nil
+	})
+
 	spec := &sdl.AudioSpec{
-		Freq:     audio.SampleFreq,
+		Freq:     int32(aud.Resampling.Rate()),
 		Format:   sdl.AUDIO_U8,
-		Channels: 1,
+		Channels: numChannels,
 		Samples:  uint16(bufferLength),
 	}
 
-	var err error
 	var actualSpec sdl.AudioSpec
 
 	aud.id, err = sdl.OpenAudioDevice("", false, spec, &actualSpec, 0)
@@ -73,6 +136,7 @@ func NewAudio() (*Audio, error) {
 	}
 
 	aud.spec = actualSpec
+	aud.resampler = resampler.New(numChannels, audio.SampleFreq, int(aud.spec.Freq), aud.Resampling.LowPass.Get().(bool))
 
 	logger.Log("sdl: audio:", fmt.Sprintf("frequency: %d samples/sec", aud.spec.Freq))
 	logger.Log("sdl: audio:", fmt.Sprintf("format: %d", aud.spec.Format))
@@ -90,9 +154,27 @@ func NewAudio() (*Audio, error) {
 }
 
 // SetAudio implements the television.AudioMixer interface.
-func (aud *Audio) SetAudio(audioData uint8) error {
-	aud.buffer[aud.bufferCt] = audioData + aud.spec.Silence
-	aud.bufferCt++
+func (aud *Audio) SetAudio(sig signal.SignalAttributes) error {
+	left := sig.AudioData
+	right := sig.AudioData
+
+	m := aud.mode.Load().(stereoMode)
+	if m.stereo {
+		left, right = audio.Stereo(sig.AudioChannel0, sig.AudioChannel1, m.separation)
+	}
+
+	aud.in[0] = float32(left)
+	aud.in[1] = float32(right)
+	aud.resampler.SetLowPass(m.lowPass)
+
+	return aud.resampler.Write(aud.in[:], aud.queue)
+}
+
+// queue is called by the resampler for every output sample.
+func (aud *Audio) queue(out []float32) error {
+	aud.buffer[aud.bufferCt] = resampler.ToUint8(out[0]) + aud.spec.Silence
+	aud.buffer[aud.bufferCt+1] = resampler.ToUint8(out[1]) + aud.spec.Silence
+	aud.bufferCt += numChannels
 
 	if aud.bufferCt >= len(aud.buffer) {
 		// if buffer is full then queue audio unconditionally
@@ -102,7 +184,7 @@ func (aud *Audio) SetAudio(audioData uint8) error {
 		}
 		aud.bufferCt = 0
 	} else {
-		remaining := int(sdl.GetQueuedAudioSize(aud.id))
+		remaining := int(sdl.GetQueuedAudioSize(aud.id)) / numChannels
 
 		if remaining < critQueueLength {
 			// if we're running short of bits in the queue the queue what we have
@@ -113,7 +195,7 @@ func (aud *Audio) SetAudio(audioData uint8) error {
 			if err != nil {
 				return err
 			}
-		} else if remaining < minQueueLength && aud.bufferCt > 10 {
+		} else if remaining < minQueueLength && aud.bufferCt > 10*numChannels {
 			// if we're running short of bits in the queue the queue what we have
 			// in the buffer.
 			//
@@ -122,7 +204,7 @@ func (aud *Audio) SetAudio(audioData uint8) error {
 			// the additional condition makes sure we're not queueing a slice
 			// that is too short. SDL has been known to hang with short audio
 			// queues
-			err := sdl.QueueAudio(aud.id, aud.buffer[:aud.bufferCt-1])
+			err := sdl.QueueAudio(aud.id, aud.buffer[:aud.bufferCt-numChannels])
 			if err != nil {
 				return err
 			}
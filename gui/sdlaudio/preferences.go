@@ -0,0 +1,91 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlaudio
+
+import (
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// Preferences for the SDL audio output.
+type Preferences struct {
+	dsk *prefs.Disk
+
+	// if stereo is false then the mixed audio is sent to both speakers
+	Stereo prefs.Bool
+
+	// separation of the two audio channels when stereo is true. see the
+	// Stereo() function in the hardware/tia/audio package
+	Separation prefs.Float
+}
+
+func (p *Preferences) String() string {
+	return p.dsk.String()
+}
+
+const (
+	stereo     = false
+	separation = 1.0
+)
+
+// NewPreferences is the preferred method of initialisation for the Preferences type.
+func NewPreferences() (*Preferences, error) {
+	p := &Preferences{}
+	p.SetDefaults()
+
+	// save server using the prefs package
+	pth, err := paths.ResourcePath("", prefs.DefaultPrefsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	p.dsk, err = prefs.NewDisk(pth)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.dsk.Add("sdlaudio.stereo", &p.Stereo)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("sdlaudio.separation", &p.Separation)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.dsk.Load(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// SetDefaults reverts all audio settings to default values.
+func (p *Preferences) SetDefaults() {
+	p.Stereo.Set(stereo)
+	p.Separation.Set(separation)
+}
+
+// Load audio preferences from disk.
+func (p *Preferences) Load() error {
+	return p.dsk.Load(false)
+}
+
+// Save current audio preferences to disk.
+func (p *Preferences) Save() error {
+	return p.dsk.Save()
+}
@@ -81,10 +81,29 @@ type imguiColors struct {
 	DisasmBreakAddress imgui.Vec4
 	DisasmBreakOther   imgui.Vec4
 
+	// disassembly heatmap. the heat of an entry is interpolated between the
+	// cold and hot colours
+	DisasmHeatCold imgui.Vec4
+	DisasmHeatHot  imgui.Vec4
+
+	// disassembly entries classified as data and graphics data
+	DisasmData imgui.Vec4
+	DisasmGFX  imgui.Vec4
+
+	// source lines interleaved with the disassembly
+	DisasmSource imgui.Vec4
+
+	// user comments
+	DisasmComment imgui.Vec4
+
 	// audio oscilloscope
 	AudioOscBg   imgui.Vec4
 	AudioOscLine imgui.Vec4
 
+	// audio tracker
+	TrackerTimestamp imgui.Vec4
+	TrackerWrite     imgui.Vec4
+
 	// tia window
 	IdxPointer imgui.Vec4
 
@@ -115,9 +134,10 @@ type imguiColors struct {
 	// log
 	LogBackground imgui.Vec4
 
-	packedPaletteNTSC packedPalette
-	packedPalettePAL  packedPalette
-	packedPaletteAlt  packedPalette
+	packedPaletteNTSC  packedPalette
+	packedPalettePAL   packedPalette
+	packedPaletteSECAM packedPalette
+	packedPaletteAlt   packedPalette
 }
 
 func newColors() *imguiColors {
@@ -171,11 +191,21 @@ func newColors() *imguiColors {
 		DisasmCPUstep:   imgui.Vec4{1.0, 1.0, 1.0, 0.1},
 		DisasmVideoStep: imgui.Vec4{0.5, 0.5, 0.5, 0.07},
 		// deferring DisasmBreakAddress & DisasmBreakOther
+		DisasmHeatCold: imgui.Vec4{0.2, 0.2, 0.8, 0.15},
+		DisasmHeatHot:  imgui.Vec4{0.9, 0.2, 0.1, 0.35},
+		DisasmData:     imgui.Vec4{0.5, 0.7, 0.5, 1.0},
+		DisasmGFX:      imgui.Vec4{0.4, 0.8, 0.9, 1.0},
+		DisasmSource:   imgui.Vec4{0.6, 0.6, 0.6, 1.0},
+		DisasmComment:  imgui.Vec4{0.5, 0.8, 0.5, 1.0},
 
 		// audio oscilloscope
 		AudioOscBg:   imgui.Vec4{0.21, 0.29, 0.23, 1.0},
 		AudioOscLine: imgui.Vec4{0.10, 0.97, 0.29, 1.0},
 
+		// audio tracker
+		TrackerTimestamp: imgui.Vec4{0.6, 0.6, 0.6, 1.0},
+		TrackerWrite:     imgui.Vec4{0.10, 0.97, 0.29, 1.0},
+
 		// tia
 		IdxPointer: imgui.Vec4{0.8, 0.8, 0.8, 1.0},
 
@@ -247,6 +277,17 @@ func newColors() *imguiColors {
 		vec4PalettePAL = append(vec4PalettePAL, v)
 	}
 
+	vec4PaletteSECAM := make([]imgui.Vec4, 0, len(specification.PaletteSECAM))
+	for _, c := range specification.PaletteSECAM {
+		v := imgui.Vec4{
+			float32(c.R) / 255,
+			float32(c.G) / 255,
+			float32(c.B) / 255,
+			1.0,
+		}
+		vec4PaletteSECAM = append(vec4PaletteSECAM, v)
+	}
+
 	vec4PaletteAlt := make([]imgui.Vec4, 0, len(reflection.PaletteElements))
 	for _, c := range reflection.PaletteElements {
 		v := imgui.Vec4{
@@ -269,6 +310,11 @@ func newColors() *imguiColors {
 		cols.packedPalettePAL = append(cols.packedPalettePAL, imgui.PackedColorFromVec4(c))
 	}
 
+	cols.packedPaletteSECAM = make(packedPalette, 0, len(vec4PaletteSECAM))
+	for _, c := range vec4PaletteSECAM {
+		cols.packedPaletteSECAM = append(cols.packedPaletteSECAM, imgui.PackedColorFromVec4(c))
+	}
+
 	cols.packedPaletteAlt = make(packedPalette, 0, len(vec4PaletteAlt))
 	for _, c := range vec4PaletteAlt {
 		cols.packedPaletteAlt = append(cols.packedPaletteAlt, imgui.PackedColorFromVec4(c))
@@ -197,10 +197,12 @@ func (img *SdlImgui) imguiWindowQuadrant(p imgui.Vec2) imgui.Vec2 {
 // use appropriate palette for television spec.
 func (img *SdlImgui) imguiTVPalette() (string, packedPalette) {
 	switch img.lz.TV.Spec.ID {
-	case "PAL":
+	case "PAL", "PAL60":
 		return "PAL", img.cols.packedPalettePAL
-	case "NTSC":
+	case "NTSC", "PAL-M", "PAL-N":
 		return "NTSC", img.cols.packedPaletteNTSC
+	case "SECAM":
+		return "SECAM", img.cols.packedPaletteSECAM
 	}
 
 	return "NTSC?", img.cols.packedPaletteNTSC
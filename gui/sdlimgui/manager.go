@@ -65,6 +65,7 @@ type manager struct {
 	dbgScr   *winDbgScr
 	crtPrefs *winCRTPrefs
 	playScr  *winPlayScr
+	xref     *winXref
 }
 
 func newManager(img *SdlImgui) (*manager, error) {
@@ -124,9 +125,15 @@ func newManager(img *SdlImgui) (*manager, error) {
 	if err := addWindow(newWinDisasm, true, windowMenuVCS); err != nil {
 		return nil, err
 	}
+	if err := addWindow(newWinXref, false, windowMenuVCS); err != nil {
+		return nil, err
+	}
 	if err := addWindow(newWinAudio, true, windowMenuVCS); err != nil {
 		return nil, err
 	}
+	if err := addWindow(newWinTracker, false, windowMenuVCS); err != nil {
+		return nil, err
+	}
 	if err := addWindow(newWinDbgScr, true, windowMenuVCS); err != nil {
 		return nil, err
 	}
@@ -191,6 +198,7 @@ func newManager(img *SdlImgui) (*manager, error) {
 	// elsewhere in the system
 	wm.dbgScr = wm.windows[winDbgScrTitle].(*winDbgScr)
 	wm.crtPrefs = wm.windows[winCRTPrefsTitle].(*winCRTPrefs)
+	wm.xref = wm.windows[winXrefTitle].(*winXref)
 
 	// create play window. this is a very special window that never appears
 	// directly in an any menu
@@ -119,6 +119,12 @@ func (img *SdlImgui) serviceSetFeature(request featureRequest) {
 		if err == nil {
 			err = img.crtPrefs.Save()
 		}
+		if err == nil {
+			err = img.audio.Prefs.Save()
+		}
+		if err == nil {
+			err = img.audio.Resampling.Save()
+		}
 
 	case gui.ReqChangingCartridge:
 		// a new cartridge requires us to reset the lazy system (see the
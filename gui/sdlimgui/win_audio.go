@@ -17,6 +17,7 @@ package sdlimgui
 
 import (
 	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
 )
 
 const winAudioTitle = "Audio"
@@ -89,9 +90,9 @@ func (win *winAudio) draw() {
 }
 
 // SetAudio implements television.AudioMixer.
-func (win *winAudio) SetAudio(audioData uint8) error {
+func (win *winAudio) SetAudio(sig signal.SignalAttributes) error {
 	select {
-	case win.newData <- float32(audioData) / 256:
+	case win.newData <- float32(sig.AudioData) / 256:
 	default:
 	}
 	return nil
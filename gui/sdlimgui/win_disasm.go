@@ -17,11 +17,14 @@ package sdlimgui
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/jetsetilly/gopher2600/debugger"
 	"github.com/jetsetilly/gopher2600/disassembly"
 	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/symbols"
 
 	"github.com/inkyblackness/imgui-go/v2"
 )
@@ -37,6 +40,24 @@ type winDisasm struct {
 	showAllEntries bool
 	showByteCode   bool
 
+	// colour the background of executed entries according to how often they
+	// have been executed
+	showHeatmap bool
+
+	// show bytes that have been classified as data (see disassembly.Classify)
+	// and draw a bitmap for graphics data
+	showClasses bool
+
+	// interleave source lines with the disassembly. only possible if the
+	// symbols file for the cartridge provides source information
+	showSource bool
+
+	// input strings for the annotation context menu. annotateID identifies
+	// the entry the strings were initialised from
+	annotateID      string
+	annotateLabel   string
+	annotateComment string
+
 	// height of options line at bottom of window. valid after first frame
 	optionsHeight float32
 
@@ -191,6 +212,15 @@ func (win *winDisasm) draw() {
 	imgui.SameLine()
 	imgui.Checkbox("Show Bytecode", &win.showByteCode)
 
+	imgui.SameLine()
+	imgui.Checkbox("Heatmap", &win.showHeatmap)
+
+	imgui.SameLine()
+	imgui.Checkbox("Classes", &win.showClasses)
+
+	imgui.SameLine()
+	imgui.Checkbox("Source", &win.showSource)
+
 	imgui.SameLine()
 	if imgui.Button("Goto PC") {
 		win.alignOnPC = true
@@ -213,7 +243,14 @@ func (win *winDisasm) drawBank(pcaddr uint16, b int, selected bool, cpuStep bool
 	if win.showAllEntries {
 		lvl = disassembly.EntryLevelDecoded
 	}
-	bitr, err := win.img.lz.Dbg.Disasm.NewBankIteration(lvl, b)
+
+	var bitr *disassembly.IterateBank
+	var err error
+	if win.showClasses {
+		bitr, err = win.img.lz.Dbg.Disasm.NewBankIterationWithData(lvl, b)
+	} else {
+		bitr, err = win.img.lz.Dbg.Disasm.NewBankIteration(lvl, b)
+	}
 
 	// check that NewBankIteration has succeeded. if it hasn't it probably
 	// means the cart has changed in the middle of the draw routine. but that's
@@ -225,10 +262,37 @@ func (win *winDisasm) drawBank(pcaddr uint16, b int, selected bool, cpuStep bool
 	height := imguiRemainingWinHeight() - win.optionsHeight
 	imgui.BeginChildV(fmt.Sprintf("bank %d", b), imgui.Vec2{X: 0, Y: height}, false, 0)
 
+	// interleave source lines with the disassembly if requested. rows will
+	// be nil if there is no source information
+	var rows []disasmRow
+	if win.showSource {
+		rows = win.sourceRows(bitr)
+	}
+
 	// only draw elements that will be visible
 	var clipper imgui.ListClipper
-	clipper.Begin(bitr.EntryCount + bitr.LabelCount)
-	for clipper.Step() {
+	if rows != nil {
+		clipper.Begin(len(rows))
+		for clipper.Step() {
+			for i := clipper.DisplayStart; i < clipper.DisplayEnd; i++ {
+				r := rows[i]
+				if i == clipper.DisplayStart {
+					win.addressTopList = r.e.Result.Address
+				}
+				switch {
+				case r.src != nil:
+					win.drawSource(r.src)
+				case r.label:
+					win.drawLabel(r.e)
+				default:
+					win.drawEntry(r.e, pcaddr, selected, cpuStep, bitr.MaxExecutionCount)
+				}
+			}
+		}
+	} else {
+		clipper.Begin(bitr.EntryCount + bitr.LabelCount)
+	}
+	for rows == nil && clipper.Step() {
 		_, _ = bitr.Start()
 		_, e := bitr.SkipNext(clipper.DisplayStart, true)
 
@@ -252,7 +316,7 @@ func (win *winDisasm) drawBank(pcaddr uint16, b int, selected bool, cpuStep bool
 
 			// if address value of current disasm entry and current PC value
 			// match then highlight the entry
-			win.drawEntry(e, pcaddr, selected, cpuStep)
+			win.drawEntry(e, pcaddr, selected, cpuStep, bitr.MaxExecutionCount)
 
 			_, e = bitr.Next()
 			if e == nil {
@@ -291,7 +355,13 @@ func (win *winDisasm) drawBank(pcaddr uint16, b int, selected bool, cpuStep bool
 		// walk through disassembly and note the count for the current entry
 		hlEntry := float32(0.0)
 		i := float32(0.0)
-		for _, e := bitr.Start(); e != nil; _, e = bitr.Next() {
+		for n, r := range rows {
+			if r.src == nil && !r.label && r.e.Result.Address&memorymap.CartridgeBits == addr&memorymap.CartridgeBits {
+				hlEntry = float32(n)
+				break // for loop
+			}
+		}
+		for _, e := bitr.Start(); rows == nil && e != nil; _, e = bitr.Next() {
 			if e.Result.Address&memorymap.CartridgeBits == addr&memorymap.CartridgeBits {
 				hlEntry = i
 				break // for loop
@@ -318,6 +388,65 @@ func (win *winDisasm) drawBank(pcaddr uint16, b int, selected bool, cpuStep bool
 	imgui.EndChild()
 }
 
+// disasmRow is a single row in the disassembly listing when source lines are
+// interleaved with the disassembly.
+type disasmRow struct {
+	e *disassembly.Entry
+
+	// the row is a source line if src is not nil
+	src *symbols.SourceLine
+
+	// the row is the label for the entry
+	label bool
+}
+
+// prepare the rows for a disassembly listing with interleaved source lines. a
+// source line is shown before the first entry that belongs to it. returns nil
+// if there is no source information.
+//
+// the entire bank is iterated over so this is more expensive than drawing
+// the disassembly without source lines.
+func (win *winDisasm) sourceRows(bitr *disassembly.IterateBank) []disasmRow {
+	sym := win.img.lz.Dbg.Disasm.Symbols
+	if sym == nil || sym.Source == nil {
+		return nil
+	}
+
+	rows := make([]disasmRow, 0, bitr.EntryCount+bitr.LabelCount)
+
+	var prev *symbols.SourceLine
+	for _, e := bitr.Start(); e != nil; _, e = bitr.Next() {
+		if ln, ok := sym.Source.Lookup(e.Result.Address, entryBytecode(e)); ok && ln != prev {
+			rows = append(rows, disasmRow{e: e, src: ln})
+			prev = ln
+		}
+		if e.Label.String() != "" {
+			rows = append(rows, disasmRow{e: e, label: true})
+		}
+		rows = append(rows, disasmRow{e: e})
+	}
+
+	return rows
+}
+
+// the bytes of the instruction in the entry.
+func entryBytecode(e *disassembly.Entry) []uint8 {
+	if e.Result.Defn == nil {
+		return nil
+	}
+	b := []uint8{e.Result.Defn.OpCode, uint8(e.Result.InstructionData), uint8(e.Result.InstructionData >> 8)}
+	if e.Result.Defn.Bytes < len(b) {
+		b = b[:e.Result.Defn.Bytes]
+	}
+	return b
+}
+
+func (win *winDisasm) drawSource(ln *symbols.SourceLine) {
+	imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.DisasmSource)
+	imgui.Text(fmt.Sprintf("%s  %s", ln, strings.TrimSpace(strings.ReplaceAll(ln.Text, "\t", " "))))
+	imgui.PopStyleColor()
+}
+
 func (win *winDisasm) drawLabel(e *disassembly.Entry) bool {
 	s := e.GetField(disassembly.FldLabel)
 	if s == "" {
@@ -328,10 +457,17 @@ func (win *winDisasm) drawLabel(e *disassembly.Entry) bool {
 }
 
 // drawEntry() is called many times from drawBank(), once for each entry in the list.
-func (win *winDisasm) drawEntry(e *disassembly.Entry, pcaddr uint16, selected bool, cpuStep bool) {
+//
+// the maxCount argument is the highest execution count in the bank and is used
+// to scale the heatmap.
+func (win *winDisasm) drawEntry(e *disassembly.Entry, pcaddr uint16, selected bool, cpuStep bool, maxCount int) {
 	imgui.BeginGroup()
 	adj := imgui.Vec4{0.0, 0.0, 0.0, 0.0}
 
+	if win.showHeatmap && e.ExecutionCount > 0 {
+		win.drawHeat(e.ExecutionCount, maxCount)
+	}
+
 	// highlight current disassembly entry
 	if win.showAllEntries && e.Level < disassembly.EntryLevelBlessed {
 		adj = imgui.Vec4{0.0, 0.0, 0.0, -0.4}
@@ -367,6 +503,13 @@ func (win *winDisasm) drawEntry(e *disassembly.Entry, pcaddr uint16, selected bo
 	s := e.GetField(disassembly.FldAddress)
 	imgui.Text(s)
 
+	if win.showClasses && e.IsData() {
+		imgui.PopStyleColor()
+		win.drawData(e, adj)
+		imgui.EndGroup()
+		return
+	}
+
 	if win.showByteCode {
 		imgui.SameLine()
 		imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.DisasmByteCode.Plus(adj))
@@ -400,16 +543,20 @@ func (win *winDisasm) drawEntry(e *disassembly.Entry, pcaddr uint16, selected bo
 		imgui.PopStyleColor()
 	}
 
+	if c := e.Comment(); c != "" {
+		imgui.SameLine()
+		imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.DisasmComment.Plus(adj))
+		imgui.Text(fmt.Sprintf("; %s", c))
+		imgui.PopStyleColor()
+	}
+
 	imgui.EndGroup()
 
 	// the following Is*() conditions apply to the whole group
 
-	// on right mouse button, set interactive to false. if emulation is not
-	// running, it will be true for only one (imgui) frame but that is enough
-	// to cause the scroller to centre on the current entry.
-	if imgui.IsItemHoveredV(imgui.HoveredFlagsAllowWhenDisabled) && imgui.IsMouseDown(1) {
-		win.alignOnPC = true
-	}
+	// right mouse button opens the annotation menu for the entry. use the
+	// "Goto PC" button to centre on the current entry
+	win.drawAnnotationMenu(e)
 
 	// single click toggles a PC breakpoint on the entries address
 	if imgui.IsItemClicked() {
@@ -417,6 +564,131 @@ func (win *winDisasm) drawEntry(e *disassembly.Entry, pcaddr uint16, selected bo
 	}
 }
 
+// context menu for adding a user label and comment to the entry. changes are
+// made when the enter key is pressed.
+func (win *winDisasm) drawAnnotationMenu(e *disassembly.Entry) {
+	id := fmt.Sprintf("annotate%d%04x", e.Bank.Number, e.Result.Address)
+	if !imgui.BeginPopupContextItemV(id, 1) {
+		return
+	}
+	defer imgui.EndPopup()
+
+	// initialise input strings when the menu is first opened
+	if win.annotateID != id {
+		win.annotateID = id
+		win.annotateLabel = e.Label.String()
+		win.annotateComment = e.Comment()
+	}
+
+	bank := e.Bank.Number
+	address := e.Result.Address
+
+	imgui.Text(fmt.Sprintf("%s (bank %d)", e.Address, bank))
+	imgui.Separator()
+
+	imgui.AlignTextToFramePadding()
+	imgui.Text("Label  ")
+	imgui.SameLine()
+	if imguiTextInput("##label", true, 32, &win.annotateLabel, true) {
+		label := strings.TrimSpace(win.annotateLabel)
+		win.img.lz.Dbg.PushRawEvent(func() {
+			if err := win.img.lz.Dbg.Disasm.Symbols.SetLabel(bank, address, label); err != nil {
+				logger.Log("disasm", err.Error())
+			}
+		})
+		imgui.CloseCurrentPopup()
+		win.annotateID = ""
+	}
+
+	imgui.AlignTextToFramePadding()
+	imgui.Text("Comment")
+	imgui.SameLine()
+	if imguiTextInput("##comment", true, 64, &win.annotateComment, true) {
+		comment := win.annotateComment
+		win.img.lz.Dbg.PushRawEvent(func() {
+			if err := win.img.lz.Dbg.Disasm.Symbols.SetComment(bank, address, comment); err != nil {
+				logger.Log("disasm", err.Error())
+			}
+		})
+		imgui.CloseCurrentPopup()
+		win.annotateID = ""
+	}
+
+	imgui.Separator()
+	if imgui.Selectable("Cross references") {
+		win.img.wm.xref.showAddress(address)
+		win.annotateID = ""
+	}
+}
+
+// draw an entry that has been classified as data. graphics data is drawn with
+// a bitmap preview.
+func (win *winDisasm) drawData(e *disassembly.Entry, adj imgui.Vec4) {
+	if e.Result.Defn == nil {
+		return
+	}
+	v := e.Result.Defn.OpCode
+
+	col := win.img.cols.DisasmData
+	if e.Class&disassembly.ClassGFX == disassembly.ClassGFX {
+		col = win.img.cols.DisasmGFX
+	}
+
+	imgui.SameLine()
+	imgui.PushStyleColor(imgui.StyleColorText, col.Plus(adj))
+	imgui.Text(fmt.Sprintf(".byte $%02x", v))
+	imgui.SameLine()
+	imgui.Text(e.Class.String())
+	imgui.PopStyleColor()
+
+	if e.Class&disassembly.ClassGFX != disassembly.ClassGFX {
+		return
+	}
+
+	// one square for each bit in the byte. the most significant bit is on
+	// the left
+	imgui.SameLine()
+	sz := imgui.FontSize()
+	p := imgui.CursorScreenPos()
+	dl := imgui.WindowDrawList()
+	on := imgui.PackedColorFromVec4(col)
+	off := imgui.PackedColorFromVec4(col.Times(0.25))
+	for i := 0; i < 8; i++ {
+		p1 := imgui.Vec2{X: p.X + float32(i)*sz, Y: p.Y}
+		p2 := imgui.Vec2{X: p1.X + sz - 1, Y: p1.Y + sz - 1}
+		if v&(0x80>>i) != 0 {
+			dl.AddRectFilled(p1, p2, on)
+		} else {
+			dl.AddRectFilled(p1, p2, off)
+		}
+	}
+	imgui.Dummy(imgui.Vec2{X: sz * 8, Y: sz})
+}
+
+// heat is scaled logarithmically. a linear scale would leave all but the
+// very hottest entries looking cold.
+func (win *winDisasm) drawHeat(count int, maxCount int) {
+	heat := float32(1.0)
+	if maxCount > 1 {
+		heat = float32(math.Log(float64(count)) / math.Log(float64(maxCount)))
+	}
+
+	cold := win.img.cols.DisasmHeatCold
+	hot := win.img.cols.DisasmHeatHot
+	col := imgui.Vec4{
+		X: cold.X + (hot.X-cold.X)*heat,
+		Y: cold.Y + (hot.Y-cold.Y)*heat,
+		Z: cold.Z + (hot.Z-cold.Z)*heat,
+		W: cold.W + (hot.W-cold.W)*heat,
+	}
+
+	p1 := imgui.CursorScreenPos()
+	p2 := p1
+	p2.X += imgui.WindowWidth()
+	p2.Y += imgui.FontSize() * 1.1
+	imgui.WindowDrawList().AddRectFilled(p1, p2, imgui.PackedColorFromVec4(col))
+}
+
 func (win *winDisasm) drawBreak(e *disassembly.Entry) {
 	switch win.img.lz.Breakpoints.HasBreak(e) {
 	case debugger.BrkPCAddress:
@@ -20,6 +20,7 @@ import (
 
 	"github.com/inkyblackness/imgui-go/v2"
 	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/resampler"
 )
 
 const winPrefsTile = "Preferences"
@@ -76,6 +77,14 @@ func (win *winPrefs) draw() {
 	imgui.Separator()
 	imgui.Spacing()
 
+	imgui.Text("Audio")
+	imgui.Spacing()
+	win.drawAudio()
+
+	imgui.Spacing()
+	imgui.Separator()
+	imgui.Spacing()
+
 	win.drawDiskButtons()
 
 	imgui.End()
@@ -104,6 +113,39 @@ func (win *winPrefs) drawRewind() {
 	imguiIndentText("rewind controls to feel sluggish.")
 }
 
+func (win *winPrefs) drawAudio() {
+	b := win.img.audio.Prefs.Stereo.Get().(bool)
+	if imgui.Checkbox("Stereo##stereo", &b) {
+		win.img.audio.Prefs.Stereo.Set(b)
+	}
+
+	f := float32(win.img.audio.Prefs.Separation.Get().(float64))
+	if imgui.SliderFloatV("Separation##separation", &f, 0.0, 1.0, "%.2f", 1.0) {
+		win.img.audio.Prefs.Separation.Set(f)
+	}
+
+	imgui.Spacing()
+
+	b = win.img.audio.Resampling.LowPass.Get().(bool)
+	if imgui.Checkbox("Low-Pass Filter##lowpass", &b) {
+		win.img.audio.Resampling.LowPass.Set(b)
+	}
+
+	r := win.img.audio.Resampling.Rate()
+	if imgui.BeginCombo("Sample Rate##samplerate", fmt.Sprintf("%dHz", r)) {
+		for _, s := range resampler.SampleRates {
+			if imgui.Selectable(fmt.Sprintf("%dHz", s)) {
+				win.img.audio.Resampling.SampleRate.Set(s)
+			}
+		}
+		imgui.EndCombo()
+	}
+
+	imgui.Spacing()
+	imguiIndentText("Changes to the sample rate will take")
+	imguiIndentText("effect the next time Gopher2600 starts.")
+}
+
 func (win *winPrefs) drawGeneral() {
 	if imgui.Checkbox("Random State (on startup)", &win.img.lz.Prefs.RandomState) {
 		win.img.term.pushCommand("PREFS TOGGLE RANDSTART")
@@ -141,6 +183,13 @@ func (win *winPrefs) drawDiskButtons() {
 		if err != nil {
 			logger.Log("sdlimgui", fmt.Sprintf("could not save preferences: %v", err))
 		}
+		err = win.img.audio.Prefs.Save()
+		if err == nil {
+			err = win.img.audio.Resampling.Save()
+		}
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not save audio preferences: %v", err))
+		}
 		win.img.term.pushCommand("PREFS SAVE")
 	}
 
@@ -150,6 +199,13 @@ func (win *winPrefs) drawDiskButtons() {
 		if err != nil {
 			logger.Log("sdlimgui", fmt.Sprintf("could not restore preferences: %v", err))
 		}
+		err = win.img.audio.Prefs.Load()
+		if err == nil {
+			err = win.img.audio.Resampling.Load()
+		}
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not restore audio preferences: %v", err))
+		}
 		win.img.term.pushCommand("PREFS LOAD")
 	}
 }
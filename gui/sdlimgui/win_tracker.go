@@ -0,0 +1,172 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/tracker"
+
+	"github.com/inkyblackness/imgui-go/v2"
+)
+
+const winTrackerTitle = "Audio Tracker"
+
+type winTracker struct {
+	img  *SdlImgui
+	open bool
+
+	// copy of the tracker entries. copying the entries can be expensive so we
+	// only do it when the tracker has changed
+	entries []tracker.Entry
+
+	// scroll to the most recent entry when the entries change
+	follow bool
+}
+
+func newWinTracker(img *SdlImgui) (window, error) {
+	win := &winTracker{
+		img:    img,
+		follow: true,
+	}
+
+	return win, nil
+}
+
+func (win *winTracker) init() {
+}
+
+func (win *winTracker) destroy() {
+}
+
+func (win *winTracker) id() string {
+	return winTrackerTitle
+}
+
+func (win *winTracker) isOpen() bool {
+	return win.open
+}
+
+func (win *winTracker) setOpen(open bool) {
+	win.open = open
+}
+
+// update the copy of the tracker entries if the tracker has changed. returns
+// true if the entries have been updated.
+func (win *winTracker) update() bool {
+	tr := win.img.lz.Dbg.Tracker
+
+	// comparing the length and the most recent entry is enough to know if
+	// the tracker has changed. rewinding the emulation will remove entries
+	// from the tracker but they are replaced as the emulation catches up
+	recent := tr.Recent(1)
+	if tr.Len() == len(win.entries) {
+		if len(recent) == 0 || recent[0] == win.entries[len(win.entries)-1] {
+			return false
+		}
+	}
+
+	win.entries = tr.Copy()
+	return true
+}
+
+func (win *winTracker) draw() {
+	if !win.open {
+		return
+	}
+
+	// the tracker is part of the debugger
+	if win.img.lz.Dbg == nil {
+		return
+	}
+
+	imgui.SetNextWindowPosV(imgui.Vec2{648, 540}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.SetNextWindowSizeV(imgui.Vec2{360, 300}, imgui.ConditionFirstUseEver)
+	imgui.BeginV(winTrackerTitle, &win.open, 0)
+
+	updated := win.update()
+
+	if imgui.Button("Clear") {
+		win.img.term.pushCommand("TRACKER CLEAR")
+	}
+	imgui.SameLine()
+	if imgui.Button("Save CSV") {
+		win.img.term.pushCommand("TRACKER SAVE CSV")
+	}
+	imgui.SameLine()
+	imgui.Checkbox("Follow", &win.follow)
+
+	imgui.Separator()
+
+	// column headings
+	imgui.Text("Frame Line  ")
+	imgui.SameLine()
+	imgui.Text("C  F  V  ")
+	imgui.SameLine()
+	imgui.Text("C  F  V")
+
+	imgui.Separator()
+
+	imgui.BeginChild("tracker")
+
+	var clipper imgui.ListClipper
+	clipper.Begin(len(win.entries))
+	for clipper.Step() {
+		for i := clipper.DisplayStart; i < clipper.DisplayEnd; i++ {
+			win.drawEntry(win.entries[i])
+		}
+	}
+
+	if updated && win.follow {
+		imgui.SetScrollHereY(1.0)
+	}
+
+	imgui.EndChild()
+
+	imgui.End()
+}
+
+// draw a single tracker entry as a row in the pattern. the register that was
+// written to is highlighted.
+func (win *winTracker) drawEntry(e tracker.Entry) {
+	imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.TrackerTimestamp)
+	imgui.Text(fmt.Sprintf("%05d %03d  ", e.Frame, e.Scanline))
+	imgui.PopStyleColor()
+
+	win.drawRegisters(e, 0, "AUDC0", "AUDF0", "AUDV0")
+	imgui.SameLine()
+	imgui.Text(" ")
+	win.drawRegisters(e, 1, "AUDC1", "AUDF1", "AUDV1")
+}
+
+func (win *winTracker) drawRegisters(e tracker.Entry, channel int, control, freq, volume string) {
+	reg := e.Channel[channel]
+
+	field := func(name string, v uint8) {
+		imgui.SameLine()
+		if e.Register == name {
+			imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.TrackerWrite)
+			imgui.Text(fmt.Sprintf("%02x", v))
+			imgui.PopStyleColor()
+		} else {
+			imgui.Text(fmt.Sprintf("%02x", v))
+		}
+	}
+
+	field(control, reg.Control)
+	field(freq, reg.Freq)
+	field(volume, reg.Volume)
+}
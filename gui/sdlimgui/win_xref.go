@@ -0,0 +1,141 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jetsetilly/gopher2600/disassembly"
+
+	"github.com/inkyblackness/imgui-go/v2"
+)
+
+const winXrefTitle = "Cross References"
+
+type winXref struct {
+	img  *SdlImgui
+	open bool
+
+	// the address being referred to as entered by the user
+	addressInput string
+
+	// references to the address. finding references requires a search of the
+	// entire disassembly so the list is only updated when the address changes
+	// or when the refresh button is pressed
+	address uint16
+	refs    []disassembly.Xref
+}
+
+func newWinXref(img *SdlImgui) (window, error) {
+	win := &winXref{
+		img: img,
+	}
+
+	return win, nil
+}
+
+func (win *winXref) init() {
+}
+
+func (win *winXref) destroy() {
+}
+
+func (win *winXref) id() string {
+	return winXrefTitle
+}
+
+func (win *winXref) isOpen() bool {
+	return win.open
+}
+
+func (win *winXref) setOpen(open bool) {
+	win.open = open
+}
+
+// show the references to the address and open the window.
+func (win *winXref) showAddress(address uint16) {
+	win.address = address
+	win.addressInput = fmt.Sprintf("%04x", address)
+	win.refs = win.img.lz.Dbg.Disasm.Xrefs(address)
+	win.open = true
+}
+
+func (win *winXref) draw() {
+	if !win.open {
+		return
+	}
+
+	imgui.SetNextWindowPosV(imgui.Vec2{905, 720}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.SetNextWindowSizeV(imgui.Vec2{353, 200}, imgui.ConditionFirstUseEver)
+	imgui.BeginV(winXrefTitle, &win.open, 0)
+
+	imguiText("Address")
+	if imguiHexInput("##xrefaddress", true, 4, &win.addressInput) {
+		if v, err := strconv.ParseUint(win.addressInput, 16, 16); err == nil {
+			win.showAddress(uint16(v))
+		}
+	}
+
+	imgui.SameLine()
+	if imgui.Button("Refresh") {
+		win.showAddress(win.address)
+	}
+
+	imgui.SameLine()
+	runtime := win.img.lz.Dbg.Disasm.IsObservingXrefs()
+	if imgui.Checkbox("Runtime", &runtime) {
+		win.img.lz.Dbg.PushRawEvent(func() { win.img.lz.Dbg.Disasm.SetXrefObservation(runtime) })
+	}
+
+	imgui.Separator()
+
+	if len(win.refs) == 0 {
+		imgui.Text(fmt.Sprintf("no references to $%04x", win.address))
+		imgui.End()
+		return
+	}
+
+	imgui.BeginChild("xrefs")
+	for _, x := range win.refs {
+		imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.DisasmAddress)
+		imgui.Text(fmt.Sprintf("%d $%04x", x.Bank, x.Address))
+		imgui.PopStyleColor()
+
+		if x.Entry != nil {
+			imgui.SameLine()
+			imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.DisasmMnemonic)
+			imgui.Text(x.Entry.GetField(disassembly.FldMnemonic))
+			imgui.PopStyleColor()
+			imgui.SameLine()
+			imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.DisasmOperand)
+			imgui.Text(x.Entry.GetField(disassembly.FldOperand))
+			imgui.PopStyleColor()
+		}
+
+		imgui.SameLine()
+		imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.DisasmNotes)
+		if x.Runtime {
+			imgui.Text(fmt.Sprintf("%s (runtime)", x.Kind))
+		} else {
+			imgui.Text(x.Kind.String())
+		}
+		imgui.PopStyleColor()
+	}
+	imgui.EndChild()
+
+	imgui.End()
+}
@@ -15,7 +15,10 @@
 
 package instructions
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // AddressingMode describes the method data for the instruction should be received.
 type AddressingMode int
@@ -83,3 +86,10 @@ func (defn Definition) String() string {
 func (defn Definition) IsBranch() bool {
 	return defn.AddressingMode == Relative && defn.Effect == Flow
 }
+
+// IsUndocumented returns true if instruction is not part of the documented
+// 6502 instruction set. By convention, undocumented instructions have lower
+// case mnemonics.
+func (defn Definition) IsUndocumented() bool {
+	return defn.Mnemonic != "" && strings.ToLower(defn.Mnemonic) == defn.Mnemonic
+}
@@ -40,6 +40,11 @@ type Cartridge struct {
 	Filename string
 	Hash     string
 
+	// copy of the cartridge data as it was loaded. the mapper might not
+	// expose all of the file through CopyBanks() so this is kept for binary
+	// patches, which describe the file and not the banks
+	fileData []uint8
+
 	// the specific cartridge data, mapped appropriately to the memory
 	// interfaces
 	mapper mapper.CartMapper
@@ -128,9 +133,16 @@ func (cart *Cartridge) Write(addr uint16, data uint8) error {
 func (cart *Cartridge) Eject() {
 	cart.Filename = "ejected"
 	cart.Hash = ""
+	cart.fileData = nil
 	cart.mapper = newEjected()
 }
 
+// FileData returns the cartridge data as it was loaded. Offsets into the data
+// are the offsets used by Patch(). The returned slice should not be altered.
+func (cart *Cartridge) FileData() []uint8 {
+	return cart.fileData
+}
+
 // IsEjected returns true if no cartridge is attached.
 func (cart *Cartridge) IsEjected() bool {
 	_, ok := cart.mapper.(*ejected)
@@ -152,6 +164,8 @@ func (cart *Cartridge) Attach(cartload cartridgeloader.Loader) error {
 
 	cart.Filename = cartload.Filename
 	cart.Hash = cartload.Hash
+	cart.fileData = make([]uint8, len(cartload.Data))
+	copy(cart.fileData, cartload.Data)
 	cart.mapper = newEjected()
 
 	// fingerprint cartridgeloader.Loader
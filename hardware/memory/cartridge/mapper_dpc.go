@@ -299,8 +299,8 @@ func (cart *dpc) Patch(offset int, data uint8) error {
 	}
 
 	staticStart := cart.NumBanks() * cart.bankSize
-	if staticStart >= cart.NumBanks()*cart.bankSize {
-		cart.static[offset] = data
+	if offset >= staticStart {
+		cart.static[offset-staticStart] = data
 	} else {
 		bank := offset / cart.bankSize
 		offset %= cart.bankSize
@@ -110,7 +110,11 @@ type FrameTrigger interface {
 // example of an AudioMixer that does not play sound but otherwise works with
 // it is the digest.Audio type.
 type AudioMixer interface {
-	SetAudio(audioData uint8) error
+	// SetAudio is called whenever the AudioUpdate field of the signal is true.
+	// The AudioData field is the mix of both audio channels. Mixers that want
+	// to treat the channels separately should use the AudioChannel0 and
+	// AudioChannel1 fields.
+	SetAudio(sig signal.SignalAttributes) error
 
 	// some mixers may need to conclude and/or dispose of resources gently.
 	// for simplicity, the AudioMixer should be considered unusable after
@@ -34,6 +34,11 @@ type SignalAttributes struct {
 	Pixel     ColorSignal
 	AudioData uint8
 
+	// the volume of each of the two audio channels. AudioData is the mix of
+	// these two values
+	AudioChannel0 uint8
+	AudioChannel1 uint8
+
 	// whether the AudioData is valid. should be true only every 114th clock,
 	// which equates to 30Khz
 	AudioUpdate bool
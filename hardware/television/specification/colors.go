@@ -23,6 +23,9 @@ var PaletteNTSC = []color.RGBA{}
 // PalettePAL is the collection of PAL colours.
 var PalettePAL = []color.RGBA{}
 
+// PaletteSECAM is the collection of SECAM colours.
+var PaletteSECAM = []color.RGBA{}
+
 // VideoBlack is the color produced by a television in the absence of a color
 // signal.
 var videoBlack = color.RGBA{0, 0, 0, 255}
@@ -69,6 +72,12 @@ var pal32bit = []uint32{
 	0x000000, 0x282828, 0x505050, 0x747474, 0x949494, 0xb4b4b4, 0xd0d0d0, 0xececec,
 }
 
+// the SECAM TIA ignores the hue bits of the color signal. the luminance bits
+// select one of eight colors.
+var secam32bit = []uint32{
+	0x000000, 0x2121ff, 0xf03c79, 0xff50ff, 0x7fff00, 0x7fffff, 0xffff3f, 0xffffff,
+}
+
 // convert the "raw" color values to the RGB components.
 func init() {
 	for _, col := range ntsc32bit {
@@ -86,4 +95,15 @@ func init() {
 		PalettePAL = append(PalettePAL, color.RGBA{red, green, blue, 255})
 		PalettePAL = append(PalettePAL, color.RGBA{red, green, blue, 255})
 	}
+
+	// the same eight SECAM colors for every hue
+	for hue := 0; hue < 16; hue++ {
+		for _, col := range secam32bit {
+			red, green, blue := byte((col&0xff0000)>>16), byte((col&0xff00)>>8), byte(col&0xff)
+
+			// repeat color twice in palette
+			PaletteSECAM = append(PaletteSECAM, color.RGBA{red, green, blue, 255})
+			PaletteSECAM = append(PaletteSECAM, color.RGBA{red, green, blue, 255})
+		}
+	}
 }
@@ -0,0 +1,217 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+package specification
+
+import (
+	"fmt"
+	"image/color"
+	"io/ioutil"
+	"math"
+	"sync/atomic"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// the palettes in colors.go are all one of three families.
+type paletteFamily int
+
+const (
+	familyNTSC paletteFamily = iota
+	familyPAL
+	familySECAM
+)
+
+// the number of entries in a palette. the 2600 has 128 colors but the palettes
+// in this package have every color repeated so that they can be indexed with
+// the color signal directly.
+const (
+	numColors      = 128
+	numColorsSECAM = 8
+	paletteLen     = numColors * 2
+)
+
+// the custom palette for a specification. the palette can be changed while
+// the emulation is running so access is via an atomic value.
+type customPalette struct {
+	v atomic.Value
+}
+
+// wrapper for the palette stored in the atomic value. atomic.Value can't store
+// a nil value.
+type customPaletteValue struct {
+	pal []color.RGBA
+}
+
+func (c *customPalette) get() []color.RGBA {
+	if c == nil {
+		return nil
+	}
+	if v, ok := c.v.Load().(customPaletteValue); ok {
+		return v.pal
+	}
+	return nil
+}
+
+// SetPalette replaces the palette used by GetColor(). The palette should have
+// either 128 entries, one for each 2600 color, or 256 entries in which case it
+// is indexed by the color signal directly. A nil palette restores the Colors
+// palette.
+//
+// The palette is changed for every copy of the specification.
+func (spec *Spec) SetPalette(pal []color.RGBA) error {
+	if spec.custom == nil {
+		return curated.Errorf("palette: cannot set palette for %s", spec.ID)
+	}
+
+	switch len(pal) {
+	case 0:
+		pal = nil
+	case numColors:
+		pal = expandPalette(pal)
+	case paletteLen:
+	default:
+		return curated.Errorf("palette: wrong number of colors for %s (%d)", spec.ID, len(pal))
+	}
+
+	spec.custom.v.Store(customPaletteValue{pal: pal})
+
+	return nil
+}
+
+// repeat every color twice. see colors.go.
+func expandPalette(pal []color.RGBA) []color.RGBA {
+	e := make([]color.RGBA, 0, len(pal)*2)
+	for _, c := range pal {
+		e = append(e, c, c)
+	}
+	return e
+}
+
+// the size of Stella palette files. a single palette is 128 RGB triplets. a
+// complete Stella palette file is the NTSC, PAL and SECAM palettes in that
+// order. the SECAM palette has only eight colors.
+const (
+	stellaSingleLen   = numColors * 3
+	stellaCompleteLen = stellaSingleLen*2 + numColorsSECAM*3
+)
+
+// LoadPalette reads a palette file in the Stella .pal format. The file may
+// contain one palette of 128 colors or, in the case of a complete Stella
+// palette file, the NTSC, PAL and SECAM palettes one after the other. For
+// complete palette files, the palette for the family of the specification
+// is returned.
+//
+// The returned palette can be used with SetPalette().
+func LoadPalette(filename string, spec Spec) ([]color.RGBA, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, curated.Errorf("palette: %v", err)
+	}
+
+	toRGBA := func(d []uint8) []color.RGBA {
+		pal := make([]color.RGBA, 0, len(d)/3)
+		for i := 0; i+2 < len(d); i += 3 {
+			pal = append(pal, color.RGBA{R: d[i], G: d[i+1], B: d[i+2], A: 255})
+		}
+		return pal
+	}
+
+	switch len(data) {
+	case stellaSingleLen:
+		return expandPalette(toRGBA(data)), nil
+	case stellaCompleteLen:
+		switch spec.family {
+		case familyNTSC:
+			return expandPalette(toRGBA(data[:stellaSingleLen])), nil
+		case familyPAL:
+			return expandPalette(toRGBA(data[stellaSingleLen : stellaSingleLen*2])), nil
+		case familySECAM:
+			secam := toRGBA(data[stellaSingleLen*2:])
+			pal := make([]color.RGBA, 0, numColors)
+			for hue := 0; hue < numColors/numColorsSECAM; hue++ {
+				pal = append(pal, secam...)
+			}
+			return expandPalette(pal), nil
+		}
+	}
+
+	return nil, curated.Errorf("palette: %v", fmt.Errorf("not a Stella palette file (%s)", filename))
+}
+
+// PaletteAdjustment describes how ParametricPalette() changes a palette.
+type PaletteAdjustment struct {
+	// rotation of the hue in degrees
+	Hue float64
+
+	// multiplier for the color component of each color. zero is greyscale
+	Saturation float64
+
+	// multiplier for the distance of the luminance from mid-grey
+	Contrast float64
+
+	// added to the luminance. in the range -1 to 1
+	Brightness float64
+
+	// gamma correction applied to the final RGB values. one is no correction
+	Gamma float64
+}
+
+// DefaultAdjustment leaves a palette unchanged.
+var DefaultAdjustment = PaletteAdjustment{
+	Saturation: 1.0,
+	Contrast:   1.0,
+	Gamma:      1.0,
+}
+
+// ParametricPalette generates a new palette by adjusting the Colors palette of
+// the specification. The adjustment is made in the YIQ color space.
+func ParametricPalette(spec Spec, adj PaletteAdjustment) []color.RGBA {
+	sin, cos := math.Sincos(adj.Hue * math.Pi / 180)
+
+	gamma := adj.Gamma
+	if gamma <= 0 {
+		gamma = 1.0
+	}
+
+	clamp := func(v float64) uint8 {
+		v = math.Max(0, math.Min(1, v))
+		return uint8(math.Round(math.Pow(v, 1/gamma) * 255))
+	}
+
+	pal := make([]color.RGBA, len(spec.Colors))
+	for i, c := range spec.Colors {
+		r := float64(c.R) / 255
+		g := float64(c.G) / 255
+		b := float64(c.B) / 255
+
+		y := 0.299*r + 0.587*g + 0.114*b
+		ci := 0.596*r - 0.274*g - 0.322*b
+		cq := 0.211*r - 0.523*g + 0.312*b
+
+		// hue rotation and saturation
+		ci, cq = (ci*cos-cq*sin)*adj.Saturation, (ci*sin+cq*cos)*adj.Saturation
+
+		// contrast and brightness
+		y = (y-0.5)*adj.Contrast + 0.5 + adj.Brightness
+
+		r = y + 0.956*ci + 0.621*cq
+		g = y - 0.272*ci - 0.647*cq
+		b = y - 1.106*ci + 1.703*cq
+
+		pal[i] = color.RGBA{R: clamp(r), G: clamp(g), B: clamp(b), A: 255}
+	}
+
+	return pal
+}
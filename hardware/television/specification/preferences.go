@@ -0,0 +1,224 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+package specification
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// Values for the palette preferences other than the filename of a palette
+// file.
+const (
+	// the palette in colors.go
+	PaletteCanonical = "canonical"
+
+	// the palette in colors.go adjusted by the parametric preferences
+	PaletteParametric = "parametric"
+)
+
+// Preferences for the television palettes. The palette for each specification
+// is chosen independently. Palettes are applied to the specifications
+// immediately.
+//
+// Note that the video digest always uses the canonical palette.
+type Preferences struct {
+	dsk *prefs.Disk
+
+	// the palette for each specification. the value is one of PaletteCanonical,
+	// PaletteParametric or the filename of a Stella palette file
+	NTSC  prefs.String
+	PAL   prefs.String
+	PAL60 prefs.String
+	PALM  prefs.String
+	PALN  prefs.String
+	SECAM prefs.String
+
+	// the adjustments made to the canonical palette when PaletteParametric is
+	// chosen. see PaletteAdjustment type
+	Hue        prefs.Float
+	Saturation prefs.Float
+	Contrast   prefs.Float
+	Brightness prefs.Float
+	Gamma      prefs.Float
+}
+
+func (p *Preferences) String() string {
+	return p.dsk.String()
+}
+
+// NewPreferences is the preferred method of initialisation for the Preferences
+// type.
+func NewPreferences() (*Preferences, error) {
+	p := &Preferences{}
+	p.SetDefaults()
+
+	pth, err := paths.ResourcePath("", prefs.DefaultPrefsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	p.dsk, err = prefs.NewDisk(pth)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []struct {
+		key string
+		p   interface {
+			RegisterCallback(f func(value prefs.Value) error)
+		}
+	}{
+		{"palette.ntsc", &p.NTSC},
+		{"palette.pal", &p.PAL},
+		{"palette.palSixty", &p.PAL60},
+		{"palette.palM", &p.PALM},
+		{"palette.palN", &p.PALN},
+		{"palette.secam", &p.SECAM},
+		{"palette.hue", &p.Hue},
+		{"palette.saturation", &p.Saturation},
+		{"palette.contrast", &p.Contrast},
+		{"palette.brightness", &p.Brightness},
+		{"palette.gamma", &p.Gamma},
+	}
+
+	for _, e := range entries {
+		switch v := e.p.(type) {
+		case *prefs.String:
+			err = p.dsk.Add(e.key, v)
+		case *prefs.Float:
+			err = p.dsk.Add(e.key, v)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = p.dsk.Load(true)
+	if err != nil {
+		return nil, err
+	}
+
+	// a missing palette file shouldn't prevent the television from being
+	// created so errors are logged and not returned
+	if err := p.Apply(); err != nil {
+		logger.Log("palette", err.Error())
+	}
+
+	// changes to the preferences from now on are applied immediately
+	for _, e := range entries {
+		e.p.RegisterCallback(func(_ prefs.Value) error {
+			return p.Apply()
+		})
+	}
+
+	return p, nil
+}
+
+// SetDefaults reverts all palette preferences to the default values.
+func (p *Preferences) SetDefaults() {
+	p.NTSC.Set(PaletteCanonical)
+	p.PAL.Set(PaletteCanonical)
+	p.PAL60.Set(PaletteCanonical)
+	p.PALM.Set(PaletteCanonical)
+	p.PALN.Set(PaletteCanonical)
+	p.SECAM.Set(PaletteCanonical)
+	p.Hue.Set(DefaultAdjustment.Hue)
+	p.Saturation.Set(DefaultAdjustment.Saturation)
+	p.Contrast.Set(DefaultAdjustment.Contrast)
+	p.Brightness.Set(DefaultAdjustment.Brightness)
+	p.Gamma.Set(DefaultAdjustment.Gamma)
+}
+
+// Load palette preferences from disk.
+func (p *Preferences) Load() error {
+	return p.dsk.Load(false)
+}
+
+// Save current palette preferences to disk.
+func (p *Preferences) Save() error {
+	return p.dsk.Save()
+}
+
+// Palette returns the palette preference for the specification. Returns nil if
+// the ID is not recognised.
+func (p *Preferences) Palette(id string) *prefs.String {
+	switch strings.ToUpper(id) {
+	case SpecNTSC.ID:
+		return &p.NTSC
+	case SpecPAL.ID:
+		return &p.PAL
+	case SpecPAL60.ID:
+		return &p.PAL60
+	case SpecPALM.ID:
+		return &p.PALM
+	case SpecPALN.ID:
+		return &p.PALN
+	case SpecSECAM.ID:
+		return &p.SECAM
+	}
+	return nil
+}
+
+// Apply the palette preferences to the specifications. A palette that can't be
+// applied doesn't prevent the palettes for the other specifications from being
+// applied. The returned error lists every palette that failed.
+func (p *Preferences) Apply() error {
+	adj := PaletteAdjustment{
+		Hue:        p.Hue.Get().(float64),
+		Saturation: p.Saturation.Get().(float64),
+		Contrast:   p.Contrast.Get().(float64),
+		Brightness: p.Brightness.Get().(float64),
+		Gamma:      p.Gamma.Get().(float64),
+	}
+
+	var errs []string
+
+	for _, spec := range []Spec{SpecNTSC, SpecPAL, SpecPAL60, SpecPALM, SpecPALN, SpecSECAM} {
+		spec := spec
+		v := p.Palette(spec.ID).Get().(string)
+
+		var err error
+
+		switch v {
+		case "", PaletteCanonical:
+			err = spec.SetPalette(nil)
+		case PaletteParametric:
+			err = spec.SetPalette(ParametricPalette(spec, adj))
+		default:
+			var pal []color.RGBA
+			pal, err = LoadPalette(v, spec)
+			if err == nil {
+				err = spec.SetPalette(pal)
+			}
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", spec.ID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return curated.Errorf("palette: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
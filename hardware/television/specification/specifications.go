@@ -13,20 +13,32 @@
 // You should have received a copy of the GNU General Public License
 // along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
 
-// Package specification contains the definitions, including colour, of the PAL
-// and NTSC television protocols supported by the emulation.
+// Package specification contains the definitions, including colour, of the
+// television protocols supported by the emulation.
+//
+// As well as NTSC and PAL, the following variations are supported:
+//
+//	PAL60: PAL colors at NTSC line counts
+//	PAL-M: NTSC colors at NTSC line counts (Brazil)
+//	PAL-N: NTSC colors at PAL line counts (Argentina, Paraguay, Uruguay)
+//	SECAM: eight colors selected by luminance, at PAL line counts
+//
+// The colour subcarrier of PAL-M and PAL-N televisions is very close to the
+// NTSC subcarrier, which is why consoles for those regions produce NTSC
+// colors.
 package specification
 
 import (
 	"image/color"
+	"strings"
 
 	"github.com/jetsetilly/gopher2600/hardware/television/signal"
 )
 
 // SpecList is the list of specifications that the television may adopt.
-var SpecList = []string{"NTSC", "PAL"}
+var SpecList = []string{"NTSC", "PAL", "PAL60", "PAL-M", "PAL-N", "SECAM"}
 
-// Spec is used to define the two television specifications.
+// Spec is used to define the television specifications.
 type Spec struct {
 	ID     string
 	Colors []color.RGBA
@@ -40,7 +52,7 @@ type Spec struct {
 	//
 	// the above figures are in reference to the NTSC protocol
 	ScanlinesVSync    int
-	scanlinesVBlank   int
+	ScanlinesVBlank   int
 	ScanlinesVisible  int
 	ScanlinesOverscan int
 
@@ -87,13 +99,36 @@ type Spec struct {
 	// if the generated image is exactly ScanlinesTotal in height then how many
 	// pixels would that be. used for frame rate measurement.
 	IdealPixelsPerFrame int
+
+	// the family of the Colors palette. used to choose the correct part of a
+	// palette file
+	family paletteFamily
+
+	// a palette that replaces the Colors palette in GetColor(). the pointer is
+	// shared by every copy of the Spec. see SetPalette()
+	custom *customPalette
 }
 
-// GetColor translates a signals to the color type.
+// GetColor translates a signals to the color type. The palette set with
+// SetPalette() is used in preference to the Colors palette.
 func (spec *Spec) GetColor(col signal.ColorSignal) color.RGBA {
 	// we're usng the ColorSignal to index an array so we need to be extra
 	// careful to make sure the value is valid. if it's not a valid index then
 	// assume the intention was video black
+	if col == signal.VideoBlack {
+		return videoBlack
+	}
+	if pal := spec.custom.get(); pal != nil {
+		return pal[col]
+	}
+	return spec.Colors[col]
+}
+
+// GetCanonicalColor translates a signal to the color type using the Colors
+// palette, regardless of any palette set with SetPalette(). Should be used when
+// the color must be the same on every installation, for example in the video
+// digest.
+func (spec *Spec) GetCanonicalColor(col signal.ColorSignal) color.RGBA {
 	if col == signal.VideoBlack {
 		return videoBlack
 	}
@@ -122,12 +157,38 @@ var SpecNTSC Spec
 // SpecPAL is the specification for PAL television types.
 var SpecPAL Spec
 
+// SpecPAL60 is the specification for PAL televisions receiving a 60Hz
+// signal.
+var SpecPAL60 Spec
+
+// SpecPALM is the specification for PAL-M television types.
+var SpecPALM Spec
+
+// SpecPALN is the specification for PAL-N television types.
+var SpecPALN Spec
+
+// SpecSECAM is the specification for SECAM television types.
+var SpecSECAM Spec
+
+// SearchSpec returns the specification with the ID. The search is case
+// insensitive. The bool return value is false if there is no specification
+// with the ID.
+func SearchSpec(id string) (Spec, bool) {
+	for _, spec := range []Spec{SpecNTSC, SpecPAL, SpecPAL60, SpecPALM, SpecPALN, SpecSECAM} {
+		if strings.EqualFold(spec.ID, id) {
+			return spec, true
+		}
+	}
+	return Spec{}, false
+}
+
 func init() {
 	SpecNTSC = Spec{
 		ID:                "NTSC",
 		Colors:            PaletteNTSC,
+		family:            familyNTSC,
 		ScanlinesVSync:    3,
-		scanlinesVBlank:   37,
+		ScanlinesVBlank:   37,
 		ScanlinesVisible:  192,
 		ScanlinesOverscan: 30,
 		ScanlinesTotal:    262,
@@ -135,15 +196,16 @@ func init() {
 		AspectBias:        0.91,
 	}
 
-	SpecNTSC.AtariSafeTop = SpecNTSC.scanlinesVBlank + SpecNTSC.ScanlinesVSync
+	SpecNTSC.AtariSafeTop = SpecNTSC.ScanlinesVBlank + SpecNTSC.ScanlinesVSync
 	SpecNTSC.AtariSafeBottom = SpecNTSC.ScanlinesTotal - SpecNTSC.ScanlinesOverscan
 	SpecNTSC.IdealPixelsPerFrame = SpecNTSC.ScanlinesTotal * HorizClksScanline
 
 	SpecPAL = Spec{
 		ID:                "PAL",
 		Colors:            PalettePAL,
+		family:            familyPAL,
 		ScanlinesVSync:    3,
-		scanlinesVBlank:   45,
+		ScanlinesVBlank:   45,
 		ScanlinesVisible:  228,
 		ScanlinesOverscan: 36,
 		ScanlinesTotal:    312,
@@ -151,7 +213,7 @@ func init() {
 		AspectBias:        1.09,
 	}
 
-	SpecPAL.AtariSafeTop = SpecPAL.scanlinesVBlank + SpecPAL.ScanlinesVSync
+	SpecPAL.AtariSafeTop = SpecPAL.ScanlinesVBlank + SpecPAL.ScanlinesVSync
 	SpecPAL.AtariSafeBottom = SpecPAL.ScanlinesTotal - SpecPAL.ScanlinesOverscan
 	SpecPAL.IdealPixelsPerFrame = SpecPAL.ScanlinesTotal * HorizClksScanline
 
@@ -162,4 +224,31 @@ func init() {
 	SpecNTSC.NewSafeBottom = 249
 	SpecPAL.NewSafeTop = 20
 	SpecPAL.NewSafeBottom = 299
+
+	// the variations differ from NTSC or PAL only in the palette
+	SpecPAL60 = SpecNTSC
+	SpecPAL60.ID = "PAL60"
+	SpecPAL60.Colors = PalettePAL
+	SpecPAL60.family = familyPAL
+
+	SpecPALM = SpecNTSC
+	SpecPALM.ID = "PAL-M"
+
+	SpecPALN = SpecPAL
+	SpecPALN.ID = "PAL-N"
+	SpecPALN.Colors = PaletteNTSC
+	SpecPALN.family = familyNTSC
+
+	SpecSECAM = SpecPAL
+	SpecSECAM.ID = "SECAM"
+	SpecSECAM.Colors = PaletteSECAM
+	SpecSECAM.family = familySECAM
+
+	// every specification has its own custom palette
+	SpecNTSC.custom = &customPalette{}
+	SpecPAL.custom = &customPalette{}
+	SpecPAL60.custom = &customPalette{}
+	SpecPALM.custom = &customPalette{}
+	SpecPALN.custom = &customPalette{}
+	SpecSECAM.custom = &customPalette{}
 }
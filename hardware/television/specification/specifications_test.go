@@ -0,0 +1,199 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+package specification_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+func TestSearchSpec(t *testing.T) {
+	for _, id := range specification.SpecList {
+		spec, ok := specification.SearchSpec(id)
+		if !ok || spec.ID != id {
+			t.Errorf("expected to find specification (%s)", id)
+		}
+		if len(spec.Colors) != len(specification.PaletteNTSC) {
+			t.Errorf("incomplete palette for specification (%s)", id)
+		}
+	}
+
+	if spec, ok := specification.SearchSpec("pal60"); !ok || spec.ScanlinesTotal != specification.SpecNTSC.ScanlinesTotal {
+		t.Errorf("expected PAL60 to have NTSC line counts")
+	}
+
+	if _, ok := specification.SearchSpec("AUTO"); ok {
+		t.Errorf("unexpected specification for AUTO")
+	}
+}
+
+func TestSECAMPalette(t *testing.T) {
+	// the SECAM colour depends only on the luminance bits
+	for col := signal.ColorSignal(0); col < 256; col += 2 {
+		a := specification.SpecSECAM.GetColor(col)
+		b := specification.SpecSECAM.GetColor(col & 0x0e)
+		if a != b {
+			t.Errorf("unexpected SECAM color for %#02x", col)
+		}
+	}
+}
+
+func TestParametricPalette(t *testing.T) {
+	diff := func(a, b uint8) int {
+		if a > b {
+			return int(a - b)
+		}
+		return int(b - a)
+	}
+
+	// the default adjustment should leave the palette (very nearly) unchanged
+	pal := specification.ParametricPalette(specification.SpecNTSC, specification.DefaultAdjustment)
+	for i, c := range pal {
+		o := specification.SpecNTSC.Colors[i]
+		if diff(c.R, o.R) > 2 || diff(c.G, o.G) > 2 || diff(c.B, o.B) > 2 {
+			t.Errorf("unexpected change to color %d (%v -> %v)", i, o, c)
+		}
+	}
+
+	// zero saturation is greyscale
+	adj := specification.DefaultAdjustment
+	adj.Saturation = 0
+	for i, c := range specification.ParametricPalette(specification.SpecPAL, adj) {
+		if diff(c.R, c.G) > 1 || diff(c.G, c.B) > 1 {
+			t.Errorf("expected grey for color %d (%v)", i, c)
+		}
+	}
+}
+
+func TestLoadPalette(t *testing.T) {
+	dir, err := ioutil.TempDir("", "palette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// single palette of 128 colors. each color is the index repeated
+	single := make([]uint8, 128*3)
+	for i := range single {
+		single[i] = uint8(i / 3)
+	}
+	fn := filepath.Join(dir, "single.pal")
+	if err := ioutil.WriteFile(fn, single, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pal, err := specification.LoadPalette(fn, specification.SpecNTSC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pal) != 256 {
+		t.Fatalf("unexpected palette length (%d)", len(pal))
+	}
+	if pal[0x0e].R != 0x07 || pal[0x0f].R != 0x07 {
+		t.Errorf("unexpected color for 0x0e (%v)", pal[0x0e])
+	}
+
+	// set palette changes GetColor() but not GetCanonicalColor()
+	spec := specification.SpecNTSC
+	if err := spec.SetPalette(pal); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = spec.SetPalette(nil) }()
+
+	if c := spec.GetColor(0x0e); c != pal[0x0e] {
+		t.Errorf("GetColor() not using custom palette (%v)", c)
+	}
+	if c := spec.GetCanonicalColor(0x0e); c != specification.SpecNTSC.Colors[0x0e] {
+		t.Errorf("GetCanonicalColor() using custom palette (%v)", c)
+	}
+
+	// complete Stella palette file. the PAL palette is the second in the file
+	complete := make([]uint8, 128*3*2+8*3)
+	for i := 128 * 3; i < 128*3*2; i++ {
+		complete[i] = 0xff
+	}
+	fn = filepath.Join(dir, "complete.pal")
+	if err := ioutil.WriteFile(fn, complete, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pal, err = specification.LoadPalette(fn, specification.SpecPAL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pal[0].R != 0xff {
+		t.Errorf("expected PAL palette from complete palette file")
+	}
+
+	// wrong size
+	if err := ioutil.WriteFile(fn, single[:10], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := specification.LoadPalette(fn, specification.SpecNTSC); err == nil {
+		t.Errorf("expected error for short palette file")
+	}
+}
+
+func TestPreferencesApply(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopher2600_palette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	single := make([]uint8, 128*3)
+	for i := range single {
+		single[i] = 0x42
+	}
+	fn := filepath.Join(dir, "single.pal")
+	if err := ioutil.WriteFile(fn, single, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// a missing palette file for NTSC and SECAM shouldn't prevent the palette
+	// being applied to the specifications in between
+	p := &specification.Preferences{}
+	p.SetDefaults()
+	p.NTSC.Set(filepath.Join(dir, "missing.pal"))
+	p.PAL.Set(fn)
+	p.SECAM.Set(filepath.Join(dir, "missing.pal"))
+
+	pal := specification.SpecPAL
+	defer func() {
+		p.SetDefaults()
+		_ = p.Apply()
+	}()
+
+	err = p.Apply()
+	if err == nil {
+		t.Fatalf("expected an error for the missing palette files")
+	}
+	if !strings.Contains(err.Error(), "NTSC") || !strings.Contains(err.Error(), "SECAM") {
+		t.Errorf("error should mention every failed specification (%v)", err)
+	}
+	if strings.Contains(err.Error(), "PAL:") {
+		t.Errorf("error shouldn't mention PAL (%v)", err)
+	}
+
+	if c := pal.GetColor(0x0e); c.R != 0x42 {
+		t.Errorf("PAL palette not applied (%v)", c)
+	}
+}
@@ -109,6 +109,9 @@ type Television struct {
 	// test recreation etc.
 	reqSpecID string
 
+	// palette preferences. palettes apply to every television
+	PalettePrefs *specification.Preferences
+
 	// framerate limiter
 	lmtr limiter
 
@@ -152,6 +155,11 @@ func NewTelevision(spec string) (*Television, error) {
 		return nil, err
 	}
 
+	tv.PalettePrefs, err = specification.NewPreferences()
+	if err != nil {
+		return nil, err
+	}
+
 	// empty list of renderers
 	tv.renderers = make([]PixelRenderer, 0)
 
@@ -258,7 +266,7 @@ func (tv *Television) Signal(sig signal.SignalAttributes) error {
 	// mix audio before we do anything else
 	if sig.AudioUpdate {
 		for _, m := range tv.mixers {
-			err := m.SetAudio(sig.AudioData)
+			err := m.SetAudio(sig)
 			if err != nil {
 				return err
 			}
@@ -394,7 +402,10 @@ func (tv *Television) newFrame(synced bool) error {
 	// specification change
 	if tv.state.syncedFrameNum > leadingFrames && tv.state.syncedFrameNum < stabilityThreshold {
 		if tv.state.auto && !tv.state.syncedFrame && tv.state.scanline > excessScanlinesNTSC {
-			// flip from NTSC to PAL
+			// flip from NTSC to PAL. the other specifications differ from
+			// NTSC and PAL only in the palette, which can't be detected from
+			// the signal, so auto-detection only ever chooses between NTSC
+			// and PAL
 			if tv.state.spec.ID == specification.SpecNTSC.ID {
 				_ = tv.SetSpec("PAL")
 			}
@@ -487,17 +498,16 @@ func (tv *Television) GetState(request signal.StateReq) int {
 // Set the television's specification.
 func (tv *Television) SetSpec(spec string) error {
 	switch strings.ToUpper(spec) {
-	case "NTSC":
-		tv.state.spec = specification.SpecNTSC
-		tv.state.auto = false
-	case "PAL":
-		tv.state.spec = specification.SpecPAL
-		tv.state.auto = false
 	case "AUTO":
 		tv.state.spec = specification.SpecNTSC
 		tv.state.auto = true
 	default:
-		return curated.Errorf("television: unsupported spec (%s)", spec)
+		s, ok := specification.SearchSpec(spec)
+		if !ok {
+			return curated.Errorf("television: unsupported spec (%s)", spec)
+		}
+		tv.state.spec = s
+		tv.state.auto = false
 	}
 
 	tv.state.top = tv.state.spec.AtariSafeTop
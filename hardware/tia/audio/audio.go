@@ -44,6 +44,9 @@ type Audio struct {
 	// completely independent and can be operated simultaneously [...]"
 	channel0 channel
 	channel1 channel
+
+	// tracker is notified of every write to the audio registers. can be nil
+	tracker Tracker
 }
 
 // NewAudio is the preferred method of initialisation for the Audio sub-system.
@@ -68,7 +71,8 @@ func (au *Audio) String() string {
 
 // Mix the two VCS audio channels, returning a boolean indicating whether the
 // sound has been updated and a single value representing the mixed volume.
-func (au *Audio) Mix() (bool, uint8) {
+// The volumes of the two channels before mixing are also returned.
+func (au *Audio) Mix() (bool, uint8, uint8, uint8) {
 	// the reference frequency for all sound produced by the TIA is 30Khz. this
 	// is the 3.58Mhz clock, which the TIA operates at, divided by 114 (see
 	// declaration). Mix() is called every video cycle and we return
@@ -76,7 +80,7 @@ func (au *Audio) Mix() (bool, uint8) {
 	// audio registers and mix the two signals
 	au.clock114++
 	if au.clock114 < 115 {
-		return false, 0
+		return false, 0, 0, 0
 	}
 
 	// reset clock114
@@ -100,5 +104,7 @@ func (au *Audio) Mix() (bool, uint8) {
 	// https://atariage.com/forums/topic/249865-tia-sounding-off-in-the-digital-domain/
 	//
 	// !!TODO: simulate analogue sound generation
-	return true, (au.channel0.actualVol + au.channel1.actualVol) << 2
+	ch0 := au.channel0.actualVol << 2
+	ch1 := au.channel1.actualVol << 2
+	return true, ch0 + ch1, ch0, ch1
 }
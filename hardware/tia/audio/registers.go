@@ -15,7 +15,11 @@
 
 package audio
 
-import "github.com/jetsetilly/gopher2600/hardware/memory/bus"
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/bus"
+)
 
 // UpdateRegisters checks the TIA memory for changes to registers that are
 // interesting to the audio sub-system
@@ -42,6 +46,11 @@ func (au *Audio) UpdateRegisters(data bus.ChipData) bool {
 	au.channel0.reactAUDCx()
 	au.channel1.reactAUDCx()
 
+	if au.tracker != nil {
+		ch0, ch1 := au.Registers()
+		au.tracker.AudioEvent(data.Name, ch0, ch1)
+	}
+
 	return false
 }
 
@@ -78,3 +87,28 @@ func (ch *channel) reactAUDCx() {
 		// ...otherwide let it complete the previous
 	}
 }
+
+// Registers is the value of the three audio registers for a single channel.
+type Registers struct {
+	Control uint8
+	Freq    uint8
+	Volume  uint8
+}
+
+func (reg Registers) String() string {
+	return fmt.Sprintf("%04b @ %05b ^ %04b", reg.Control, reg.Freq, reg.Volume)
+}
+
+// Registers returns the current value of the audio registers for channel 0
+// and channel 1.
+func (au *Audio) Registers() (Registers, Registers) {
+	return Registers{
+		Control: au.channel0.regControl,
+		Freq:    au.channel0.regFreq,
+		Volume:  au.channel0.regVolume,
+	}, Registers{
+		Control: au.channel1.regControl,
+		Freq:    au.channel1.regFreq,
+		Volume:  au.channel1.regVolume,
+	}
+}
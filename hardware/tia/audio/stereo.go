@@ -0,0 +1,40 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package audio
+
+// Stereo mixes the volumes of the two audio channels into a left and right
+// value. The separation argument should be between 0.0 and 1.0. A separation
+// of zero means both channels are heard equally in both speakers, the same
+// as the mono mix. A separation of one means channel 0 is heard only in the
+// left speaker and channel 1 is heard only in the right speaker.
+//
+// The channel values are those sent to the television in the AudioChannel0
+// and AudioChannel1 fields of the signal.
+func Stereo(channel0 uint8, channel1 uint8, separation float32) (uint8, uint8) {
+	if separation < 0.0 {
+		separation = 0.0
+	} else if separation > 1.0 {
+		separation = 1.0
+	}
+
+	ch0 := float32(channel0)
+	ch1 := float32(channel1)
+
+	left := ch0*(1.0+separation) + ch1*(1.0-separation)
+	right := ch0*(1.0-separation) + ch1*(1.0+separation)
+
+	return uint8(left + 0.5), uint8(right + 0.5)
+}
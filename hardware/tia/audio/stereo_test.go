@@ -0,0 +1,90 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package audio_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/bus"
+	"github.com/jetsetilly/gopher2600/hardware/tia/audio"
+)
+
+// mix the output of the audio sub-system with both channels set to a constant
+// volume. returns the mono mix and the volume of each channel.
+func mix(t *testing.T, vol0 uint8, vol1 uint8) (uint8, uint8, uint8) {
+	t.Helper()
+
+	au := audio.NewAudio()
+	au.UpdateRegisters(bus.ChipData{Name: "AUDC0", Value: 0})
+	au.UpdateRegisters(bus.ChipData{Name: "AUDC1", Value: 0})
+	au.UpdateRegisters(bus.ChipData{Name: "AUDV0", Value: vol0})
+	au.UpdateRegisters(bus.ChipData{Name: "AUDV1", Value: vol1})
+
+	for i := 0; i < 200; i++ {
+		if ok, mono, ch0, ch1 := au.Mix(); ok {
+			return mono, ch0, ch1
+		}
+	}
+
+	t.Fatalf("audio was never mixed")
+	return 0, 0, 0
+}
+
+func TestStereoMono(t *testing.T) {
+	// with no separation both speakers are the same as the mono mix
+	for vol0 := uint8(0); vol0 < 16; vol0++ {
+		for vol1 := uint8(0); vol1 < 16; vol1++ {
+			mono, ch0, ch1 := mix(t, vol0, vol1)
+			left, right := audio.Stereo(ch0, ch1, 0.0)
+			if left != mono || right != mono {
+				t.Errorf("AUDV0=%d AUDV1=%d: stereo (%d, %d) is not the same as mono (%d)", vol0, vol1, left, right, mono)
+			}
+		}
+	}
+}
+
+func TestStereo(t *testing.T) {
+	tests := []struct {
+		ch0        uint8
+		ch1        uint8
+		separation float32
+		left       uint8
+		right      uint8
+	}{
+		// no separation
+		{ch0: 20, ch1: 40, separation: 0.0, left: 60, right: 60},
+
+		// full separation puts each channel in one speaker only
+		{ch0: 60, ch1: 0, separation: 1.0, left: 120, right: 0},
+		{ch0: 0, ch1: 60, separation: 1.0, left: 0, right: 120},
+		{ch0: 20, ch1: 40, separation: 1.0, left: 40, right: 80},
+
+		// partial separation
+		{ch0: 20, ch1: 40, separation: 0.5, left: 50, right: 70},
+
+		// out of range separation values are clamped
+		{ch0: 20, ch1: 40, separation: -1.0, left: 60, right: 60},
+		{ch0: 20, ch1: 40, separation: 2.0, left: 40, right: 80},
+	}
+
+	for _, tt := range tests {
+		left, right := audio.Stereo(tt.ch0, tt.ch1, tt.separation)
+		if left != tt.left || right != tt.right {
+			t.Errorf("Stereo(%d, %d, %.1f): unexpected result (%d, %d), wanted (%d, %d)",
+				tt.ch0, tt.ch1, tt.separation, left, right, tt.left, tt.right)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package audio
+
+// Tracker implementations display or otherwise record the use of the audio
+// registers.
+type Tracker interface {
+	// AudioEvent is called whenever an audio register is written to. The name
+	// of the register (eg. AUDC0) is given along with the value of the
+	// registers for each channel after the write.
+	AudioEvent(register string, channel0 Registers, channel1 Registers)
+}
+
+// SetTracker attaches a Tracker to the audio sub-system. A value of nil
+// removes any tracker that was previously attached.
+//
+// The tracker is copied by the Snapshot() function, so a tracker that is
+// attached before any snapshots are taken will survive rewinding.
+func (au *Audio) SetTracker(tracker Tracker) {
+	au.tracker = tracker
+}
@@ -512,7 +512,7 @@ func (tia *TIA) Step(readMemory bool) (bool, error) {
 	}
 
 	// copy audio to television signal
-	tia.sig.AudioUpdate, tia.sig.AudioData = tia.Audio.Mix()
+	tia.sig.AudioUpdate, tia.sig.AudioData, tia.sig.AudioChannel0, tia.sig.AudioChannel1 = tia.Audio.Mix()
 
 	// send signal to television
 	if err := tia.tv.Signal(tia.sig); err != nil {
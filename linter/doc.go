@@ -14,5 +14,13 @@
 // along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
 
 // Package linter analyses disassembled code (from the disassembly package)
-// producing a lint report. As it is, it is a proof-of-concept and incomplete.
+// producing a lint report.
+//
+// Each lint rule has an ID and a Severity and is listed in the Rules slice.
+// Individual rules can be enabled or disabled through the Preferences type.
+//
+// The Runtime type meanwhile, analyses the running emulation for timing
+// problems that cannot be detected by looking at the disassembly alone. For
+// example, frames that have the wrong number of scanlines for the television
+// specification.
 package linter
@@ -23,52 +23,383 @@ import (
 	"github.com/jetsetilly/gopher2600/disassembly"
 	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
 	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
 	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/symbols"
 )
 
-// Lint the disassembly of the load ROM. Currently, the function looks for read
-// instructions that target non-addressible TIA and RIOT addresses.
-func Lint(dsm *disassembly.Disassembly, output io.Writer) error {
-	// look at every bank in the disassembly
+// Cartridge defines the cartridge functions required by the Lint() function.
+type Cartridge interface {
+	Peek(addr uint16) (uint8, error)
+	GetCartHotspots() mapper.CartHotspotsBus
+	GetRAMbus() mapper.CartRAMbus
+}
+
+// Issue is a single problem found by the Lint() function.
+type Issue struct {
+	Rule     string
+	Severity Severity
+
+	// the bank and address of the instruction and the nearest label
+	Bank    int
+	Address uint16
+	Symbol  string
+
+	Detail string
+}
+
+func (is Issue) String() string {
+	sym := ""
+	if is.Symbol != "" {
+		sym = fmt.Sprintf(" (%s)", is.Symbol)
+	}
+	return fmt.Sprintf("%-7s %-19s bank=%d pc=%#04x%s: %s",
+		is.Severity, is.Rule, is.Bank, is.Address, sym, is.Detail)
+}
+
+// Check the disassembly of the loaded ROM against every enabled rule. The
+// cartridge is used to decide which writes to cartridge space are legitimate.
+// The preferences argument can be nil, in which case every rule is checked.
+func Check(dsm *disassembly.Disassembly, cart Cartridge, prefs *Preferences) ([]Issue, error) {
+	st := newStatic(cart, dsm.Symbols, prefs)
+
+	// gather blessed entries for every bank in the disassembly
+	banks := make(map[int][]*disassembly.Entry)
+
 	citr := dsm.NewCartIteration()
-	citr.Start()
 	for b, ok := citr.Start(); ok; b, ok = citr.Next() {
 		// create a new iteration for the bank
 		bitr, err := dsm.NewBankIteration(disassembly.EntryLevelBlessed, b)
 		if err != nil {
-			return curated.Errorf("linter: %v", err)
+			return nil, curated.Errorf("linter: %v", err)
 		}
 
-		// iterate through disassembled bank
 		for _, e := bitr.Start(); e != nil; _, e = bitr.Next() {
-			// if instruction has a read opcode, and the addressing mode seems
-			// to be reading from non-read addresses in TIA or RIOT space then
-			// create a lint warning
-
-			if e.Result.Defn.Effect == instructions.Read {
-				if e.Result.Defn.AddressingMode == instructions.Absolute ||
-					e.Result.Defn.AddressingMode == instructions.ZeroPage {
-					ma, area := memorymap.MapAddress(e.Result.InstructionData, true)
-
-					switch area {
-					case memorymap.TIA:
-						_, isRead := addresses.TIAReadSymbols[ma]
-						if !isRead {
-							s := fmt.Sprintf("%#04x\tread TIA address [%#04x (%#04x)]\n", e.Result.Address, e.Result.InstructionData, ma)
-							output.Write([]byte(s))
-						}
-
-					case memorymap.RIOT:
-						_, isRead := addresses.RIOTReadSymbols[ma]
-						if !isRead {
-							s := fmt.Sprintf("%#04x\tread RIOT address [%#04x (%#04x)]\n", e.Result.Address, e.Result.InstructionData, ma)
-							output.Write([]byte(s))
-						}
-					}
+			banks[b] = append(banks[b], e)
+		}
+	}
+
+	// targets must be collated for every bank before any bank is checked
+	for _, entries := range banks {
+		st.collate(entries)
+	}
+
+	citr = dsm.NewCartIteration()
+	for b, ok := citr.Start(); ok; b, ok = citr.Next() {
+		st.check(b, banks[b])
+	}
+
+	return st.issues, nil
+}
+
+// Lint the disassembly of the loaded ROM, writing every issue found to
+// output. See Check() for details.
+func Lint(dsm *disassembly.Disassembly, cart Cartridge, prefs *Preferences, output io.Writer) error {
+	issues, err := Check(dsm, cart, prefs)
+	if err != nil {
+		return err
+	}
+
+	for _, is := range issues {
+		output.Write([]byte(is.String()))
+		output.Write([]byte("\n"))
+	}
+
+	return nil
+}
+
+// static checks a list of entries against the static rules.
+type static struct {
+	cart  Cartridge
+	sym   *symbols.Symbols
+	prefs *Preferences
+
+	// cartridge addresses (masked with memorymap.CartridgeBits) that are the
+	// target of a blessed JMP, JSR or branch instruction, or of an interrupt
+	// vector. bank information is discarded because JMP and JSR instructions
+	// can cross banks
+	targets map[uint16]bool
+
+	// the address pointed to by the reset vector. stack usage is measured
+	// from this address
+	reset      uint16
+	resetValid bool
+
+	issues []Issue
+}
+
+func newStatic(cart Cartridge, sym *symbols.Symbols, prefs *Preferences) *static {
+	st := &static{
+		cart:    cart,
+		sym:     sym,
+		prefs:   prefs,
+		targets: make(map[uint16]bool),
+	}
+
+	// the reset and interrupt vectors are targets
+	if cart != nil {
+		for _, v := range []uint16{addresses.Reset, addresses.IRQ} {
+			lo, err := cart.Peek(v)
+			if err != nil {
+				continue
+			}
+			hi, err := cart.Peek(v + 1)
+			if err != nil {
+				continue
+			}
+			address := uint16(hi)<<8 | uint16(lo)
+			st.addTarget(address)
+
+			if v == addresses.Reset {
+				st.reset = address
+				st.resetValid = true
+			}
+		}
+	}
+
+	return st
+}
+
+func (st *static) addTarget(address uint16) {
+	st.targets[address&memorymap.CartridgeBits] = true
+}
+
+// collate the targets of every flow control instruction in the list of
+// entries.
+func (st *static) collate(entries []*disassembly.Entry) {
+	for _, e := range entries {
+		defn := e.Result.Defn
+		if defn == nil {
+			continue
+		}
+
+		switch {
+		case defn.IsBranch():
+			st.addTarget(branchDestination(e.Result.Address, e.Result.InstructionData))
+		case defn.Mnemonic == "JSR":
+			st.addTarget(e.Result.InstructionData)
+		case defn.Mnemonic == "JMP" && defn.AddressingMode == instructions.Absolute:
+			st.addTarget(e.Result.InstructionData)
+		}
+	}
+}
+
+// check every entry in the list of entries. the list should be in address
+// order and contain only the blessed entries of a single bank.
+func (st *static) check(bank int, entries []*disassembly.Entry) {
+	var prev *disassembly.Entry
+
+	for _, e := range entries {
+		if e.Result.Defn == nil {
+			prev = nil
+			continue
+		}
+
+		// the previous entry is only useful if it immediately precedes this
+		// entry
+		if prev != nil && prev.Result.Address+uint16(prev.Result.Defn.Bytes) != e.Result.Address {
+			prev = nil
+		}
+
+		st.unreadable(bank, e)
+		st.undocumented(bank, e)
+		st.jmpIndirect(bank, e)
+		st.romWrite(bank, e)
+		st.unreachable(bank, e, prev)
+		st.branchPageCross(bank, e)
+
+		prev = e
+	}
+
+	st.stackTIA(bank, entries)
+}
+
+// measure the stack usage of the paths from the reset vector. the bank
+// containing the reset code isn't known statically so every bank with a
+// blessed entry at the reset address is walked.
+func (st *static) stackTIA(bank int, entries []*disassembly.Entry) {
+	if !st.resetValid || !st.prefs.Enabled(RuleStackTIA) {
+		return
+	}
+
+	stk := newStack(st, bank, entries)
+	if stk.entry(st.reset) == nil {
+		return
+	}
+	stk.walk(st.reset)
+}
+
+func (st *static) add(rule string, bank int, address uint16, detail string) {
+	if !st.prefs.Enabled(rule) {
+		return
+	}
+
+	r, _ := LookupRule(rule)
+
+	is := Issue{
+		Rule:     rule,
+		Severity: r.Severity,
+		Bank:     bank,
+		Address:  address,
+		Detail:   detail,
+	}
+
+	if st.sym != nil {
+		is.Symbol, _, _ = st.sym.NearestLabel(address)
+	}
+
+	st.issues = append(st.issues, is)
+}
+
+// read instructions that target non-addressable TIA and RIOT addresses.
+func (st *static) unreadable(bank int, e *disassembly.Entry) {
+	defn := e.Result.Defn
+	if defn.Effect != instructions.Read {
+		return
+	}
+	if defn.AddressingMode != instructions.Absolute && defn.AddressingMode != instructions.ZeroPage {
+		return
+	}
+
+	ma, area := memorymap.MapAddress(e.Result.InstructionData, true)
+
+	switch area {
+	case memorymap.TIA:
+		if _, ok := addresses.TIAReadSymbols[ma]; !ok {
+			st.add(RuleUnreadable, bank, e.Result.Address,
+				fmt.Sprintf("read TIA address [%#04x (%#04x)]", e.Result.InstructionData, ma))
+		}
+	case memorymap.RIOT:
+		if _, ok := addresses.RIOTReadSymbols[ma]; !ok {
+			st.add(RuleUnreadable, bank, e.Result.Address,
+				fmt.Sprintf("read RIOT address [%#04x (%#04x)]", e.Result.InstructionData, ma))
+		}
+	}
+}
+
+func (st *static) undocumented(bank int, e *disassembly.Entry) {
+	defn := e.Result.Defn
+	if defn.IsUndocumented() {
+		st.add(RuleUndocumented, bank, e.Result.Address,
+			fmt.Sprintf("undocumented opcode %#02x (%s)", defn.OpCode, defn.Mnemonic))
+	}
+}
+
+// the 6502 does not carry into the high byte when reading the high byte of an
+// indirect JMP vector. a vector at $xxFF will use the byte at $xx00 as the
+// high byte rather than the byte at the start of the next page.
+func (st *static) jmpIndirect(bank int, e *disassembly.Entry) {
+	defn := e.Result.Defn
+	if defn.Mnemonic != "JMP" || defn.AddressingMode != instructions.Indirect {
+		return
+	}
+
+	v := e.Result.InstructionData
+	if v&0x00ff == 0x00ff {
+		st.add(RuleJMPIndirect, bank, e.Result.Address,
+			fmt.Sprintf("JMP (%#04x) reads high byte of vector from %#04x and not %#04x", v, v&0xff00, v+1))
+	}
+}
+
+// writes to cartridge space are only meaningful if the address is a hotspot
+// or is in cartridge RAM.
+func (st *static) romWrite(bank int, e *disassembly.Entry) {
+	defn := e.Result.Defn
+	if defn.Effect != instructions.Write && defn.Effect != instructions.RMW {
+		return
+	}
+
+	switch defn.AddressingMode {
+	case instructions.Absolute:
+	case instructions.AbsoluteIndexedX:
+	case instructions.AbsoluteIndexedY:
+	default:
+		return
+	}
+
+	ma, area := memorymap.MapAddress(e.Result.InstructionData, false)
+	if area != memorymap.Cartridge {
+		return
+	}
+
+	if st.cart != nil {
+		// writing to a read hotspot will still trigger the hotspot
+		if hs := st.cart.GetCartHotspots(); hs != nil {
+			if _, ok := hs.WriteHotspots()[ma]; ok {
+				return
+			}
+			if _, ok := hs.ReadHotspots()[ma]; ok {
+				return
+			}
+		}
+
+		// the origin of cartridge RAM is not necessarily the write address. for
+		// example, the write address for superchip RAM is immediately before the
+		// read address. we therefore accept writes either side of the origin
+		if bus := st.cart.GetRAMbus(); bus != nil {
+			for _, r := range bus.GetRAM() {
+				origin := r.Origin&memorymap.CartridgeBits | memorymap.OriginCart
+				l := uint16(len(r.Data))
+				if ma >= origin-l && ma < origin+l {
+					return
 				}
 			}
 		}
 	}
 
-	return nil
+	st.add(RuleROMWrite, bank, e.Result.Address,
+		fmt.Sprintf("%s to cartridge address %#04x is not a hotspot or cartridge RAM", defn.Mnemonic, ma))
+}
+
+// an entry is unreachable if it immediately follows an unconditional change
+// of flow and is not the target of any other instruction. only the first
+// entry in a sequence of unreachable entries is reported.
+//
+// executed entries are obviously reachable. they may have been reached by an
+// RTS trick or a jump table, neither of which can be detected statically.
+func (st *static) unreachable(bank int, e *disassembly.Entry, prev *disassembly.Entry) {
+	if prev == nil || e.Level == disassembly.EntryLevelExecuted {
+		return
+	}
+
+	switch prev.Result.Defn.Mnemonic {
+	case "JMP", "RTS", "RTI":
+	default:
+		return
+	}
+
+	if st.targets[e.Result.Address&memorymap.CartridgeBits] {
+		return
+	}
+
+	st.add(RuleUnreachable, bank, e.Result.Address,
+		fmt.Sprintf("follows %s and is not the target of any JMP, JSR or branch", prev.Result.Defn.Mnemonic))
+}
+
+// a taken branch costs an extra cycle if the destination is on a different
+// page to the instruction following the branch. backwards branches are
+// likely to be loops where the extra cycle will be incurred many times.
+func (st *static) branchPageCross(bank int, e *disassembly.Entry) {
+	if !e.Result.Defn.IsBranch() {
+		return
+	}
+
+	next := e.Result.Address + 2
+	dest := branchDestination(e.Result.Address, e.Result.InstructionData)
+	if dest >= next {
+		return
+	}
+
+	if dest&0xff00 != next&0xff00 {
+		st.add(RuleBranchPageCross, bank, e.Result.Address,
+			fmt.Sprintf("%s to %#04x crosses page boundary. each iteration costs an extra cycle", e.Result.Defn.Mnemonic, dest))
+	}
+}
+
+// branchDestination returns the address a branch instruction at address
+// would jump to if the branch is taken. all 6502 branch instructions are two
+// bytes long and the operand is a signed offset from the following
+// instruction.
+func branchDestination(address uint16, operand uint16) uint16 {
+	return address + 2 + uint16(int16(int8(operand)))
 }
@@ -0,0 +1,256 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package linter
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// a cartridge with a single bank switching hotspot and superchip style RAM.
+type mockCart struct{}
+
+func (cart *mockCart) Peek(addr uint16) (uint8, error) {
+	// reset and interrupt vectors both point to $f000
+	if addr&0x01 == 0x01 {
+		return 0xf0, nil
+	}
+	return 0x00, nil
+}
+
+func (cart *mockCart) GetCartHotspots() mapper.CartHotspotsBus {
+	return cart
+}
+
+func (cart *mockCart) ReadHotspots() map[uint16]mapper.CartHotspotInfo {
+	return map[uint16]mapper.CartHotspotInfo{
+		0x1ff8: {Symbol: "BANK0", Action: mapper.HotspotBankSwitch},
+	}
+}
+
+func (cart *mockCart) WriteHotspots() map[uint16]mapper.CartHotspotInfo {
+	return cart.ReadHotspots()
+}
+
+func (cart *mockCart) GetRAMbus() mapper.CartRAMbus {
+	return cart
+}
+
+func (cart *mockCart) GetRAM() []mapper.CartRAM {
+	return []mapper.CartRAM{{Label: "Superchip", Origin: 0x1080, Data: make([]uint8, 128), Mapped: true}}
+}
+
+func (cart *mockCart) PutRAM(_ int, _ int, _ uint8) {
+}
+
+// assemble a sequence of instructions starting at $f000. each instruction
+// is an opcode and operand pair.
+func assemble(code [][2]uint16) []*disassembly.Entry {
+	defns := instructions.GetDefinitions()
+
+	entries := make([]*disassembly.Entry, 0, len(code))
+	address := uint16(0xf000)
+	for _, c := range code {
+		defn := defns[c[0]]
+		entries = append(entries, &disassembly.Entry{
+			Level: disassembly.EntryLevelBlessed,
+			Result: execution.Result{
+				Defn:            defn,
+				Address:         address,
+				InstructionData: c[1],
+				Final:           true,
+			},
+		})
+		address += uint16(defn.Bytes)
+	}
+
+	return entries
+}
+
+func check(prefs *Preferences, code [][2]uint16) map[string]int {
+	entries := assemble(code)
+	st := newStatic(&mockCart{}, nil, prefs)
+	st.collate(entries)
+	st.check(0, entries)
+
+	r := make(map[string]int)
+	for _, is := range st.issues {
+		r[is.Rule]++
+	}
+	return r
+}
+
+func TestStaticRules(t *testing.T) {
+	r := check(nil, [][2]uint16{
+		{0xa2, 0xff},   // LDX #$ff
+		{0x9a, 0x00},   // TXS
+		{0xa2, 0x1f},   // LDX #$1f
+		{0x9a, 0x00},   // TXS (deliberate so not stack-tia)
+		{0xad, 0x0280}, // LDA SWCHA
+		{0xad, 0x0296}, // LDA TIM64T (unreadable-address)
+		{0xa5, 0x0e},   // LDA $0e (unreadable-address)
+		{0xa7, 0x80},   // lax $80 (undocumented-opcode)
+		{0x8d, 0x1ff8}, // STA BANK0
+		{0x8d, 0x1000}, // STA superchip RAM
+		{0x8d, 0x1200}, // STA (rom-write)
+		{0xee, 0x1300}, // INC (rom-write)
+		{0x6c, 0x00ff}, // JMP ($00ff) (jmp-indirect-bug)
+		{0xea, 0x00},   // NOP (unreachable)
+		{0xea, 0x00},   // NOP
+		{0x4c, 0xf000}, // JMP $f000
+	})
+
+	expected := map[string]int{
+		RuleUnreadable:   2,
+		RuleUndocumented: 1,
+		RuleROMWrite:     2,
+		RuleJMPIndirect:  1,
+		RuleUnreachable:  1,
+	}
+
+	for rule, n := range expected {
+		if r[rule] != n {
+			t.Errorf("expected %d %s issues, got %d", n, rule, r[rule])
+		}
+	}
+	if len(r) != len(expected) {
+		t.Errorf("unexpected issues: %v", r)
+	}
+}
+
+func TestStaticBranches(t *testing.T) {
+	// padding so that the loop starts near the end of the page
+	code := make([][2]uint16, 0)
+	for i := 0; i < 0xfd; i++ {
+		code = append(code, [2]uint16{0xea, 0x00}) // NOP
+	}
+
+	// loop at $f0fd. the branch at $f0fe is followed by $f100
+	code = append(code, [2]uint16{0xca, 0x00})   // DEX
+	code = append(code, [2]uint16{0xd0, 0xfd})   // BNE $f0fd (branch-page-cross)
+	code = append(code, [2]uint16{0xca, 0x00})   // DEX
+	code = append(code, [2]uint16{0xd0, 0xfd})   // BNE $f100
+	code = append(code, [2]uint16{0x60, 0x00})   // RTS
+	code = append(code, [2]uint16{0xea, 0x00})   // NOP
+	code = append(code, [2]uint16{0xf0, 0xfd})   // BEQ $f104
+	code = append(code, [2]uint16{0x4c, 0xf000}) // JMP $f000
+
+	r := check(nil, code)
+	if r[RuleBranchPageCross] != 1 {
+		t.Errorf("expected 1 %s issue, got %d", RuleBranchPageCross, r[RuleBranchPageCross])
+	}
+	if r[RuleUnreachable] != 0 {
+		t.Errorf("expected 0 %s issues, got %d", RuleUnreachable, r[RuleUnreachable])
+	}
+}
+
+func TestStaticStack(t *testing.T) {
+	// nested subroutines that push the stack into TIA space
+	r := check(nil, [][2]uint16{
+		{0xa2, 0x85},   // f000 LDX #$85
+		{0x9a, 0x00},   // f002 TXS
+		{0x20, 0xf00d}, // f003 JSR $f00d (stack-tia)
+		{0xa2, 0x1f},   // f006 LDX #$1f
+		{0x9a, 0x00},   // f008 TXS
+		{0x08, 0x00},   // f009 PHP (deliberate so not stack-tia)
+		{0x4c, 0xf000}, // f00a JMP $f000
+		{0x48, 0x00},   // f00d PHA
+		{0x48, 0x00},   // f00e PHA
+		{0x20, 0xf015}, // f00f JSR $f015
+		{0x68, 0x00},   // f012 PLA
+		{0x68, 0x00},   // f013 PLA
+		{0x60, 0x00},   // f014 RTS
+		{0x48, 0x00},   // f015 PHA
+		{0x68, 0x00},   // f016 PLA
+		{0x60, 0x00},   // f017 RTS
+	})
+	if r[RuleStackTIA] != 1 {
+		t.Errorf("expected 1 %s issue, got %d", RuleStackTIA, r[RuleStackTIA])
+	}
+
+	// the same subroutines with the stack at the top of RAM
+	r = check(nil, [][2]uint16{
+		{0xa2, 0xff},   // f000 LDX #$ff
+		{0x9a, 0x00},   // f002 TXS
+		{0x20, 0xf00d}, // f003 JSR $f00d
+		{0xea, 0x00},   // f006 NOP
+		{0xea, 0x00},   // f007 NOP
+		{0xea, 0x00},   // f008 NOP
+		{0xea, 0x00},   // f009 NOP
+		{0x4c, 0xf000}, // f00a JMP $f000
+		{0x48, 0x00},   // f00d PHA
+		{0x48, 0x00},   // f00e PHA
+		{0x20, 0xf015}, // f00f JSR $f015
+		{0x68, 0x00},   // f012 PLA
+		{0x68, 0x00},   // f013 PLA
+		{0x60, 0x00},   // f014 RTS
+		{0x48, 0x00},   // f015 PHA
+		{0x68, 0x00},   // f016 PLA
+		{0x60, 0x00},   // f017 RTS
+	})
+	if r[RuleStackTIA] != 0 {
+		t.Errorf("expected 0 %s issues, got %d", RuleStackTIA, r[RuleStackTIA])
+	}
+
+	// pushing in a loop and a recursive subroutine
+	r = check(nil, [][2]uint16{
+		{0xa2, 0xff},   // f000 LDX #$ff
+		{0x9a, 0x00},   // f002 TXS
+		{0x20, 0xf00b}, // f003 JSR $f00b
+		{0x48, 0x00},   // f006 PHA (stack-tia)
+		{0x4c, 0xf006}, // f007 JMP $f006
+		{0xea, 0x00},   // f00a NOP
+		{0x20, 0xf00b}, // f00b JSR $f00b
+		{0x60, 0x00},   // f00e RTS
+	})
+	if r[RuleStackTIA] != 1 {
+		t.Errorf("expected 1 %s issue, got %d", RuleStackTIA, r[RuleStackTIA])
+	}
+
+	// rule can be disabled
+	p := &Preferences{}
+	p.rules = map[string]*prefs.Bool{RuleStackTIA: {}}
+	r = check(p, [][2]uint16{
+		{0xa2, 0xff},   // f000 LDX #$ff
+		{0x9a, 0x00},   // f002 TXS
+		{0x48, 0x00},   // f003 PHA
+		{0x4c, 0xf003}, // f004 JMP $f003
+	})
+	if r[RuleStackTIA] != 0 {
+		t.Errorf("expected 0 %s issues, got %d", RuleStackTIA, r[RuleStackTIA])
+	}
+}
+
+func TestPrefsKey(t *testing.T) {
+	if prefsKey(RuleJMPIndirect) != "linter.jmpIndirectBug" {
+		t.Errorf("unexpected prefs key (%s)", prefsKey(RuleJMPIndirect))
+	}
+
+	// the prefs package only allows letters and the period character
+	for _, r := range Rules {
+		for _, c := range prefsKey(r.ID) {
+			if !(c == '.' || unicode.IsLetter(c)) {
+				t.Errorf("illegal character in prefs key for %s", r.ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package linter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// Sentinal errors.
+const (
+	UnknownRule = "linter: unknown rule (%s)"
+)
+
+// Preferences for the linter. Every rule can be enabled or disabled.
+type Preferences struct {
+	dsk *prefs.Disk
+
+	// indexed by rule ID
+	rules map[string]*prefs.Bool
+}
+
+func (p *Preferences) String() string {
+	return p.dsk.String()
+}
+
+// NewPreferences is the preferred method of initialisation for the
+// Preferences type.
+func NewPreferences() (*Preferences, error) {
+	p := &Preferences{
+		rules: make(map[string]*prefs.Bool),
+	}
+
+	// save server using the prefs package
+	pth, err := paths.ResourcePath("", prefs.DefaultPrefsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	p.dsk, err = prefs.NewDisk(pth)
+	if err != nil {
+		return nil, err
+	}
+
+	// every rule is enabled by default
+	for _, r := range Rules {
+		b := &prefs.Bool{}
+		b.Set(true)
+		p.rules[r.ID] = b
+
+		err = p.dsk.Add(prefsKey(r.ID), b)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = p.dsk.Load(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Load linter preferences.
+func (p *Preferences) Load() error {
+	return p.dsk.Load(false)
+}
+
+// Save current linter preferences to disk.
+func (p *Preferences) Save() error {
+	return p.dsk.Save()
+}
+
+// Rule returns the preference value for the rule ID.
+func (p *Preferences) Rule(id string) (*prefs.Bool, error) {
+	r, ok := LookupRule(id)
+	if !ok {
+		return nil, curated.Errorf(UnknownRule, id)
+	}
+	return p.rules[r.ID], nil
+}
+
+// Enabled returns true if the rule is enabled. A nil Preferences instance
+// will report every rule as being enabled.
+func (p *Preferences) Enabled(id string) bool {
+	if p == nil {
+		return true
+	}
+	if b, ok := p.rules[id]; ok {
+		return b.Get().(bool)
+	}
+	return true
+}
+
+// prefsKey converts a rule ID to a key suitable for the prefs package, which
+// does not allow the hyphen character. for example, "jmp-indirect-bug" becomes
+// "linter.jmpIndirectBug".
+func prefsKey(id string) string {
+	s := strings.Split(id, "-")
+	for i := 1; i < len(s); i++ {
+		if len(s[i]) > 0 {
+			s[i] = strings.ToUpper(s[i][:1]) + s[i][1:]
+		}
+	}
+	return fmt.Sprintf("linter.%s", strings.Join(s, ""))
+}
@@ -0,0 +1,93 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package linter
+
+import "strings"
+
+// Severity indicates how likely it is that a lint rule has found a real
+// problem.
+type Severity int
+
+// List of valid Severity values.
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return ""
+}
+
+// List of rules checked by the Lint() function.
+const (
+	RuleUnreadable      = "unreadable-address"
+	RuleUndocumented    = "undocumented-opcode"
+	RuleJMPIndirect     = "jmp-indirect-bug"
+	RuleStackTIA        = "stack-tia"
+	RuleROMWrite        = "rom-write"
+	RuleUnreachable     = "unreachable"
+	RuleBranchPageCross = "branch-page-cross"
+)
+
+// Rule describes a single lint rule.
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+
+	// whether the rule is checked by the Runtime linter rather than by the
+	// Lint() function
+	Runtime bool
+}
+
+// Rules is the list of every rule known to the linter.
+var Rules = []Rule{
+	{ID: RuleUnreadable, Severity: SeverityWarning, Description: "read from a TIA or RIOT address that can only be written to"},
+	{ID: RuleUndocumented, Severity: SeverityInfo, Description: "use of an undocumented opcode"},
+	{ID: RuleJMPIndirect, Severity: SeverityError, Description: "indirect JMP through a vector on a page boundary ($xxFF)"},
+	{ID: RuleStackTIA, Severity: SeverityWarning, Description: "nested subroutine calls and pushes that overflow the stack into TIA space"},
+	{ID: RuleROMWrite, Severity: SeverityWarning, Description: "write to a cartridge address that is not a hotspot or cartridge RAM"},
+	{ID: RuleUnreachable, Severity: SeverityInfo, Description: "code that is not reachable from any other code"},
+	{ID: RuleBranchPageCross, Severity: SeverityWarning, Description: "loop branch that crosses a page boundary and costs an extra cycle"},
+
+	{ID: RuleFrameLength, Severity: SeverityWarning, Description: "frame with the wrong number of scanlines", Runtime: true},
+	{ID: RuleVSync, Severity: SeverityWarning, Description: "VSYNC of the wrong length or not starting on a scanline boundary", Runtime: true},
+	{ID: RuleTimerLate, Severity: SeverityWarning, Description: "RIOT timer expired before being read", Runtime: true},
+	{ID: RuleHMOVEVisible, Severity: SeverityInfo, Description: "HMOVE in the visible part of the scanline", Runtime: true},
+	{ID: RuleHMOVEHMxx, Severity: SeverityWarning, Description: "HMxx register written too soon after HMOVE", Runtime: true},
+	{ID: RuleVBLANKMidline, Severity: SeverityInfo, Description: "VBLANK changed in the visible part of the scanline", Runtime: true},
+}
+
+// LookupRule returns the Rule with the specified ID. The ID is not case
+// sensitive.
+func LookupRule(id string) (Rule, bool) {
+	id = strings.ToLower(id)
+	for _, r := range Rules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
@@ -0,0 +1,431 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package linter
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/symbols"
+)
+
+// List of rules checked by the Runtime linter.
+const (
+	RuleFrameLength   = "frame-length"
+	RuleVSync         = "vsync"
+	RuleTimerLate     = "timer-late"
+	RuleHMOVEVisible  = "hmove-visible"
+	RuleHMOVEHMxx     = "hmove-hmxx"
+	RuleVBLANKMidline = "vblank-midline"
+)
+
+// MaxFrameReports is the maximum number of frames with findings that will be
+// kept by the Runtime linter.
+const MaxFrameReports = 100
+
+// the number of CPU cycles after an HMOVE during which the HMxx registers
+// should not be written to.
+const hmoveWindow = 24
+
+// HMOVE at CPU cycle 73 or 74 is a recognised technique for avoiding the HMOVE
+// bar. this is the horizontal position at which HMOVE is no longer considered
+// to be occurring in the visible part of the scanline.
+const hmoveLate = 150
+
+// Television defines the television functions required by the Runtime
+// linter.
+type Television interface {
+	GetSpec() specification.Spec
+	GetState(signal.StateReq) int
+}
+
+// Access describes the memory access made by an instruction.
+type Access struct {
+	Address uint16
+	Value   uint8
+	Write   bool
+}
+
+// Finding is a single problem found by the Runtime linter.
+type Finding struct {
+	Rule string
+
+	// the television coordinates at the end of the instruction that triggered
+	// the finding
+	Frame    int
+	Scanline int
+	HorizPos int
+
+	// the address of the instruction and the nearest label
+	PC     uint16
+	Symbol string
+
+	Detail string
+
+	// the number of times the same rule was triggered by the same instruction
+	// in the frame
+	Count int
+}
+
+func (f Finding) String() string {
+	sym := ""
+	if f.Symbol != "" {
+		sym = fmt.Sprintf(" (%s)", f.Symbol)
+	}
+	cnt := ""
+	if f.Count > 1 {
+		cnt = fmt.Sprintf(" [x%d]", f.Count)
+	}
+	return fmt.Sprintf("%-14s sl=%03d hp=%03d pc=%#04x%s: %s%s",
+		f.Rule, f.Scanline, f.HorizPos, f.PC, sym, f.Detail, cnt)
+}
+
+// FrameReport lists the findings for a single frame.
+type FrameReport struct {
+	Frame    int
+	Findings []*Finding
+}
+
+type findingKey struct {
+	rule string
+	pc   uint16
+}
+
+// Runtime checks the running emulation for timing problems. Unlike the Lint()
+// function, which looks at the disassembly, the Runtime linter must be
+// stepped after every CPU instruction.
+type Runtime struct {
+	tv    Television
+	sym   *symbols.Symbols
+	prefs *Preferences
+
+	reports []*FrameReport
+	current *FrameReport
+	dedupe  map[findingKey]*Finding
+
+	// running count of CPU cycles. measured from the television coordinates
+	cycles int
+
+	// television coordinates at the end of the most recent instruction
+	frame    int
+	scanline int
+	horizpos int
+	started  bool
+
+	// the highest scanline seen in the current frame and the number of
+	// scanlines in the previous frame. the first frame is likely to be
+	// incomplete so we don't check it
+	maxScanline  int
+	prevLength   int
+	checkedFirst bool
+
+	// VSYNC state
+	vsyncOn    bool
+	vsyncStart int
+
+	// VBLANK state
+	vblankOn bool
+
+	// RIOT timer state. timerPending is true until the timer has been read
+	timerPending  bool
+	timerInterval int
+	timerValue    int
+	timerSet      int
+	timerPC       uint16
+	timerName     string
+
+	// cycle count at the most recent HMOVE
+	hmove     int
+	hmoveSeen bool
+}
+
+// NewRuntime is the preferred method of initialisation for the Runtime type.
+// The symbols and preferences arguments can be nil. If there are no
+// preferences then every rule is checked.
+func NewRuntime(tv Television, sym *symbols.Symbols, prefs *Preferences) *Runtime {
+	rt := &Runtime{
+		tv:    tv,
+		sym:   sym,
+		prefs: prefs,
+	}
+	rt.Reset()
+	return rt
+}
+
+// Reset discards all findings and the current state of the linter.
+func (rt *Runtime) Reset() {
+	rt.reports = rt.reports[:0]
+	rt.current = nil
+	rt.dedupe = make(map[findingKey]*Finding)
+	rt.cycles = 0
+	rt.started = false
+	rt.maxScanline = 0
+	rt.prevLength = 0
+	rt.checkedFirst = false
+	rt.vsyncOn = false
+	rt.vblankOn = false
+	rt.timerPending = false
+	rt.hmoveSeen = false
+}
+
+// Reports returns the frames for which there are findings, oldest first.
+//
+// The returned reports should not be altered.
+func (rt *Runtime) Reports() []*FrameReport {
+	return rt.reports
+}
+
+// Write the findings for every frame to output.
+func (rt *Runtime) Write(output io.Writer) {
+	for _, r := range rt.reports {
+		output.Write([]byte(fmt.Sprintf("frame %d\n", r.Frame)))
+		for _, f := range r.Findings {
+			output.Write([]byte(fmt.Sprintf("  %s\n", f)))
+		}
+	}
+}
+
+// Step should be called after every CPU instruction. The access argument
+// should describe the last memory access made by the CPU.
+func (rt *Runtime) Step(result execution.Result, access Access) {
+	if result.Defn == nil || !result.Final {
+		return
+	}
+
+	frame := rt.tv.GetState(signal.ReqFramenum)
+	scanline := rt.tv.GetState(signal.ReqScanline)
+	horizpos := rt.tv.GetState(signal.ReqHorizPos)
+
+	if rt.started {
+		rt.cycles += rt.elapsed(result, frame, scanline, horizpos)
+	}
+
+	// check length of frame on a new frame
+	if rt.started && frame != rt.frame {
+		rt.endFrame(result)
+	}
+
+	rt.frame = frame
+	rt.scanline = scanline
+	rt.horizpos = horizpos
+	rt.started = true
+
+	if scanline > rt.maxScanline {
+		rt.maxScanline = scanline
+	}
+
+	switch result.Defn.Effect {
+	case instructions.Write, instructions.RMW:
+		if access.Write {
+			rt.write(result, access)
+		}
+	case instructions.Read:
+		if !access.Write && result.Defn.AddressingMode != instructions.Implied {
+			rt.read(result, access)
+		}
+	}
+}
+
+// the number of cycles since the previous call to Step(). a single instruction
+// can cross at most one scanline, even with a WSYNC. if the coordinates are
+// not consistent with that then the cycle count of the instruction is used.
+func (rt *Runtime) elapsed(result execution.Result, frame int, scanline int, horizpos int) int {
+	clks := horizpos - rt.horizpos
+	if frame != rt.frame || scanline != rt.scanline {
+		if (frame == rt.frame && scanline == rt.scanline+1) || (frame == rt.frame+1 && scanline == 0) {
+			clks += specification.HorizClksScanline
+		} else {
+			return result.Cycles
+		}
+	}
+	if clks < 0 {
+		return result.Cycles
+	}
+	return clks / 3
+}
+
+func (rt *Runtime) endFrame(result execution.Result) {
+	length := rt.maxScanline + 1
+	rt.maxScanline = 0
+
+	if !rt.checkedFirst {
+		rt.checkedFirst = true
+		return
+	}
+
+	// prevLength will be zero if the previous frame was the first frame
+	spec := rt.tv.GetSpec()
+	if length != spec.ScanlinesTotal {
+		detail := fmt.Sprintf("frame has %d scanlines, %s requires %d", length, spec.ID, spec.ScanlinesTotal)
+		if rt.prevLength > 0 && length != rt.prevLength {
+			detail = fmt.Sprintf("%s (previous frame had %d)", detail, rt.prevLength)
+		}
+		rt.add(RuleFrameLength, result.Address, detail)
+	} else if rt.prevLength > 0 && length != rt.prevLength {
+		rt.add(RuleFrameLength, result.Address, fmt.Sprintf("frame has %d scanlines, previous frame had %d", length, rt.prevLength))
+	}
+
+	rt.prevLength = length
+}
+
+func (rt *Runtime) write(result execution.Result, access Access) {
+	ma, area := memorymap.MapAddress(access.Address, false)
+
+	switch area {
+	case memorymap.TIA:
+		switch addresses.WriteSymbols[ma] {
+		case "VSYNC":
+			rt.writeVSYNC(result, access.Value&0x02 == 0x02)
+		case "VBLANK":
+			on := access.Value&0x02 == 0x02
+			if on != rt.vblankOn && rt.horizpos > 0 && rt.horizpos < specification.HorizClksVisible {
+				s := "off"
+				if on {
+					s = "on"
+				}
+				rt.add(RuleVBLANKMidline, result.Address, fmt.Sprintf("VBLANK turned %s in visible part of scanline", s))
+			}
+			rt.vblankOn = on
+		case "HMOVE":
+			if rt.horizpos >= 0 && rt.horizpos < hmoveLate {
+				rt.add(RuleHMOVEVisible, result.Address, fmt.Sprintf("HMOVE at CPU cycle %d", (rt.horizpos+specification.HorizClksHBlank)/3))
+			}
+			rt.hmove = rt.cycles
+			rt.hmoveSeen = true
+		case "HMP0", "HMP1", "HMM0", "HMM1", "HMBL", "HMCLR":
+			if rt.hmoveSeen && rt.cycles-rt.hmove < hmoveWindow {
+				rt.add(RuleHMOVEHMxx, result.Address, fmt.Sprintf("%s written %d cycles after HMOVE",
+					addresses.WriteSymbols[ma], rt.cycles-rt.hmove))
+			}
+		}
+
+	case memorymap.RIOT:
+		n := addresses.WriteSymbols[ma]
+		switch n {
+		case "TIM1T", "TIM8T", "TIM64T", "T1024T":
+			rt.timerPending = true
+			rt.timerValue = int(access.Value)
+			rt.timerSet = rt.cycles
+			rt.timerPC = result.Address
+			rt.timerName = n
+			switch n {
+			case "TIM1T":
+				rt.timerInterval = 1
+			case "TIM8T":
+				rt.timerInterval = 8
+			case "TIM64T":
+				rt.timerInterval = 64
+			case "T1024T":
+				rt.timerInterval = 1024
+			}
+		}
+	}
+}
+
+func (rt *Runtime) writeVSYNC(result execution.Result, on bool) {
+	if on == rt.vsyncOn {
+		return
+	}
+	rt.vsyncOn = on
+
+	if on {
+		rt.vsyncStart = rt.cycles
+
+		// VSYNC should start at the beginning of a scanline. we allow the
+		// write to happen at any point in the horizontal blank
+		if rt.horizpos >= 0 {
+			rt.add(RuleVSync, result.Address, fmt.Sprintf("VSYNC started mid-scanline (CPU cycle %d)",
+				(rt.horizpos+specification.HorizClksHBlank)/3))
+		}
+		return
+	}
+
+	spec := rt.tv.GetSpec()
+	cpl := specification.HorizClksScanline / 3
+	duration := rt.cycles - rt.vsyncStart
+	lines := (duration + cpl/2) / cpl
+	if lines != spec.ScanlinesVSync {
+		rt.add(RuleVSync, result.Address, fmt.Sprintf("VSYNC held for %d scanlines (%d cycles), %s requires %d",
+			lines, duration, spec.ID, spec.ScanlinesVSync))
+	}
+}
+
+func (rt *Runtime) read(result execution.Result, access Access) {
+	ma, area := memorymap.MapAddress(access.Address, true)
+	if area != memorymap.RIOT {
+		return
+	}
+
+	switch addresses.ReadSymbols[ma] {
+	case "INTIM", "TIMINT":
+		if !rt.timerPending {
+			return
+		}
+		rt.timerPending = false
+
+		// the timer expires one interval after INTIM reaches zero
+		expiry := (rt.timerValue + 1) * rt.timerInterval
+		elapsed := rt.cycles - rt.timerSet
+		if elapsed > expiry {
+			rt.add(RuleTimerLate, result.Address, fmt.Sprintf("%s set at %#04x expired %d cycles before first read",
+				rt.timerName, rt.timerPC, elapsed-expiry))
+		}
+	}
+}
+
+func (rt *Runtime) add(rule string, pc uint16, detail string) {
+	if !rt.prefs.Enabled(rule) {
+		return
+	}
+
+	if rt.current == nil || rt.current.Frame != rt.frame {
+		rt.current = &FrameReport{Frame: rt.frame}
+		rt.reports = append(rt.reports, rt.current)
+		if len(rt.reports) > MaxFrameReports {
+			rt.reports = rt.reports[1:]
+		}
+		rt.dedupe = make(map[findingKey]*Finding)
+	}
+
+	key := findingKey{rule: rule, pc: pc}
+	if f, ok := rt.dedupe[key]; ok {
+		f.Count++
+		return
+	}
+
+	f := &Finding{
+		Rule:     rule,
+		Frame:    rt.frame,
+		Scanline: rt.scanline,
+		HorizPos: rt.horizpos,
+		PC:       pc,
+		Detail:   detail,
+		Count:    1,
+	}
+
+	if rt.sym != nil {
+		f.Symbol, _, _ = rt.sym.NearestLabel(pc)
+	}
+
+	rt.dedupe[key] = f
+	rt.current.Findings = append(rt.current.Findings, f)
+}
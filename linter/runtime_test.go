@@ -0,0 +1,215 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package linter_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/linter"
+)
+
+// a minimal television. a new frame is started on the scanline after VSYNC
+// has been turned on.
+type mockTV struct {
+	frame    int
+	scanline int
+	horizpos int
+	vsync    bool
+}
+
+func (tv *mockTV) GetSpec() specification.Spec {
+	return specification.SpecNTSC
+}
+
+func (tv *mockTV) GetState(req signal.StateReq) int {
+	switch req {
+	case signal.ReqFramenum:
+		return tv.frame
+	case signal.ReqScanline:
+		return tv.scanline
+	case signal.ReqHorizPos:
+		return tv.horizpos - specification.HorizClksHBlank
+	}
+	return 0
+}
+
+func (tv *mockTV) advance(clks int) {
+	tv.horizpos += clks
+	for tv.horizpos >= specification.HorizClksScanline {
+		tv.horizpos -= specification.HorizClksScanline
+		tv.scanline++
+		if tv.vsync {
+			tv.vsync = false
+			tv.frame++
+			tv.scanline = 0
+		}
+	}
+}
+
+type mockCPU struct {
+	tv  *mockTV
+	rt  *linter.Runtime
+	pc  uint16
+	sta *instructions.Definition
+	lda *instructions.Definition
+	nop *instructions.Definition
+}
+
+func (cpu *mockCPU) step(defn *instructions.Definition, access linter.Access) {
+	cpu.tv.advance(defn.Cycles * 3)
+	cpu.rt.Step(execution.Result{Defn: defn, Address: cpu.pc, Cycles: defn.Cycles, Final: true}, access)
+	cpu.pc += uint16(defn.Bytes)
+}
+
+func (cpu *mockCPU) write(address uint16, value uint8) {
+	cpu.step(cpu.sta, linter.Access{Address: address, Value: value, Write: true})
+}
+
+func (cpu *mockCPU) read(address uint16) {
+	cpu.step(cpu.lda, linter.Access{Address: address})
+}
+
+func (cpu *mockCPU) wsync() {
+	cpu.write(0x02, 0)
+	if cpu.tv.horizpos > 0 {
+		cpu.tv.advance(specification.HorizClksScanline - cpu.tv.horizpos)
+	}
+}
+
+func (cpu *mockCPU) nops(n int) {
+	for i := 0; i < n; i++ {
+		cpu.step(cpu.nop, linter.Access{})
+	}
+}
+
+// frame produces a frame of the specified number of scanlines. the timer is
+// set in the vblank and read after the number of scanlines specified by
+// timerRead.
+func (cpu *mockCPU) frame(scanlines int, vsync int, timerRead int) {
+	cpu.pc = 0xf000
+	cpu.write(0x00, 0x02)
+	cpu.tv.vsync = true
+	for i := 0; i < vsync; i++ {
+		cpu.wsync()
+	}
+	cpu.write(0x00, 0x00)
+
+	// 43 * 64 cycles is the standard vblank timer for NTSC
+	cpu.write(0x296, 43)
+	for i := 0; i < timerRead; i++ {
+		cpu.wsync()
+	}
+	cpu.read(0x284)
+
+	for cpu.tv.scanline < scanlines-1 {
+		cpu.wsync()
+	}
+	cpu.nops(2)
+}
+
+func newMockCPU() *mockCPU {
+	defns := instructions.GetDefinitions()
+	tv := &mockTV{}
+	return &mockCPU{
+		tv:  tv,
+		rt:  linter.NewRuntime(tv, nil, nil),
+		sta: defns[0x8d],
+		lda: defns[0xad],
+		nop: defns[0xea],
+	}
+}
+
+func rules(rt *linter.Runtime) map[string]int {
+	r := make(map[string]int)
+	for _, fr := range rt.Reports() {
+		for _, f := range fr.Findings {
+			r[f.Rule] += f.Count
+		}
+	}
+	return r
+}
+
+func TestRuntimeClean(t *testing.T) {
+	cpu := newMockCPU()
+	for i := 0; i < 5; i++ {
+		cpu.frame(262, 3, 30)
+	}
+	if len(cpu.rt.Reports()) != 0 {
+		t.Errorf("unexpected findings: %v", rules(cpu.rt))
+	}
+}
+
+func TestRuntimeTiming(t *testing.T) {
+	cpu := newMockCPU()
+	cpu.frame(262, 3, 30)
+	cpu.frame(262, 3, 30)
+
+	// short frame
+	cpu.frame(260, 3, 30)
+
+	// vsync of only two scanlines
+	cpu.frame(262, 2, 30)
+
+	// timer read after 45 scanlines. 43 * 64 cycles is just over 36 scanlines
+	cpu.frame(262, 3, 45)
+	cpu.frame(262, 3, 30)
+
+	r := rules(cpu.rt)
+
+	// the short frame and the frame following are both reported
+	if r[linter.RuleFrameLength] != 2 {
+		t.Errorf("expected 2 %s findings, got %d", linter.RuleFrameLength, r[linter.RuleFrameLength])
+	}
+	if r[linter.RuleVSync] != 1 {
+		t.Errorf("expected 1 %s finding, got %d", linter.RuleVSync, r[linter.RuleVSync])
+	}
+	if r[linter.RuleTimerLate] != 1 {
+		t.Errorf("expected 1 %s finding, got %d", linter.RuleTimerLate, r[linter.RuleTimerLate])
+	}
+}
+
+func TestRuntimeHMOVE(t *testing.T) {
+	cpu := newMockCPU()
+	cpu.frame(262, 3, 30)
+
+	// correct HMOVE: immediately after WSYNC
+	cpu.wsync()
+	cpu.write(0x2a, 0)
+	cpu.nops(12)
+	cpu.write(0x20, 0)
+
+	// HMxx written too soon after HMOVE
+	cpu.wsync()
+	cpu.write(0x2a, 0)
+	cpu.write(0x2b, 0)
+
+	// HMOVE in visible part of the scanline
+	cpu.wsync()
+	cpu.nops(20)
+	cpu.write(0x2a, 0)
+
+	r := rules(cpu.rt)
+	if r[linter.RuleHMOVEHMxx] != 1 {
+		t.Errorf("expected 1 %s finding, got %d", linter.RuleHMOVEHMxx, r[linter.RuleHMOVEHMxx])
+	}
+	if r[linter.RuleHMOVEVisible] != 1 {
+		t.Errorf("expected 1 %s finding, got %d", linter.RuleHMOVEVisible, r[linter.RuleHMOVEVisible])
+	}
+}
@@ -0,0 +1,262 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package linter
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// the stack pointer is assumed to be $ff at the reset vector. this is what
+// almost every program sets the stack pointer to, either explicitly or by way
+// of the CLEAN_START macro.
+const stackInit = 0xff
+
+// the largest stack depth measured for a subroutine. a path that exceeds this
+// is pushing without pulling and the exact depth doesn't matter.
+const stackMaxDepth = 0x100
+
+// stack walks the blessed paths of a single bank, measuring how deep the
+// stack can grow. bank switching cannot be followed statically so paths that
+// leave the bank are not followed.
+type stack struct {
+	st      *static
+	bank    int
+	entries map[uint16]*disassembly.Entry
+
+	// the maximum stack depth of each subroutine, relative to the stack
+	// pointer at the point of the JSR. a value of -1 means the depth of the
+	// subroutine is currently being measured (ie. it is recursive)
+	depth map[uint16]int
+
+	// addresses that have already been reported
+	reported map[uint16]bool
+}
+
+func newStack(st *static, bank int, entries []*disassembly.Entry) *stack {
+	stk := &stack{
+		st:       st,
+		bank:     bank,
+		entries:  make(map[uint16]*disassembly.Entry),
+		depth:    make(map[uint16]int),
+		reported: make(map[uint16]bool),
+	}
+	for _, e := range entries {
+		if e.Result.Defn != nil {
+			stk.entries[e.Result.Address&memorymap.CartridgeBits] = e
+		}
+	}
+	return stk
+}
+
+func (stk *stack) entry(address uint16) *disassembly.Entry {
+	return stk.entries[address&memorymap.CartridgeBits]
+}
+
+// the addresses that execution can continue at after the instruction. returns
+// nil if the flow of execution cannot be followed.
+func successors(e *disassembly.Entry) []uint16 {
+	defn := e.Result.Defn
+	next := e.Result.Address + uint16(defn.Bytes)
+
+	switch {
+	case defn.IsBranch():
+		return []uint16{next, branchDestination(e.Result.Address, e.Result.InstructionData)}
+	}
+
+	switch defn.Mnemonic {
+	case "JMP":
+		if defn.AddressingMode == instructions.Absolute {
+			return []uint16{e.Result.InstructionData}
+		}
+		return nil
+	case "RTS", "RTI", "BRK":
+		return nil
+	}
+
+	return []uint16{next}
+}
+
+// subroutine returns the maximum number of bytes pushed by the subroutine at
+// address, including the bytes pushed by any nested subroutine. subroutines
+// not in the bank are assumed to push nothing.
+func (stk *stack) subroutine(address uint16) int {
+	key := address & memorymap.CartridgeBits
+
+	if d, ok := stk.depth[key]; ok {
+		// recursion can't be measured statically
+		if d < 0 {
+			return 0
+		}
+		return d
+	}
+
+	if stk.entry(address) == nil {
+		return 0
+	}
+
+	stk.depth[key] = -1
+
+	type state struct {
+		address uint16
+		depth   int
+	}
+
+	peak := 0
+	visited := make(map[state]bool)
+	pending := []state{{address: address}}
+
+	for len(pending) > 0 {
+		s := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		e := stk.entry(s.address)
+		if e == nil || visited[s] {
+			continue
+		}
+		visited[s] = true
+
+		// pulling without pushing can't be usefully measured
+		if s.depth < -stackMaxDepth {
+			continue
+		}
+
+		d := s.depth
+
+		switch e.Result.Defn.Mnemonic {
+		case "PHA", "PHP":
+			d++
+		case "PLA", "PLP":
+			d--
+		case "JSR":
+			if n := d + 2 + stk.subroutine(e.Result.InstructionData); n > peak {
+				peak = n
+			}
+		case "BRK":
+			if n := d + 3; n > peak {
+				peak = n
+			}
+		case "TXS":
+			// the stack pointer has been set explicitly. the depth from this
+			// point on is not relative to the JSR
+			continue
+		}
+
+		if d > peak {
+			peak = d
+		}
+		if peak >= stackMaxDepth {
+			peak = stackMaxDepth
+			break
+		}
+
+		for _, a := range successors(e) {
+			pending = append(pending, state{address: a, depth: d})
+		}
+	}
+
+	stk.depth[key] = peak
+
+	return peak
+}
+
+// walk the blessed paths from address, keeping track of the stack pointer.
+//
+// the stack pointer is only known after a TXS if the X register was loaded
+// with an immediate value by the preceding instruction. a TXS without a known
+// value is assumed to be restoring the stack pointer to stackInit.
+//
+// pushes are only reported when they take the stack pointer from RAM into TIA
+// space. a stack pointer that has been set explicitly to TIA space is a
+// deliberate technique (eg. PHP to write to ENAM0/ENAM1/ENABL) and is not
+// reported.
+func (stk *stack) walk(address uint16) {
+	type state struct {
+		address uint16
+		sp      int
+
+		// the value of the X register if it was loaded with an immediate
+		// value by the previous instruction. -1 otherwise
+		x int
+	}
+
+	visited := make(map[state]bool)
+	pending := []state{{address: address, sp: stackInit, x: -1}}
+
+	for len(pending) > 0 {
+		s := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		e := stk.entry(s.address)
+		if e == nil || visited[s] {
+			continue
+		}
+		visited[s] = true
+
+		sp := s.sp
+		x := -1
+
+		defn := e.Result.Defn
+		switch defn.Mnemonic {
+		case "LDX":
+			if defn.AddressingMode == instructions.Immediate {
+				x = int(e.Result.InstructionData & 0xff)
+			}
+		case "TXS":
+			if s.x >= 0 {
+				sp = s.x
+			} else {
+				sp = stackInit
+			}
+		case "PHA", "PHP":
+			stk.check(e, sp, sp-1, fmt.Sprintf("%s with stack pointer at %#02x", defn.Mnemonic, sp))
+			sp--
+		case "PLA", "PLP":
+			sp++
+		case "JSR":
+			d := 2 + stk.subroutine(e.Result.InstructionData)
+			stk.check(e, sp, sp-d, fmt.Sprintf("call to %#04x pushes up to %d bytes with stack pointer at %#02x",
+				e.Result.InstructionData, d, sp))
+		}
+
+		// the stack has wrapped around. further pushes can't be usefully
+		// measured
+		if sp < 0 || sp > 0xff {
+			continue
+		}
+
+		for _, a := range successors(e) {
+			pending = append(pending, state{address: a, sp: sp, x: x})
+		}
+	}
+}
+
+func (stk *stack) check(e *disassembly.Entry, sp int, low int, detail string) {
+	if sp < int(memorymap.OriginRAM) || low >= int(memorymap.OriginRAM) {
+		return
+	}
+
+	key := e.Result.Address & memorymap.CartridgeBits
+	if stk.reported[key] {
+		return
+	}
+	stk.reported[key] = true
+
+	stk.st.add(RuleStackTIA, stk.bank, e.Result.Address, fmt.Sprintf("%s. stack overflows into TIA space", detail))
+}
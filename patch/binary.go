@@ -0,0 +1,60 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+package patch
+
+import (
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
+)
+
+// the signature of functions that apply a binary patch to the source data,
+// returning the patched data.
+type applyFunc func(source []uint8, patch []uint8) ([]uint8, error)
+
+// apply a binary patch to cartridge memory. binary patches describe the
+// entire target file so the patch is applied to the cartridge file as it was
+// loaded and only the differences are poked into the cartridge. the file can
+// contain more than the cartridge banks (eg. the DPC static area) but offsets
+// into the file are the same offsets used by cartridge.Patch().
+func binaryPatch(mem *cartridge.Cartridge, buffer []uint8, apply applyFunc) (bool, error) {
+	source := mem.FileData()
+	if len(source) == 0 {
+		return false, curated.Errorf("patch: no cartridge data to patch")
+	}
+
+	target, err := apply(source, buffer)
+	if err != nil {
+		return false, curated.Errorf("patch: %v", err)
+	}
+
+	if len(target) != len(source) {
+		return false, curated.Errorf("patch: patch changes the size of the cartridge (%d to %d bytes)", len(source), len(target))
+	}
+
+	patched := false
+	for i := range target {
+		if target[i] == source[i] {
+			continue // for loop
+		}
+
+		err = mem.Patch(i, target[i])
+		if err != nil {
+			return patched, curated.Errorf("patch: %v", err)
+		}
+		patched = true
+	}
+
+	return patched, nil
+}
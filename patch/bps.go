@@ -0,0 +1,236 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+package patch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// BPS files begin with bpsHeader. the footer is three CRC32 checksums: of the
+// source file, the target file and of the patch itself (minus the final
+// checksum).
+const (
+	bpsHeader     = "BPS1"
+	bpsFooterSize = 12
+)
+
+// BPS actions.
+const (
+	bpsSourceRead = iota
+	bpsTargetRead
+	bpsSourceCopy
+	bpsTargetCopy
+)
+
+// apply BPS patch to source data. the source data is not altered.
+//
+// the checksum of the source data is compared to the checksum in the patch
+// before the patch is applied. this guards against the patch being applied to
+// the wrong cartridge. the checksum of the patched data is also checked.
+func applyBPS(source []uint8, patch []uint8) ([]uint8, error) {
+	if len(patch) < len(bpsHeader)+bpsFooterSize {
+		return nil, fmt.Errorf("bps: patch is too short")
+	}
+
+	footer := patch[len(patch)-bpsFooterSize:]
+	sourceCRC := binary.LittleEndian.Uint32(footer[0:])
+	targetCRC := binary.LittleEndian.Uint32(footer[4:])
+	patchCRC := binary.LittleEndian.Uint32(footer[8:])
+
+	if crc32.ChecksumIEEE(patch[:len(patch)-4]) != patchCRC {
+		return nil, fmt.Errorf("bps: patch is corrupt")
+	}
+	if crc32.ChecksumIEEE(source) != sourceCRC {
+		return nil, fmt.Errorf("bps: patch is not for this cartridge (checksum mismatch)")
+	}
+
+	// the actions end where the footer begins
+	end := len(patch) - bpsFooterSize
+	idx := len(bpsHeader)
+
+	read := func() (int, error) {
+		v := 0
+		shift := 1
+		for {
+			if idx >= end {
+				return 0, fmt.Errorf("bps: unexpected end of patch")
+			}
+			x := int(patch[idx])
+			idx++
+			v += (x & 0x7f) * shift
+			if x&0x80 == 0x80 {
+				break // for loop
+			}
+			shift <<= 7
+			v += shift
+		}
+		return v, nil
+	}
+
+	// relative offsets are stored with the sign in the lowest bit
+	readSigned := func() (int, error) {
+		v, err := read()
+		if err != nil {
+			return 0, err
+		}
+		if v&0x01 == 0x01 {
+			return -(v >> 1), nil
+		}
+		return v >> 1, nil
+	}
+
+	sourceSize, err := read()
+	if err != nil {
+		return nil, err
+	}
+	targetSize, err := read()
+	if err != nil {
+		return nil, err
+	}
+	metadataSize, err := read()
+	if err != nil {
+		return nil, err
+	}
+	idx += metadataSize
+
+	if sourceSize != len(source) {
+		return nil, fmt.Errorf("bps: patch is not for this cartridge (size mismatch)")
+	}
+
+	target := make([]uint8, targetSize)
+	outputOffset := 0
+	sourceRelative := 0
+	targetRelative := 0
+
+	for idx < end {
+		v, err := read()
+		if err != nil {
+			return nil, err
+		}
+		action := v & 0x03
+		length := (v >> 2) + 1
+
+		if outputOffset+length > len(target) {
+			return nil, fmt.Errorf("bps: action writes beyond end of target")
+		}
+
+		switch action {
+		case bpsSourceRead:
+			if outputOffset+length > len(source) {
+				return nil, fmt.Errorf("bps: action reads beyond end of source")
+			}
+			copy(target[outputOffset:], source[outputOffset:outputOffset+length])
+
+		case bpsTargetRead:
+			if idx+length > end {
+				return nil, fmt.Errorf("bps: unexpected end of patch")
+			}
+			copy(target[outputOffset:], patch[idx:idx+length])
+			idx += length
+
+		case bpsSourceCopy:
+			o, err := readSigned()
+			if err != nil {
+				return nil, err
+			}
+			sourceRelative += o
+			if sourceRelative < 0 || sourceRelative+length > len(source) {
+				return nil, fmt.Errorf("bps: action reads beyond end of source")
+			}
+			copy(target[outputOffset:], source[sourceRelative:sourceRelative+length])
+			sourceRelative += length
+
+		case bpsTargetCopy:
+			o, err := readSigned()
+			if err != nil {
+				return nil, err
+			}
+			targetRelative += o
+			if targetRelative < 0 || targetRelative >= outputOffset {
+				return nil, fmt.Errorf("bps: action reads beyond end of target")
+			}
+
+			// target copy can overlap the output so copy byte by byte
+			for i := 0; i < length; i++ {
+				target[outputOffset+i] = target[targetRelative]
+				targetRelative++
+			}
+		}
+
+		outputOffset += length
+	}
+
+	if crc32.ChecksumIEEE(target) != targetCRC {
+		return nil, fmt.Errorf("bps: patched cartridge checksum mismatch")
+	}
+
+	return target, nil
+}
+
+// append a number to the patch using the BPS variable length encoding.
+func bpsNumber(patch []uint8, v int) []uint8 {
+	for {
+		x := uint8(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(patch, 0x80|x)
+		}
+		patch = append(patch, x)
+		v--
+	}
+}
+
+// encode the differences between original and modified as a BPS patch.
+// original and modified must be the same length. unchanged runs are encoded
+// with the SourceRead action and changed runs with the TargetRead action.
+func encodeBPS(original []uint8, modified []uint8) ([]uint8, error) {
+	patch := []uint8(bpsHeader)
+	patch = bpsNumber(patch, len(original))
+	patch = bpsNumber(patch, len(modified))
+	patch = bpsNumber(patch, 0)
+
+	i := 0
+	for i < len(modified) {
+		same := original[i] == modified[i]
+
+		end := i
+		for end < len(modified) && (original[end] == modified[end]) == same {
+			end++
+		}
+
+		length := end - i
+		if same {
+			patch = bpsNumber(patch, (length-1)<<2|bpsSourceRead)
+		} else {
+			patch = bpsNumber(patch, (length-1)<<2|bpsTargetRead)
+			patch = append(patch, modified[i:end]...)
+		}
+
+		i = end
+	}
+
+	footer := make([]uint8, 8)
+	binary.LittleEndian.PutUint32(footer[0:], crc32.ChecksumIEEE(original))
+	binary.LittleEndian.PutUint32(footer[4:], crc32.ChecksumIEEE(modified))
+	patch = append(patch, footer...)
+
+	crc := make([]uint8, 4)
+	binary.LittleEndian.PutUint32(crc, crc32.ChecksumIEEE(patch))
+	patch = append(patch, crc...)
+
+	return patch, nil
+}
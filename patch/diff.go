@@ -0,0 +1,120 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+package patch
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// Format of a patch file.
+type Format int
+
+// List of valid Format values.
+const (
+	FormatText Format = iota
+	FormatIPS
+	FormatBPS
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatIPS:
+		return "IPS"
+	case FormatBPS:
+		return "BPS"
+	}
+	return ""
+}
+
+// FormatFromFilename returns the patch format suggested by the extension of
+// the filename. Any extension other than .ips or .bps suggests the text
+// format.
+func FormatFromFilename(filename string) Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".ips":
+		return FormatIPS
+	case ".bps":
+		return FormatBPS
+	}
+	return FormatText
+}
+
+// Diff returns a patch in the specified format that changes original into
+// modified. Cartridges are patched in place so the two files must be the
+// same size.
+func Diff(original []uint8, modified []uint8, format Format) ([]uint8, error) {
+	if len(original) != len(modified) {
+		return nil, curated.Errorf("patch: files must be the same size (%d and %d bytes)", len(original), len(modified))
+	}
+
+	switch format {
+	case FormatIPS:
+		p, err := encodeIPS(original, modified)
+		if err != nil {
+			return nil, curated.Errorf("patch: %v", err)
+		}
+		return p, nil
+	case FormatBPS:
+		p, err := encodeBPS(original, modified)
+		if err != nil {
+			return nil, curated.Errorf("patch: %v", err)
+		}
+		return p, nil
+	}
+
+	pokes := make(map[int]uint8)
+	for i := range modified {
+		if original[i] != modified[i] {
+			pokes[i] = modified[i]
+		}
+	}
+
+	return encodeText(pokes, ""), nil
+}
+
+// DiffFiles compares the original and modified files and writes a patch file
+// to output. The format of the patch is decided by the extension of the
+// output filename (see FormatFromFilename()).
+//
+// Unlike Write(), the output filename is not relative to the resource path.
+func DiffFiles(original string, modified string, output string) error {
+	o, err := ioutil.ReadFile(original)
+	if err != nil {
+		return curated.Errorf("patch: %v", err)
+	}
+
+	m, err := ioutil.ReadFile(modified)
+	if err != nil {
+		return curated.Errorf("patch: %v", err)
+	}
+
+	p, err := Diff(o, m, FormatFromFilename(output))
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(output, p, 0644)
+	if err != nil {
+		return curated.Errorf("patch: %v", err)
+	}
+
+	return nil
+}
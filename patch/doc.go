@@ -19,9 +19,14 @@
 // (see cartridge package) deal with that individually.
 //
 // This package simply loads the patch instructions, interprets them and calls
-// the cartridge.Patch() function. Currently only one patch format is
-// supported. This is an ad-hoc format taken from the "In case you can't wait"
-// section of the following web page:
+// the cartridge.Patch() function. Three patch formats are supported: IPS, BPS
+// and an ad-hoc text format. IPS and BPS are the binary formats commonly used
+// to distribute ROM hacks. The checksum of the cartridge is checked before a
+// BPS patch is applied. Neither binary format can be used to change the size
+// of the cartridge.
+//
+// The text format is taken from the "In case you can't wait" section of the
+// following web page:
 //
 //	"Fixing E.T. The Extra-Terrestrial for the Atari 2600"
 //
@@ -47,4 +52,8 @@
 // to how memory is mapped inside the VCS. Imagine that the patches are being
 // applied to the cartridge file image. The cartridge mapper handles the VCS
 // memory side of things.
+//
+// Patches can be created by comparing an original cartridge file with a
+// modified version, with the Diff() and DiffFiles() functions. The Write()
+// function creates a text patch from a list of changes.
 package patch
@@ -0,0 +1,148 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+package patch
+
+import (
+	"fmt"
+)
+
+// IPS files begin with the ipsHeader and end with ipsFooter. ipsFooter can
+// never be the offset of a record.
+const (
+	ipsHeader = "PATCH"
+	ipsFooter = "EOF"
+)
+
+// the largest offset that can be expressed and the largest number of bytes in
+// a single record.
+const (
+	ipsMaxOffset = 0xffffff
+	ipsMaxRecord = 0xffff
+)
+
+// the offset that can't be used because it looks like the footer.
+const ipsFooterOffset = 0x454f46
+
+// apply IPS patch to source data. the source data is not altered.
+//
+// an IPS patch is a sequence of records. each record is a three byte offset
+// and a two byte length, followed by length bytes of data. a record with a
+// length of zero is a run-length encoded record: a two byte count followed by
+// a single byte value. all numbers are big-endian.
+func applyIPS(source []uint8, patch []uint8) ([]uint8, error) {
+	target := make([]uint8, len(source))
+	copy(target, source)
+
+	idx := len(ipsHeader)
+
+	read := func(n int) (int, error) {
+		if idx+n > len(patch) {
+			return 0, fmt.Errorf("ips: unexpected end of patch")
+		}
+		v := 0
+		for i := 0; i < n; i++ {
+			v = v<<8 | int(patch[idx+i])
+		}
+		idx += n
+		return v, nil
+	}
+
+	for {
+		if idx+len(ipsFooter) <= len(patch) && string(patch[idx:idx+len(ipsFooter)]) == ipsFooter {
+			// any data after the footer is a truncation length, which we
+			// ignore. the size of cartridge memory can't be changed
+			break // for loop
+		}
+
+		offset, err := read(3)
+		if err != nil {
+			return nil, err
+		}
+		length, err := read(2)
+		if err != nil {
+			return nil, err
+		}
+
+		var data []uint8
+		if length == 0 {
+			count, err := read(2)
+			if err != nil {
+				return nil, err
+			}
+			v, err := read(1)
+			if err != nil {
+				return nil, err
+			}
+			data = make([]uint8, count)
+			for i := range data {
+				data[i] = uint8(v)
+			}
+		} else {
+			if idx+length > len(patch) {
+				return nil, fmt.Errorf("ips: unexpected end of patch")
+			}
+			data = patch[idx : idx+length]
+			idx += length
+		}
+
+		if offset+len(data) > len(target) {
+			return nil, fmt.Errorf("ips: record at offset %#06x is beyond the end of the cartridge", offset)
+		}
+		copy(target[offset:], data)
+	}
+
+	return target, nil
+}
+
+// encode the differences between original and modified as an IPS patch.
+// original and modified must be the same length.
+func encodeIPS(original []uint8, modified []uint8) ([]uint8, error) {
+	if len(modified) > ipsMaxOffset {
+		return nil, fmt.Errorf("ips: file too large for IPS format")
+	}
+
+	patch := []uint8(ipsHeader)
+
+	i := 0
+	for i < len(modified) {
+		if original[i] == modified[i] {
+			i++
+			continue // for loop
+		}
+
+		// start the record one byte early if the offset would be mistaken for
+		// the footer
+		start := i
+		if start == ipsFooterOffset {
+			start--
+		}
+
+		end := i
+		for end < len(modified) && original[end] != modified[end] && end-start < ipsMaxRecord {
+			end++
+		}
+
+		n := end - start
+		patch = append(patch, uint8(start>>16), uint8(start>>8), uint8(start))
+		patch = append(patch, uint8(n>>8), uint8(n))
+		patch = append(patch, modified[start:end]...)
+
+		i = end
+	}
+
+	patch = append(patch, []uint8(ipsFooter)...)
+
+	return patch, nil
+}
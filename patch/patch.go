@@ -16,10 +16,12 @@
 package patch
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -35,8 +37,13 @@ const commentLeader = '-'
 const pokeLineSeparator = ":"
 
 // CartridgeMemory applies the contents of a patch file to cartridge memory.
-// Currently, patch file must be in the patches sub-directory of the
-// resource path (see paths package).
+// The patch file is looked for in the patches sub-directory of the resource
+// path (see paths package) and then, if it is not found there, as a path in
+// its own right.
+//
+// The format of the patch file is detected from its contents. IPS and BPS
+// files are recognised by their headers. Anything else is treated as a text
+// patch file.
 func CartridgeMemory(mem *cartridge.Cartridge, patchFile string) (bool, error) {
 	var err error
 
@@ -45,6 +52,10 @@ func CartridgeMemory(mem *cartridge.Cartridge, patchFile string) (bool, error) {
 		return false, curated.Errorf("patch: %v", err)
 	}
 
+	if _, err := os.Stat(p); os.IsNotExist(err) {
+		p = patchFile
+	}
+
 	f, err := os.Open(p)
 	if err != nil {
 		switch err.(type) {
@@ -65,6 +76,18 @@ func CartridgeMemory(mem *cartridge.Cartridge, patchFile string) (bool, error) {
 		return false, curated.Errorf("patch: %v", err)
 	}
 
+	switch {
+	case bytes.HasPrefix(buffer, []byte(ipsHeader)):
+		return binaryPatch(mem, buffer, applyIPS)
+	case bytes.HasPrefix(buffer, []byte(bpsHeader)):
+		return binaryPatch(mem, buffer, applyBPS)
+	}
+
+	return textPatch(mem, buffer)
+}
+
+// apply the text patch format described in the package documentation.
+func textPatch(mem *cartridge.Cartridge, buffer []uint8) (bool, error) {
 	// once a patch has been made then we'll flip patched to true and return it
 	// to the calling function
 	patched := false
@@ -94,7 +117,7 @@ func CartridgeMemory(mem *cartridge.Cartridge, patchFile string) (bool, error) {
 		pokeLine[1] = strings.TrimSpace(pokeLine[1])
 
 		// parse offset
-		offset, err := strconv.ParseInt(pokeLine[0], 16, 16)
+		offset, err := strconv.ParseInt(pokeLine[0], 16, 32)
 		if err != nil {
 			continue // for loop
 		}
@@ -130,3 +153,62 @@ func CartridgeMemory(mem *cartridge.Cartridge, patchFile string) (bool, error) {
 
 	return patched, nil
 }
+
+// the maximum number of values written on a single line by Write().
+const maxValuesPerLine = 16
+
+// Write creates a patch file in the patches sub-directory of the resource path
+// (see paths package). The pokes map is keyed by the cartridge offset. The
+// description is written as a comment at the head of the file and can be
+// empty.
+//
+// Consecutive offsets are written on the same line. An existing file with the
+// same name is replaced.
+func Write(patchFile string, pokes map[int]uint8, description string) error {
+	if len(pokes) == 0 {
+		return curated.Errorf("patch: nothing to write")
+	}
+
+	p, err := paths.ResourcePath(patchPath, patchFile)
+	if err != nil {
+		return curated.Errorf("patch: %v", err)
+	}
+
+	err = ioutil.WriteFile(p, encodeText(pokes, description), 0600)
+	if err != nil {
+		return curated.Errorf("patch: %v", err)
+	}
+
+	return nil
+}
+
+// encode pokes in the text patch format.
+func encodeText(pokes map[int]uint8, description string) []uint8 {
+	offsets := make([]int, 0, len(pokes))
+	for o := range pokes {
+		offsets = append(offsets, o)
+	}
+	sort.Ints(offsets)
+
+	s := &strings.Builder{}
+
+	if description != "" {
+		s.WriteString(fmt.Sprintf("%c %s\n", commentLeader, description))
+	}
+
+	n := 0
+	for i, o := range offsets {
+		if i == 0 || o != offsets[i-1]+1 || n == maxValuesPerLine {
+			if i > 0 {
+				s.WriteString("\n")
+			}
+			s.WriteString(fmt.Sprintf("%04X%s", o, pokeLineSeparator))
+			n = 0
+		}
+		s.WriteString(fmt.Sprintf(" %02X", pokes[o]))
+		n++
+	}
+	s.WriteString("\n")
+
+	return []uint8(s.String())
+}
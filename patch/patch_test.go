@@ -0,0 +1,194 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+package patch
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
+)
+
+func testData() ([]uint8, []uint8) {
+	original := make([]uint8, 4096)
+	for i := range original {
+		original[i] = uint8(i)
+	}
+
+	modified := make([]uint8, len(original))
+	copy(modified, original)
+	modified[0] = 0xff
+	modified[0x10] = 0xaa
+	modified[0x11] = 0xbb
+	modified[0xfff] = 0x00
+
+	return original, modified
+}
+
+func TestIPS(t *testing.T) {
+	original, modified := testData()
+
+	p, err := Diff(original, modified, FormatIPS)
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+
+	target, err := applyIPS(original, p)
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	if !bytes.Equal(target, modified) {
+		t.Errorf("IPS patch did not recreate modified data")
+	}
+
+	// run-length encoded record
+	rle := []uint8(ipsHeader)
+	rle = append(rle, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x04, 0xea)
+	rle = append(rle, []uint8(ipsFooter)...)
+	target, err = applyIPS(original, rle)
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	if !bytes.Equal(target[0x100:0x105], []uint8{0xea, 0xea, 0xea, 0xea, 0x04}) {
+		t.Errorf("unexpected RLE result (% 02x)", target[0x100:0x105])
+	}
+
+	// records beyond the end of the data are an error
+	if _, err := applyIPS(original[:0x100], rle); err == nil {
+		t.Errorf("expected error for record beyond end of data")
+	}
+}
+
+func TestBPS(t *testing.T) {
+	original, modified := testData()
+
+	p, err := Diff(original, modified, FormatBPS)
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+
+	target, err := applyBPS(original, p)
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	if !bytes.Equal(target, modified) {
+		t.Errorf("BPS patch did not recreate modified data")
+	}
+
+	// the patch should not apply to different source data
+	if _, err := applyBPS(modified, p); err == nil {
+		t.Errorf("expected error for source checksum mismatch")
+	}
+
+	// or if the patch has been corrupted
+	p[len(bpsHeader)+4] ^= 0xff
+	if _, err := applyBPS(original, p); err == nil {
+		t.Errorf("expected error for corrupt patch")
+	}
+}
+
+func TestDiffText(t *testing.T) {
+	original, modified := testData()
+
+	p, err := Diff(original, modified, FormatText)
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+
+	expected := "0000: FF\n0010: AA BB\n0FFF: 00\n"
+	if string(p) != expected {
+		t.Errorf("unexpected text patch (%s)", string(p))
+	}
+
+	if _, err := Diff(original, modified[:100], FormatText); err == nil {
+		t.Errorf("expected error for files of different sizes")
+	}
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	if FormatFromFilename("hack.IPS") != FormatIPS {
+		t.Errorf("expected IPS format")
+	}
+	if FormatFromFilename("hack.bps") != FormatBPS {
+		t.Errorf("expected BPS format")
+	}
+	if FormatFromFilename("hack.patch") != FormatText {
+		t.Errorf("expected text format")
+	}
+}
+
+func TestCartridgeMemoryDPC(t *testing.T) {
+	// a DPC cartridge file is two banks followed by the static area. like
+	// Pitfall II, the file is longer than that
+	original := make([]uint8, 10495)
+	for i := range original {
+		original[i] = uint8(i)
+	}
+
+	// one change in bank 1 and one in the static area
+	modified := make([]uint8, len(original))
+	copy(modified, original)
+	modified[0x1010] = 0xaa
+	modified[0x2010] = 0xbb
+
+	for _, f := range []Format{FormatIPS, FormatBPS} {
+		p, err := Diff(original, modified, f)
+		if err != nil {
+			t.Fatalf("unexpected error (%s)", err)
+		}
+		patchFile := filepath.Join(t.TempDir(), "dpc.patch")
+		err = os.WriteFile(patchFile, p, 0644)
+		if err != nil {
+			t.Fatalf("unexpected error (%s)", err)
+		}
+
+		data := make([]uint8, len(original))
+		copy(data, original)
+
+		cart := cartridge.NewCartridge(nil)
+		err = cart.Attach(cartridgeloader.Loader{
+			Filename: "dpc_test",
+			Mapping:  "DPC",
+			Data:     data,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error (%s)", err)
+		}
+
+		patched, err := CartridgeMemory(cart, patchFile)
+		if err != nil {
+			t.Fatalf("unexpected error for %s patch (%s)", f, err)
+		}
+		if !patched {
+			t.Errorf("expected cartridge to be patched by %s patch", f)
+		}
+
+		banks, err := cart.CopyBanks()
+		if err != nil {
+			t.Fatalf("unexpected error (%s)", err)
+		}
+		if banks[1].Data[0x10] != 0xaa {
+			t.Errorf("%s patch did not change bank 1", f)
+		}
+
+		static := cart.GetStaticBus().GetStatic()
+		if static[0].Data[0x10] != 0xbb {
+			t.Errorf("%s patch did not change the static area", f)
+		}
+	}
+}
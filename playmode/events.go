@@ -16,9 +16,13 @@
 package playmode
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/jetsetilly/gopher2600/gui"
 	"github.com/jetsetilly/gopher2600/hardware"
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+	"github.com/jetsetilly/gopher2600/logger"
 )
 
 // MouseMotionEventHandler handles mouse events sent from a GUI. Returns true if key
@@ -208,6 +212,9 @@ func (pl *playmode) guiEventHandler(ev gui.Event) (bool, error) {
 	case gui.EventQuit:
 		return false, nil
 	case gui.EventKeyboard:
+		if pl.clipHotkey(ev) {
+			return true, nil
+		}
 		_, err := KeyboardEventHandler(ev, pl.vcs)
 		return err == nil, err
 	case gui.EventMouseButton:
@@ -221,6 +228,45 @@ func (pl *playmode) guiEventHandler(ev gui.Event) (bool, error) {
 	return true, nil
 }
 
+// clipHotkey saves the buffered clip if the clip hotkey has been pressed. F9
+// saves the clip as a GIF and shift-F9 saves the clip as an animated PNG.
+// Returns true if the key has been handled.
+func (pl *playmode) clipHotkey(ev gui.EventKeyboard) bool {
+	if pl.clip == nil || !ev.Down || ev.Key != "F9" {
+		return false
+	}
+
+	var ext string
+	switch ev.Mod {
+	case gui.KeyModNone:
+		ext = "gif"
+	case gui.KeyModShift:
+		ext = "png"
+	default:
+		return false
+	}
+
+	n := time.Now()
+	filename := fmt.Sprintf("clip_%s_%s.%s",
+		pl.cartName, fmt.Sprintf("%04d%02d%02d_%02d%02d%02d",
+			n.Year(), n.Month(), n.Day(), n.Hour(), n.Minute(), n.Second()), ext)
+
+	// saving the clip can take some time so we do it in a goroutine. the
+	// snapshot is a copy of the buffer and is safe to use while the emulation
+	// continues
+	clip := pl.clip.Snapshot()
+	go func() {
+		err := clip.Save(filename)
+		if err != nil {
+			logger.Log("playmode", err.Error())
+			return
+		}
+		logger.Log("playmode", fmt.Sprintf("%s saved to %s", clip, filename))
+	}()
+
+	return true
+}
+
 func (pl *playmode) eventHandler() (bool, error) {
 	select {
 	case <-pl.intChan:
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/clipwriter"
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/gui"
 	"github.com/jetsetilly/gopher2600/hardware"
@@ -44,6 +45,11 @@ type playmode struct {
 	intChan   chan os.Signal
 	guiChan   chan gui.Event
 	rawEvents chan func()
+
+	// rolling buffer of recent frames. saved with the clip hotkey. nil if
+	// clips are not being buffered
+	clip     *clipwriter.ClipWriter
+	cartName string
 }
 
 // Play creates a 'playable' instance of the emulator.
@@ -52,7 +58,10 @@ type playmode struct {
 // contents of the file specified in Filename field of the Loader instance will
 // be checked. If it is a playback file then the playback codepath will be
 // used.
-func Play(tv *television.Television, scr gui.GUI, newRecording bool, cartload cartridgeloader.Loader, patchFile string, hiscoreServer bool, useSavekey bool) error {
+//
+// The clipLength argument is the number of seconds of video to keep for the
+// clip hotkey. A value of zero disables the buffering of clips.
+func Play(tv *television.Television, scr gui.GUI, newRecording bool, cartload cartridgeloader.Loader, patchFile string, hiscoreServer bool, useSavekey bool, clipLength int) error {
 	var recording string
 
 	// if supplied cartridge name is actually a playback file then set
@@ -184,6 +193,11 @@ func Play(tv *television.Television, scr gui.GUI, newRecording bool, cartload ca
 		intChan:   make(chan os.Signal, 1),
 		guiChan:   make(chan gui.Event, 10),
 		rawEvents: make(chan func(), 1024),
+		cartName:  cartload.ShortName(),
+	}
+
+	if clipLength > 0 {
+		pl.clip = clipwriter.New(tv, clipLength)
 	}
 
 	// connect gui
@@ -0,0 +1,205 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package pngwriter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// Options control which frames are written automatically by PNGWriter and
+// how the images are produced.
+type Options struct {
+	// write a single image of this frame. zero means no single frame
+	Frame int
+
+	// write every Nth frame between From and To. if Every is zero and a range
+	// has been specified then every frame in the range will be written
+	Every int
+
+	// range of frames to write. a To value of zero means there is no upper
+	// limit
+	From int
+	To   int
+
+	// crop image to the visible screen. if false the full signal, including
+	// the horizontal and vertical blanking areas, is written
+	Crop bool
+
+	// use debug colors rather than the television palette. debug colors are
+	// only available if the PNGWriter has been added to a reflection.Monitor
+	Debug bool
+
+	// apply the software CRT filter to the image. the PNGWriter must have been
+	// given a filter with the SetFilter() function
+	CRT bool
+
+	// prefix for the filenames of automatically written frames. the frame
+	// number and the png extension will be appended
+	Prefix string
+}
+
+// DefaultOptions writes no frames automatically and crops to the visible
+// screen.
+var DefaultOptions = Options{
+	Crop:   true,
+	Prefix: "screenshot",
+}
+
+// ParseOptions parses a comma separated list of options. Each option is a
+// key=value pair. Boolean options can be specified by key alone. Recognised
+// keys:
+//
+//	frame=N       write a single image of frame N
+//	every=N       write every Nth frame
+//	range=N-M     write frames from N to M (M can be omitted)
+//	crop=bool     crop image to visible screen (default true)
+//	full          same as crop=false
+//	debug=bool    use debug colors
+//	crt=bool      apply the software CRT filter
+//	name=prefix   prefix for filenames
+//
+// For example, "frame=600" or "every=10,range=100-200,full".
+func ParseOptions(s string, opts Options) (Options, error) {
+	if strings.TrimSpace(s) == "" {
+		return opts, nil
+	}
+
+	for _, o := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(o), "=", 2)
+		key := strings.ToLower(kv[0])
+
+		var val string
+		if len(kv) == 2 {
+			val = strings.TrimSpace(kv[1])
+		}
+
+		var err error
+
+		switch key {
+		case "frame":
+			opts.Frame, err = parseFrame(val)
+		case "every":
+			opts.Every, err = parseFrame(val)
+		case "range":
+			r := strings.SplitN(val, "-", 2)
+			opts.From, err = parseFrame(r[0])
+			if err == nil {
+				opts.To = 0
+				if len(r) == 2 && r[1] != "" {
+					opts.To, err = parseFrame(r[1])
+					if err == nil && opts.To < opts.From {
+						err = fmt.Errorf("range is backwards (%s)", val)
+					}
+				}
+			}
+		case "crop":
+			opts.Crop, err = parseBool(val)
+		case "full":
+			var b bool
+			b, err = parseBool(val)
+			opts.Crop = !b
+		case "debug":
+			opts.Debug, err = parseBool(val)
+		case "crt":
+			opts.CRT, err = parseBool(val)
+		case "name":
+			if val == "" {
+				err = fmt.Errorf("name cannot be empty")
+			}
+			opts.Prefix = val
+		default:
+			err = fmt.Errorf("unrecognised option (%s)", key)
+		}
+
+		if err != nil {
+			return opts, curated.Errorf("pngwriter: %v", err)
+		}
+	}
+
+	return opts, nil
+}
+
+// String returns the options in a form that can be parsed by ParseOptions().
+func (opts Options) String() string {
+	s := []string{}
+	if opts.Frame > 0 {
+		s = append(s, fmt.Sprintf("frame=%d", opts.Frame))
+	}
+	if opts.Every > 0 {
+		s = append(s, fmt.Sprintf("every=%d", opts.Every))
+	}
+	if opts.To > 0 {
+		s = append(s, fmt.Sprintf("range=%d-%d", opts.From, opts.To))
+	} else if opts.From > 0 {
+		s = append(s, fmt.Sprintf("range=%d-", opts.From))
+	}
+	s = append(s, fmt.Sprintf("crop=%v", opts.Crop))
+	s = append(s, fmt.Sprintf("debug=%v", opts.Debug))
+	s = append(s, fmt.Sprintf("crt=%v", opts.CRT))
+	s = append(s, fmt.Sprintf("name=%s", opts.Prefix))
+	return strings.Join(s, ",")
+}
+
+func parseFrame(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("not a valid frame number (%s)", s)
+	}
+	return n, nil
+}
+
+// an empty value is true so that boolean options can be specified by key alone
+func parseBool(s string) (bool, error) {
+	if s == "" {
+		return true, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("not a valid boolean (%s)", s)
+	}
+	return b, nil
+}
+
+// automatic returns true if the options specify any automatic writing of frames.
+func (opts Options) automatic() bool {
+	return opts.Frame > 0 || opts.Every > 0 || opts.From > 0 || opts.To > 0
+}
+
+// write returns true if the frame should be written automatically.
+func (opts Options) write(frame int) bool {
+	if opts.Frame > 0 && frame == opts.Frame {
+		return true
+	}
+
+	if opts.Every == 0 && opts.From == 0 && opts.To == 0 {
+		return false
+	}
+
+	if frame < opts.From || (opts.To > 0 && frame > opts.To) {
+		return false
+	}
+
+	every := opts.Every
+	if every == 0 {
+		every = 1
+	}
+
+	return (frame-opts.From)%every == 0
+}
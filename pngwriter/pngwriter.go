@@ -0,0 +1,207 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package pngwriter allows writing of television frames to disk as PNG files.
+// It does not require a GUI and so can be used to take screenshots from a
+// headless emulation.
+//
+// Frames can be written on demand with the Save() function or automatically
+// as the emulation progresses, according to the Options used. Automatic
+// writing can be a single frame, every Nth frame or a range of frames.
+package pngwriter
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+
+	"github.com/jetsetilly/gopher2600/crtfilter"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/framebuffer"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/reflection"
+)
+
+// PNGWriter implements the television.PixelRenderer and reflection.Renderer
+// interfaces.
+type PNGWriter struct {
+	tv   *television.Television
+	opts Options
+
+	// the full television signal and the same signal in debug colors
+	buf      framebuffer.Buffer
+	elements *image.RGBA
+
+	// software CRT filter. can be nil
+	filter *crtfilter.Filter
+
+	// the most recent frame written automatically with the CRT filter. the
+	// filter is reset unless frames are written consecutively so that
+	// phosphor persistence is not carried over from an unrelated frame
+	lastCRTFrame int
+}
+
+// New is the preferred method of initialisation for the PNGWriter type. The
+// PNGWriter will add itself to the television as a PixelRenderer.
+func New(tv *television.Television, opts Options) *PNGWriter {
+	w := &PNGWriter{
+		tv:   tv,
+		opts: opts,
+	}
+
+	spec := tv.GetSpec()
+	w.Resize(spec, spec.AtariSafeTop, spec.AtariSafeBottom-spec.AtariSafeTop)
+	tv.AddPixelRenderer(w)
+
+	return w
+}
+
+// SetOptions changes the options used for automatic writing of frames.
+func (w *PNGWriter) SetOptions(opts Options) {
+	w.opts = opts
+}
+
+// Options returns the options currently being used.
+func (w *PNGWriter) Options() Options {
+	return w.opts
+}
+
+// SetFilter sets the software CRT filter to use when the CRT option is set or
+// when Save() is called with the crt argument. A value of nil removes the
+// filter.
+func (w *PNGWriter) SetFilter(filter *crtfilter.Filter) {
+	w.filter = filter
+}
+
+// Save writes the current state of the television image to a PNG file.
+// Unlike frames written automatically, the image may include part of the
+// frame currently being drawn.
+func (w *PNGWriter) Save(filename string, crop bool, debug bool, crt bool) error {
+	return w.save(filename, crop, debug, crt, true)
+}
+
+func (w *PNGWriter) save(filename string, crop bool, debug bool, crt bool, resetFilter bool) (rerr error) {
+	img := w.elements
+	if !debug {
+		img = w.buf.Image()
+	}
+
+	var out image.Image = img
+	if crop {
+		out = img.SubImage(image.Rect(specification.HorizClksHBlank, w.buf.Top,
+			specification.HorizClksScanline, w.buf.Top+w.buf.Visible))
+	}
+
+	if crt {
+		if w.filter == nil {
+			return curated.Errorf("pngwriter: %v", "no CRT filter available")
+		}
+		if resetFilter {
+			w.filter.Reset()
+		}
+		out = w.filter.Apply(out)
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return curated.Errorf("pngwriter: %v", err)
+	}
+	defer func() {
+		err := f.Close()
+		if err != nil && rerr == nil {
+			rerr = curated.Errorf("pngwriter: %v", err)
+		}
+	}()
+
+	err = png.Encode(f, out)
+	if err != nil {
+		return curated.Errorf("pngwriter: %v", err)
+	}
+
+	return nil
+}
+
+// Resize implements the television.PixelRenderer interface.
+func (w *PNGWriter) Resize(spec specification.Spec, topScanline, visibleScanlines int) error {
+	if w.buf.Resize(spec, topScanline, visibleScanlines) {
+		w.elements = image.NewRGBA(image.Rect(0, 0, w.buf.Width(), w.buf.Height()))
+		w.Reset()
+	}
+
+	return nil
+}
+
+// NewFrame implements the television.PixelRenderer interface.
+func (w *PNGWriter) NewFrame(_ bool) error {
+	if !w.opts.automatic() {
+		return nil
+	}
+
+	// the television has already moved onto the next frame. the image we have
+	// is of the previous frame
+	frame := w.tv.GetState(signal.ReqFramenum) - 1
+
+	if w.opts.write(frame) {
+		reset := frame != w.lastCRTFrame+1
+		w.lastCRTFrame = frame
+		return w.save(fmt.Sprintf("%s_%06d.png", w.opts.Prefix, frame), w.opts.Crop, w.opts.Debug, w.opts.CRT, reset)
+	}
+
+	return nil
+}
+
+// NewScanline implements the television.PixelRenderer interface.
+func (w *PNGWriter) NewScanline(_ int) error {
+	return nil
+}
+
+// UpdatingPixels implements the television.PixelRenderer interface.
+func (w *PNGWriter) UpdatingPixels(_ bool) {
+}
+
+// SetPixel implements the television.PixelRenderer interface.
+func (w *PNGWriter) SetPixel(sig signal.SignalAttributes, _ bool) error {
+	w.buf.SetPixel(sig)
+
+	return nil
+}
+
+// Reset implements the television.PixelRenderer interface.
+func (w *PNGWriter) Reset() {
+	w.buf.Reset()
+
+	black := color.RGBA{A: 255}
+	b := w.elements.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			w.elements.SetRGBA(x, y, black)
+		}
+	}
+}
+
+// EndRendering implements the television.PixelRenderer interface.
+func (w *PNGWriter) EndRendering() error {
+	return nil
+}
+
+// Reflect implements the reflection.Renderer interface.
+func (w *PNGWriter) Reflect(ref reflection.Reflection) error {
+	w.elements.SetRGBA(ref.TV.HorizPos, ref.TV.Scanline, reflection.PaletteElements[ref.VideoElement])
+	return nil
+}
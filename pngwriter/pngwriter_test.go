@@ -0,0 +1,150 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package pngwriter
+
+import (
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/crtfilter"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+func TestParseOptions(t *testing.T) {
+	opts, err := ParseOptions("frame=600", DefaultOptions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Frame != 600 || !opts.Crop || opts.Prefix != DefaultOptions.Prefix {
+		t.Errorf("unexpected options (%s)", opts)
+	}
+
+	opts, err = ParseOptions("every=10,range=100-200,full,debug,name=foo", DefaultOptions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Every != 10 || opts.From != 100 || opts.To != 200 || opts.Crop || !opts.Debug || opts.Prefix != "foo" {
+		t.Errorf("unexpected options (%s)", opts)
+	}
+
+	// the string form of the options should parse to the same options
+	p, err := ParseOptions(opts.String(), DefaultOptions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p != opts {
+		t.Errorf("options did not survive round trip (%s != %s)", p, opts)
+	}
+
+	for _, s := range []string{"frame=x", "range=200-100", "crop=maybe", "name=", "foo=1"} {
+		if _, err := ParseOptions(s, DefaultOptions); err == nil {
+			t.Errorf("expected error for %s", s)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	type test struct {
+		opts   string
+		frames []int
+	}
+
+	tests := []test{
+		{opts: "", frames: []int{}},
+		{opts: "frame=5", frames: []int{5}},
+		{opts: "every=4", frames: []int{0, 4, 8}},
+		{opts: "range=3-5", frames: []int{3, 4, 5}},
+		{opts: "range=8-", frames: []int{8, 9}},
+		{opts: "every=3,range=2-8,frame=1", frames: []int{1, 2, 5, 8}},
+	}
+
+	for _, tst := range tests {
+		opts, err := ParseOptions(tst.opts, DefaultOptions)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		frames := []int{}
+		for f := 0; f < 10; f++ {
+			if opts.write(f) {
+				frames = append(frames, f)
+			}
+		}
+
+		if len(frames) != len(tst.frames) {
+			t.Errorf("%s: unexpected frames %v", tst.opts, frames)
+			continue
+		}
+		for i := range frames {
+			if frames[i] != tst.frames[i] {
+				t.Errorf("%s: unexpected frames %v", tst.opts, frames)
+				break
+			}
+		}
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pngwriter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// not using New() because we don't need a television for this test
+	w := &PNGWriter{opts: DefaultOptions}
+	spec := specification.SpecPAL
+	_ = w.Resize(spec, spec.AtariSafeTop, spec.AtariSafeBottom-spec.AtariSafeTop)
+
+	check := func(crop bool, crt bool, width int, height int) {
+		fn := filepath.Join(dir, "test.png")
+		if err := w.Save(fn, crop, false, crt); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		f, err := os.Open(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		img, err := png.Decode(f)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if img.Bounds().Dx() != width || img.Bounds().Dy() != height {
+			t.Errorf("unexpected image size (%v)", img.Bounds())
+		}
+	}
+
+	check(true, false, specification.HorizClksVisible, spec.AtariSafeBottom-spec.AtariSafeTop)
+	check(false, false, specification.HorizClksScanline, spec.ScanlinesTotal)
+
+	// the CRT filter is not available until SetFilter() has been called
+	if err := w.Save(filepath.Join(dir, "test.png"), true, false, true); err == nil {
+		t.Errorf("expected error when saving with CRT filter but no filter set")
+	}
+
+	p := &crtfilter.Preferences{}
+	p.SetDefaults()
+	w.SetFilter(crtfilter.NewFilter(p))
+	check(true, true, specification.HorizClksVisible*crtfilter.PixelWidth,
+		(spec.AtariSafeBottom-spec.AtariSafeTop)*crtfilter.PixelHeight)
+}
@@ -0,0 +1,40 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package profiler attributes the CPU cycles consumed by the emulated
+// cartridge to the labels and subroutines in the programme. The Profiler type
+// should be stepped after every CPU instruction, along with the television
+// coordinates at the end of the instruction.
+//
+// The number of cycles consumed by an instruction is measured from the
+// difference between the television coordinates of successive calls to
+// Step(). Any cycles over and above the cycles reported by the CPU are the
+// cycles spent waiting for WSYNC. The cycles are attributed to the scanline
+// on which the instruction started.
+//
+// Cycles are attributed to the nearest preceding label in the symbols table.
+// If there is no suitable label the address of the subroutine being executed
+// is used instead. The call graph is built by watching for JSR and RTS
+// instructions (and also BRK and RTI).
+//
+// Each frame is divided into regions according to the television
+// specification and the cycle usage of each region can be compared against
+// the budget for that region. Note that cycles spent in a loop waiting for the
+// RIOT timer are counted as used cycles; only cycles spent waiting for WSYNC
+// are counted as idle.
+//
+// The cumulative profile can be exported in the pprof format with the
+// WritePProf() function and viewed with the "go tool pprof" command.
+package profiler
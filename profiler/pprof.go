@@ -0,0 +1,289 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package profiler
+
+import (
+	"compress/gzip"
+	"io"
+	"sort"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// the pprof format is a gzipped protocol buffer. the message definition can be
+// found in the pprof repository:
+//
+// https://github.com/google/pprof/blob/master/proto/profile.proto
+//
+// we only need a small part of the protocol buffer encoding so rather than
+// import a protobuf library we encode the messages by hand.
+
+// field numbers of the Profile message.
+const (
+	pbProfileSampleType   = 1
+	pbProfileSample       = 2
+	pbProfileMapping      = 3
+	pbProfileLocation     = 4
+	pbProfileFunction     = 5
+	pbProfileStringTable  = 6
+	pbProfilePeriodType   = 11
+	pbProfilePeriod       = 12
+	pbProfileDefaultValue = 14
+)
+
+// field numbers of the sub-messages.
+const (
+	pbValueTypeType = 1
+	pbValueTypeUnit = 2
+
+	pbSampleLocationID = 1
+	pbSampleValue      = 2
+	pbSampleLabel      = 3
+
+	pbLabelKey = 1
+	pbLabelStr = 2
+
+	pbMappingID           = 1
+	pbMappingMemoryStart  = 2
+	pbMappingMemoryLimit  = 3
+	pbMappingFilename     = 5
+	pbMappingHasFunctions = 7
+	pbMappingHasLines     = 9
+
+	pbLocationID        = 1
+	pbLocationMappingID = 2
+	pbLocationAddress   = 3
+	pbLocationLine      = 4
+
+	pbLineFunctionID = 1
+	pbLineLine       = 2
+
+	pbFunctionID   = 1
+	pbFunctionName = 2
+)
+
+// protobuf wire types.
+const (
+	pbVarint = 0
+	pbBytes  = 2
+)
+
+type pbuf struct {
+	data []byte
+}
+
+func (b *pbuf) varint(v uint64) {
+	for v >= 0x80 {
+		b.data = append(b.data, byte(v)|0x80)
+		v >>= 7
+	}
+	b.data = append(b.data, byte(v))
+}
+
+func (b *pbuf) key(field int, wire int) {
+	b.varint(uint64(field)<<3 | uint64(wire))
+}
+
+func (b *pbuf) uint64(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+	b.key(field, pbVarint)
+	b.varint(v)
+}
+
+func (b *pbuf) int64(field int, v int64) {
+	b.uint64(field, uint64(v))
+}
+
+func (b *pbuf) bool(field int, v bool) {
+	if v {
+		b.uint64(field, 1)
+	}
+}
+
+func (b *pbuf) bytes(field int, v []byte) {
+	b.key(field, pbBytes)
+	b.varint(uint64(len(v)))
+	b.data = append(b.data, v...)
+}
+
+func (b *pbuf) message(field int, m *pbuf) {
+	b.bytes(field, m.data)
+}
+
+func (b *pbuf) packed(field int, v []uint64) {
+	if len(v) == 0 {
+		return
+	}
+	p := &pbuf{}
+	for _, x := range v {
+		p.varint(x)
+	}
+	b.bytes(field, p.data)
+}
+
+// the string table of a pprof profile. the first entry must be the empty
+// string.
+type stringTable struct {
+	strings []string
+	index   map[string]int64
+}
+
+func newStringTable() *stringTable {
+	return &stringTable{
+		strings: []string{""},
+		index:   map[string]int64{"": 0},
+	}
+}
+
+func (st *stringTable) id(s string) int64 {
+	if i, ok := st.index[s]; ok {
+		return i
+	}
+	i := int64(len(st.strings))
+	st.strings = append(st.strings, s)
+	st.index[s] = i
+	return i
+}
+
+// WritePProf writes the cumulative profile in the pprof format. There are two
+// sample values: the number of cycles and the number of those cycles that
+// were spent waiting for WSYNC. Each sample is labelled with the television
+// region in which it occurred.
+//
+// The filename argument is used to name the mapping in the profile and will
+// usually be the cartridge filename.
+func (prof *Profiler) WritePProf(output io.Writer, filename string) error {
+	st := newStringTable()
+	pb := &pbuf{}
+
+	valueType := func(field int, typ string, unit string) {
+		m := &pbuf{}
+		m.int64(pbValueTypeType, st.id(typ))
+		m.int64(pbValueTypeUnit, st.id(unit))
+		pb.message(field, m)
+	}
+
+	valueType(pbProfileSampleType, "cycles", "count")
+	valueType(pbProfileSampleType, "wsync", "count")
+
+	// a single mapping covering the entire address space. the profile is
+	// already symbolised
+	m := &pbuf{}
+	m.uint64(pbMappingID, 1)
+	m.uint64(pbMappingMemoryStart, 0)
+	m.uint64(pbMappingMemoryLimit, 0x10000)
+	m.int64(pbMappingFilename, st.id(filename))
+	m.bool(pbMappingHasFunctions, true)
+	m.bool(pbMappingHasLines, true)
+	pb.message(pbProfileMapping, m)
+
+	functions := make(map[string]uint64)
+	locations := make(map[location]uint64)
+
+	// sort samples so that the output is stable
+	keys := make([]string, 0, len(prof.samples))
+	for k := range prof.samples {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// functions and locations are written after the samples but it's
+	// convenient to build them while writing the samples
+	fnbuf := &pbuf{}
+	locbuf := &pbuf{}
+
+	for _, k := range keys {
+		s := prof.samples[k]
+
+		ids := make([]uint64, 0, len(s.stack))
+		for _, l := range s.stack {
+			fid, ok := functions[l.function]
+			if !ok {
+				fid = uint64(len(functions) + 1)
+				functions[l.function] = fid
+
+				f := &pbuf{}
+				f.uint64(pbFunctionID, fid)
+				f.int64(pbFunctionName, st.id(l.function))
+				fnbuf.message(pbProfileFunction, f)
+			}
+
+			lid, ok := locations[l]
+			if !ok {
+				lid = uint64(len(locations) + 1)
+				locations[l] = lid
+
+				// the address of the instruction is used as the line number.
+				// this means the -lines option to pprof will show individual
+				// instructions
+				ln := &pbuf{}
+				ln.uint64(pbLineFunctionID, fid)
+				ln.int64(pbLineLine, int64(l.address))
+
+				loc := &pbuf{}
+				loc.uint64(pbLocationID, lid)
+				loc.uint64(pbLocationMappingID, 1)
+				loc.uint64(pbLocationAddress, uint64(l.address))
+				loc.message(pbLocationLine, ln)
+				locbuf.message(pbProfileLocation, loc)
+			}
+
+			ids = append(ids, lid)
+		}
+
+		lbl := &pbuf{}
+		lbl.int64(pbLabelKey, st.id("region"))
+		lbl.int64(pbLabelStr, st.id(s.region.String()))
+
+		smp := &pbuf{}
+		smp.packed(pbSampleLocationID, ids)
+		smp.packed(pbSampleValue, []uint64{uint64(s.cycles), uint64(s.wsync)})
+		smp.message(pbSampleLabel, lbl)
+		pb.message(pbProfileSample, smp)
+	}
+
+	pb.data = append(pb.data, locbuf.data...)
+	pb.data = append(pb.data, fnbuf.data...)
+
+	// period is one cycle
+	pt := &pbuf{}
+	pt.int64(pbValueTypeType, st.id("cycles"))
+	pt.int64(pbValueTypeUnit, st.id("count"))
+	pb.message(pbProfilePeriodType, pt)
+	pb.int64(pbProfilePeriod, 1)
+
+	pb.int64(pbProfileDefaultValue, st.id("cycles"))
+
+	// string table must be complete by this point
+	for _, s := range st.strings {
+		pb.bytes(pbProfileStringTable, []byte(s))
+	}
+
+	zw := gzip.NewWriter(output)
+	_, err := zw.Write(pb.data)
+	if err != nil {
+		return curated.Errorf("profiler: %v", err)
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return curated.Errorf("profiler: %v", err)
+	}
+
+	return nil
+}
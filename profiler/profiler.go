@@ -0,0 +1,425 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package profiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/symbols"
+)
+
+// MaxFrames is the number of frames kept by the profiler. Older frames are
+// discarded but continue to contribute to the cumulative profile.
+const MaxFrames = 120
+
+// the maximum depth of the call stack. some programmes manipulate the stack
+// directly meaning that not every JSR is matched by an RTS. we don't want the
+// stack to grow forever in those instances.
+const maxCallDepth = 32
+
+// cycles per scanline.
+const cyclesPerScanline = specification.HorizClksScanline / 3
+
+// Region of the television frame, as defined by the television specification.
+type Region int
+
+// List of valid Region values.
+const (
+	RegionVSync Region = iota
+	RegionVBlank
+	RegionVisible
+	RegionOverscan
+	NumRegions
+)
+
+func (r Region) String() string {
+	switch r {
+	case RegionVSync:
+		return "vsync"
+	case RegionVBlank:
+		return "vblank"
+	case RegionVisible:
+		return "visible"
+	case RegionOverscan:
+		return "overscan"
+	}
+	return ""
+}
+
+// Scanline records the CPU cycles consumed during a single scanline.
+type Scanline struct {
+	// the number of cycles consumed by the instructions that started on this
+	// scanline. includes the WSYNC cycles
+	Cycles int
+
+	// the number of cycles spent waiting for WSYNC
+	WSYNC int
+
+	// cycles attributed to each label
+	Labels map[string]int
+}
+
+// Frame records the CPU cycles consumed during a single television frame.
+type Frame struct {
+	FrameNum int
+
+	// indexed by scanline number. there may be more or fewer scanlines than
+	// required by the television specification
+	Scanlines []Scanline
+
+	// cycles and WSYNC cycles summed for each region
+	Cycles [NumRegions]int
+	WSYNC  [NumRegions]int
+}
+
+// Used returns the number of cycles in the region that were not spent waiting
+// for WSYNC.
+func (fr *Frame) Used(r Region) int {
+	return fr.Cycles[r] - fr.WSYNC[r]
+}
+
+func (fr *Frame) scanline(sl int) *Scanline {
+	for len(fr.Scanlines) <= sl {
+		fr.Scanlines = append(fr.Scanlines, Scanline{Labels: make(map[string]int)})
+	}
+	return &fr.Scanlines[sl]
+}
+
+// an entry in the call stack.
+type callFrame struct {
+	// address of the JSR (or BRK) instruction
+	callsite uint16
+
+	// the address jumped to
+	entry uint16
+}
+
+// a sample is the cumulative cycle count for a unique call stack.
+type sample struct {
+	// leaf first, as required by pprof
+	stack  []location
+	region Region
+	cycles int
+	wsync  int
+}
+
+// a location is an address and the function (label) it has been attributed
+// to.
+type location struct {
+	address  uint16
+	function string
+}
+
+// an edge in the call graph.
+type edge struct {
+	caller string
+	callee string
+}
+
+// Coords are the television coordinates at the end of an instruction.
+type coords struct {
+	frame    int
+	scanline int
+	horizpos int
+}
+
+// Profiler attributes CPU cycles to labels and to television scanlines.
+type Profiler struct {
+	spec specification.Spec
+	sym  *symbols.Symbols
+
+	// the most recent frames, oldest first. does not include the current
+	// frame
+	frames []*Frame
+
+	// the frame currently being profiled
+	current *Frame
+
+	// the call stack. innermost call last
+	stack []callFrame
+
+	// the cumulative profile
+	samples map[string]*sample
+	calls   map[edge]int
+
+	prev    coords
+	hasPrev bool
+}
+
+// NewProfiler is the preferred method of initialisation for the Profiler
+// type. The symbols argument can be nil.
+func NewProfiler(spec specification.Spec, sym *symbols.Symbols) *Profiler {
+	prof := &Profiler{
+		spec: spec,
+		sym:  sym,
+	}
+
+	prof.Reset()
+
+	return prof
+}
+
+// Reset discards all profiling information.
+func (prof *Profiler) Reset() {
+	prof.frames = prof.frames[:0]
+	prof.current = nil
+	prof.stack = prof.stack[:0]
+	prof.samples = make(map[string]*sample)
+	prof.calls = make(map[edge]int)
+	prof.hasPrev = false
+}
+
+// Step should be called after every CPU instruction. The frame, scanline and
+// horizpos arguments are the television coordinates at the end of the
+// instruction, including any WSYNC wait.
+func (prof *Profiler) Step(result execution.Result, frame int, scanline int, horizpos int) {
+	if result.Defn == nil || !result.Final {
+		return
+	}
+
+	curr := coords{frame: frame, scanline: scanline, horizpos: horizpos}
+
+	// the instruction began at the coordinates recorded at the end of the
+	// previous instruction. if there is no previous instruction then we
+	// can't attribute the cycles to a scanline
+	if prof.hasPrev {
+		cycles, ok := prof.elapsed(curr)
+		if !ok || cycles < result.Cycles {
+			cycles = result.Cycles
+		}
+		prof.attribute(result.Address, prof.prev, cycles, cycles-result.Cycles)
+	}
+
+	prof.prev = curr
+	prof.hasPrev = true
+
+	prof.updateStack(result)
+}
+
+// elapsed returns the number of CPU cycles between the previous coordinates
+// and the current coordinates. returns false if the coordinates are not
+// consistent with a single instruction, for example after a rewind.
+func (prof *Profiler) elapsed(curr coords) (int, bool) {
+	clks := curr.horizpos - prof.prev.horizpos
+
+	// a single instruction (including a WSYNC wait) will never cross more
+	// than one scanline
+	switch {
+	case curr.frame == prof.prev.frame:
+		switch curr.scanline - prof.prev.scanline {
+		case 0:
+		case 1:
+			clks += specification.HorizClksScanline
+		default:
+			return 0, false
+		}
+	case curr.frame == prof.prev.frame+1 && curr.scanline == 0:
+		clks += specification.HorizClksScanline
+	default:
+		return 0, false
+	}
+
+	if clks < 0 {
+		return 0, false
+	}
+
+	return clks / 3, true
+}
+
+func (prof *Profiler) region(scanline int) Region {
+	switch {
+	case scanline < prof.spec.ScanlinesVSync:
+		return RegionVSync
+	case scanline < prof.spec.AtariSafeTop:
+		return RegionVBlank
+	case scanline < prof.spec.AtariSafeBottom:
+		return RegionVisible
+	}
+	return RegionOverscan
+}
+
+func (prof *Profiler) attribute(address uint16, at coords, cycles int, wsync int) {
+	if prof.current == nil || prof.current.FrameNum != at.frame {
+		if prof.current != nil {
+			prof.frames = append(prof.frames, prof.current)
+			if len(prof.frames) > MaxFrames {
+				prof.frames = prof.frames[1:]
+			}
+		}
+		prof.current = &Frame{FrameNum: at.frame}
+	}
+
+	stack := prof.callStack(address)
+	region := prof.region(at.scanline)
+
+	// per scanline profile
+	sl := prof.current.scanline(at.scanline)
+	sl.Cycles += cycles
+	sl.WSYNC += wsync
+	sl.Labels[stack[0].function] += cycles
+	prof.current.Cycles[region] += cycles
+	prof.current.WSYNC[region] += wsync
+
+	// cumulative profile
+	key := strings.Builder{}
+	key.WriteString(region.String())
+	for _, l := range stack {
+		key.WriteString(fmt.Sprintf(":%04x", l.address))
+	}
+
+	s, ok := prof.samples[key.String()]
+	if !ok {
+		s = &sample{stack: stack, region: region}
+		prof.samples[key.String()] = s
+	}
+	s.cycles += cycles
+	s.wsync += wsync
+}
+
+// callStack returns the locations of the instruction at address and of each
+// of the callsites in the call stack. the instruction's location is first.
+func (prof *Profiler) callStack(address uint16) []location {
+	stack := make([]location, 0, len(prof.stack)+1)
+
+	entry, hasEntry := prof.entry(len(prof.stack) - 1)
+	stack = append(stack, location{address: address, function: prof.function(address, entry, hasEntry)})
+
+	for i := len(prof.stack) - 1; i >= 0; i-- {
+		entry, hasEntry := prof.entry(i - 1)
+		a := prof.stack[i].callsite
+		stack = append(stack, location{address: a, function: prof.function(a, entry, hasEntry)})
+	}
+
+	return stack
+}
+
+// entry returns the entry address of the subroutine at the stack depth.
+func (prof *Profiler) entry(depth int) (uint16, bool) {
+	if depth < 0 || depth >= len(prof.stack) {
+		return 0, false
+	}
+	return prof.stack[depth].entry, true
+}
+
+// function returns the name of the function containing the address. the
+// nearest preceding label is used unless that label precedes the entry point
+// of the subroutine. in that case the subroutine address is used.
+func (prof *Profiler) function(address uint16, entry uint16, hasEntry bool) string {
+	var name string
+	var la uint16
+	var ok bool
+	if prof.sym != nil {
+		name, la, ok = prof.sym.NearestLabel(address)
+	}
+
+	if hasEntry {
+		me, _ := memorymap.MapAddress(entry, true)
+		if ok && la >= me {
+			return name
+		}
+		return fmt.Sprintf("$%04x", entry)
+	}
+
+	if ok {
+		return name
+	}
+
+	return "main"
+}
+
+func (prof *Profiler) updateStack(result execution.Result) {
+	switch result.Defn.Effect {
+	case instructions.Subroutine:
+		if result.Defn.Mnemonic == "JSR" {
+			prof.call(result.Address, result.InstructionData)
+		} else {
+			prof.ret()
+		}
+	case instructions.Interrupt:
+		if result.Defn.Mnemonic == "BRK" {
+			// the entry address of an interrupt is not known by the result.
+			// we use the address of the BRK instruction instead
+			prof.call(result.Address, result.Address)
+		} else {
+			prof.ret()
+		}
+	}
+}
+
+func (prof *Profiler) call(callsite uint16, entry uint16) {
+	caller, hasCaller := prof.entry(len(prof.stack) - 1)
+	e := edge{
+		caller: prof.function(callsite, caller, hasCaller),
+		callee: prof.function(entry, entry, true),
+	}
+	prof.calls[e]++
+
+	prof.stack = append(prof.stack, callFrame{callsite: callsite, entry: entry})
+	if len(prof.stack) > maxCallDepth {
+		prof.stack = prof.stack[1:]
+	}
+}
+
+func (prof *Profiler) ret() {
+	if len(prof.stack) > 0 {
+		prof.stack = prof.stack[:len(prof.stack)-1]
+	}
+}
+
+// Frame returns the profile for the frame number. Returns false if the frame
+// is not available.
+//
+// The returned frame should not be altered.
+func (prof *Profiler) Frame(frameNum int) (*Frame, bool) {
+	for _, fr := range prof.frames {
+		if fr.FrameNum == frameNum {
+			return fr, true
+		}
+	}
+	return nil, false
+}
+
+// LastFrame returns the most recent complete frame. Returns false if no frame
+// has been completed.
+//
+// The returned frame should not be altered.
+func (prof *Profiler) LastFrame() (*Frame, bool) {
+	if len(prof.frames) == 0 {
+		return nil, false
+	}
+	return prof.frames[len(prof.frames)-1], true
+}
+
+// Budget returns the number of CPU cycles available in the region according
+// to the television specification.
+func (prof *Profiler) Budget(r Region) int {
+	switch r {
+	case RegionVSync:
+		return prof.spec.ScanlinesVSync * cyclesPerScanline
+	case RegionVBlank:
+		return prof.spec.ScanlinesVBlank * cyclesPerScanline
+	case RegionVisible:
+		return prof.spec.ScanlinesVisible * cyclesPerScanline
+	case RegionOverscan:
+		return prof.spec.ScanlinesOverscan * cyclesPerScanline
+	}
+	return 0
+}
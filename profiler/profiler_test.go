@@ -0,0 +1,150 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package profiler_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/profiler"
+)
+
+// a minimal emulation of the television coordinates.
+type mockTV struct {
+	frame    int
+	scanline int
+	horizpos int
+}
+
+func (tv *mockTV) advance(clks int) {
+	tv.horizpos += clks
+	for tv.horizpos >= specification.HorizClksScanline {
+		tv.horizpos -= specification.HorizClksScanline
+		tv.scanline++
+		if tv.scanline >= specification.SpecNTSC.ScanlinesTotal {
+			tv.scanline = 0
+			tv.frame++
+		}
+	}
+}
+
+func (tv *mockTV) wsync() {
+	if tv.horizpos > 0 {
+		tv.advance(specification.HorizClksScanline - tv.horizpos)
+	}
+}
+
+func defn(opcode uint8) *instructions.Definition {
+	return instructions.GetDefinitions()[opcode]
+}
+
+func step(prof *profiler.Profiler, tv *mockTV, address uint16, opcode uint8, data uint16, wsync bool) {
+	d := defn(opcode)
+	tv.advance(d.Cycles * 3)
+	if wsync {
+		tv.wsync()
+	}
+	prof.Step(execution.Result{
+		Defn:            d,
+		Address:         address,
+		InstructionData: data,
+		Cycles:          d.Cycles,
+		Final:           true,
+	}, tv.frame, tv.scanline, tv.horizpos)
+}
+
+func TestProfiler(t *testing.T) {
+	prof := profiler.NewProfiler(specification.SpecNTSC, nil)
+	tv := &mockTV{}
+
+	// first instruction is never attributed because there are no previous
+	// coordinates
+	step(prof, tv, 0xf000, 0xea, 0, false)
+
+	// every scanline of the frame: JSR, NOP, RTS, STA WSYNC
+	for tv.frame == 0 {
+		step(prof, tv, 0xf001, 0x20, 0xf100, false)
+		step(prof, tv, 0xf100, 0xea, 0, false)
+		step(prof, tv, 0xf101, 0x60, 0, false)
+		step(prof, tv, 0xf004, 0x85, 0x02, true)
+	}
+
+	// frame zero is complete once we've stepped into frame one
+	step(prof, tv, 0xf000, 0xea, 0, false)
+
+	fr, ok := prof.LastFrame()
+	if !ok {
+		t.Fatalf("expected a complete frame")
+	}
+	if fr.FrameNum != 0 {
+		t.Errorf("expected frame 0, got frame %d", fr.FrameNum)
+	}
+
+	// scanline zero is missing the first NOP
+	if fr.Scanlines[0].Cycles != 76-2 {
+		t.Errorf("expected %d cycles on scanline 0, got %d", 76-2, fr.Scanlines[0].Cycles)
+	}
+
+	// JSR (6) + NOP (2) + RTS (6) + STA (3) = 17
+	sl := fr.Scanlines[100]
+	if sl.Cycles != 76 {
+		t.Errorf("expected 76 cycles on scanline 100, got %d", sl.Cycles)
+	}
+	if sl.WSYNC != 76-17 {
+		t.Errorf("expected %d WSYNC cycles on scanline 100, got %d", 76-17, sl.WSYNC)
+	}
+	if sl.Labels["$f100"] != 8 {
+		t.Errorf("expected 8 cycles attributed to subroutine, got %d", sl.Labels["$f100"])
+	}
+	if sl.Labels["main"] != 68 {
+		t.Errorf("expected 68 cycles attributed to main, got %d", sl.Labels["main"])
+	}
+
+	// every scanline uses 17 cycles
+	if fr.Used(profiler.RegionVBlank) != specification.SpecNTSC.ScanlinesVBlank*17 {
+		t.Errorf("unexpected vblank usage (%d)", fr.Used(profiler.RegionVBlank))
+	}
+	if prof.Budget(profiler.RegionOverscan) != specification.SpecNTSC.ScanlinesOverscan*76 {
+		t.Errorf("unexpected overscan budget (%d)", prof.Budget(profiler.RegionOverscan))
+	}
+
+	// call graph
+	fns := prof.Functions()
+	if len(fns) != 2 {
+		t.Fatalf("expected two functions, got %d", len(fns))
+	}
+	if fns[0].Function != "main" || fns[1].Function != "$f100" {
+		t.Errorf("unexpected functions (%s, %s)", fns[0].Function, fns[1].Function)
+	}
+	if fns[0].Inclusive != fns[0].Self+fns[1].Self {
+		t.Errorf("inclusive cycles for main should include the subroutine")
+	}
+
+	// pprof output should be a valid gzip stream
+	var b bytes.Buffer
+	err := prof.WritePProf(&b, "test.bin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = gzip.NewReader(&b)
+	if err != nil {
+		t.Errorf("pprof output is not gzipped: %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package profiler
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// FunctionSummary is the cumulative profile of a single function (label).
+type FunctionSummary struct {
+	Function string
+
+	// cycles consumed by the function itself, including WSYNC
+	Self int
+
+	// cycles consumed by the function and every function it calls
+	Inclusive int
+
+	// cycles spent waiting for WSYNC by the function itself
+	WSYNC int
+}
+
+// Functions returns the cumulative profile of every function, sorted by the
+// number of Self cycles.
+func (prof *Profiler) Functions() []FunctionSummary {
+	fns := make(map[string]*FunctionSummary)
+
+	get := func(n string) *FunctionSummary {
+		if f, ok := fns[n]; ok {
+			return f
+		}
+		f := &FunctionSummary{Function: n}
+		fns[n] = f
+		return f
+	}
+
+	for _, s := range prof.samples {
+		f := get(s.stack[0].function)
+		f.Self += s.cycles
+		f.WSYNC += s.wsync
+
+		// a function should only be counted once per sample, even if it
+		// appears in the stack more than once (ie. recursion)
+		seen := make(map[string]bool)
+		for _, l := range s.stack {
+			if !seen[l.function] {
+				get(l.function).Inclusive += s.cycles
+				seen[l.function] = true
+			}
+		}
+	}
+
+	l := make([]FunctionSummary, 0, len(fns))
+	for _, f := range fns {
+		l = append(l, *f)
+	}
+
+	sort.Slice(l, func(i, j int) bool {
+		if l[i].Self == l[j].Self {
+			return l[i].Function < l[j].Function
+		}
+		return l[i].Self > l[j].Self
+	})
+
+	return l
+}
+
+// WriteSummary writes the region budget averaged over the most recent frames
+// and the top functions by cycle count.
+func (prof *Profiler) WriteSummary(output io.Writer, top int) {
+	output.Write([]byte(fmt.Sprintf("%d frames (%s)\n", len(prof.frames), prof.spec.ID)))
+
+	if len(prof.frames) > 0 {
+		var cycles [NumRegions]int
+		var wsync [NumRegions]int
+		for _, fr := range prof.frames {
+			for r := Region(0); r < NumRegions; r++ {
+				cycles[r] += fr.Cycles[r]
+				wsync[r] += fr.WSYNC[r]
+			}
+		}
+
+		output.Write([]byte("average per frame:\n"))
+		for r := Region(0); r < NumRegions; r++ {
+			used := (cycles[r] - wsync[r]) / len(prof.frames)
+			prof.writeBudget(output, r, used, wsync[r]/len(prof.frames))
+		}
+	}
+
+	fns := prof.Functions()
+	if len(fns) == 0 {
+		return
+	}
+
+	total := 0
+	for _, f := range fns {
+		total += f.Self
+	}
+
+	if top < len(fns) {
+		fns = fns[:top]
+	}
+
+	output.Write([]byte("top functions:\n"))
+	output.Write([]byte(fmt.Sprintf("  %10s %6s %10s %10s  %s\n", "self", "", "wsync", "inclusive", "function")))
+	for _, f := range fns {
+		output.Write([]byte(fmt.Sprintf("  %10d %5.1f%% %10d %10d  %s\n",
+			f.Self, float64(f.Self)/float64(total)*100, f.WSYNC, f.Inclusive, f.Function)))
+	}
+}
+
+func (prof *Profiler) writeBudget(output io.Writer, r Region, used int, wsync int) {
+	budget := prof.Budget(r)
+	pct := 0.0
+	if budget > 0 {
+		pct = float64(used) / float64(budget) * 100
+	}
+	output.Write([]byte(fmt.Sprintf("  %-8s used %5d of %5d cycles (%5.1f%%) wsync %5d\n",
+		r, used, budget, pct, wsync)))
+}
+
+// WriteFrame writes the profile for a single frame, scanline by scanline.
+func (prof *Profiler) WriteFrame(output io.Writer, fr *Frame) {
+	total := 0
+	wsync := 0
+	for r := Region(0); r < NumRegions; r++ {
+		total += fr.Cycles[r]
+		wsync += fr.WSYNC[r]
+	}
+
+	output.Write([]byte(fmt.Sprintf("frame %d: %d scanlines %d cycles (wsync %d)\n",
+		fr.FrameNum, len(fr.Scanlines), total, wsync)))
+
+	for r := Region(0); r < NumRegions; r++ {
+		prof.writeBudget(output, r, fr.Used(r), fr.WSYNC[r])
+	}
+
+	output.Write([]byte(fmt.Sprintf("  %3s %-8s %6s %6s  %s\n", "sl", "region", "cycles", "wsync", "functions")))
+	for i, sl := range fr.Scanlines {
+		if sl.Cycles == 0 {
+			continue
+		}
+
+		// functions for the scanline sorted by cycle count
+		fns := make([]string, 0, len(sl.Labels))
+		for n := range sl.Labels {
+			fns = append(fns, n)
+		}
+		sort.Slice(fns, func(i, j int) bool {
+			if sl.Labels[fns[i]] == sl.Labels[fns[j]] {
+				return fns[i] < fns[j]
+			}
+			return sl.Labels[fns[i]] > sl.Labels[fns[j]]
+		})
+
+		s := strings.Builder{}
+		for _, n := range fns {
+			s.WriteString(fmt.Sprintf(" %s(%d)", n, sl.Labels[n]))
+		}
+
+		output.Write([]byte(fmt.Sprintf("  %03d %-8s %6d %6d %s\n",
+			i, prof.region(i), sl.Cycles, sl.WSYNC, s.String())))
+	}
+}
+
+// WriteCallGraph writes every edge of the call graph along with the number of
+// times the call was made.
+func (prof *Profiler) WriteCallGraph(output io.Writer) {
+	edges := make([]edge, 0, len(prof.calls))
+	for e := range prof.calls {
+		edges = append(edges, e)
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].caller == edges[j].caller {
+			return edges[i].callee < edges[j].callee
+		}
+		return edges[i].caller < edges[j].caller
+	})
+
+	for _, e := range edges {
+		output.Write([]byte(fmt.Sprintf("%s -> %s (%d calls)\n", e.caller, e.callee, prof.calls[e])))
+	}
+}
@@ -26,7 +26,7 @@ import (
 // video cycle with a populated instance of LastResult.
 type Monitor struct {
 	vcs        *hardware.VCS
-	renderer   Renderer
+	renderers  []Renderer
 	history    [television.MaxSignalHistory]Reflection
 	historyIdx int
 }
@@ -34,11 +34,17 @@ type Monitor struct {
 // NewMonitor is the preferred method of initialisation for the Monitor type.
 func NewMonitor(vcs *hardware.VCS, renderer Renderer) *Monitor {
 	return &Monitor{
-		vcs:      vcs,
-		renderer: renderer,
+		vcs:       vcs,
+		renderers: []Renderer{renderer},
 	}
 }
 
+// AddRenderer registers an additional reflection.Renderer. Multiple renderers
+// can be added.
+func (mon *Monitor) AddRenderer(renderer Renderer) {
+	mon.renderers = append(mon.renderers, renderer)
+}
+
 // Check should be called every video cycle to record the current state of the
 // emulation/system.
 func (mon *Monitor) Check(bank mapper.BankInfo) error {
@@ -73,8 +79,10 @@ func (mon *Monitor) Check(bank mapper.BankInfo) error {
 
 // SyncReflectionPixel implements the television.ReflectionSynchronising interface.
 func (mon *Monitor) SyncReflectionPixel(idx int) error {
-	if err := mon.renderer.Reflect(mon.history[idx]); err != nil {
-		return err
+	for _, r := range mon.renderers {
+		if err := r.Reflect(mon.history[idx]); err != nil {
+			return err
+		}
 	}
 	mon.historyIdx = idx
 	return nil
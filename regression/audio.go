@@ -0,0 +1,343 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package regression
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/database"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/tia/audio"
+	"github.com/jetsetilly/gopher2600/setup"
+	"github.com/jetsetilly/gopher2600/wavwriter"
+)
+
+const audioEntryID = "audio"
+
+const (
+	audioFieldCartName int = iota
+	audioFieldCartMapping
+	audioFieldTVtype
+	audioFieldNumFrames
+	audioFieldRegistersDigest0
+	audioFieldOutputDigest0
+	audioFieldRegistersDigest1
+	audioFieldOutputDigest1
+	audioFieldWavFile
+	audioFieldNotes
+	numAudioFields
+)
+
+// AudioRegression runs for N frames and takes a digest of the audio
+// registers and the audio output for each channel separately. Regression
+// passes if subsequent runs produce the same digests.
+//
+// The registers digest includes every write to the audio registers of the
+// channel, along with the frame, scanline and clock of the write. Writes that
+// don't change the value of the register are included.
+//
+// The audio output of the first run is saved as a stereo WAV file, with
+// channel 0 on the left and channel 1 on the right. If a subsequent run fails
+// then the audio output of that run is saved in the same way so that the two
+// can be compared.
+type AudioRegression struct {
+	CartLoad  cartridgeloader.Loader
+	TVtype    string
+	NumFrames int
+	Notes     string
+	wavFile   string
+
+	// digests for channel 0 and channel 1
+	registersDigest [2]string
+	outputDigest    [2]string
+}
+
+func deserialiseAudioEntry(fields database.SerialisedEntry) (database.Entry, error) {
+	reg := &AudioRegression{}
+
+	// basic sanity check
+	if len(fields) > numAudioFields {
+		return nil, curated.Errorf("audio: too many fields")
+	}
+	if len(fields) < numAudioFields {
+		return nil, curated.Errorf("audio: too few fields")
+	}
+
+	// string fields need no conversion
+	reg.CartLoad.Filename = fields[audioFieldCartName]
+	reg.CartLoad.Mapping = fields[audioFieldCartMapping]
+	reg.TVtype = fields[audioFieldTVtype]
+	reg.registersDigest[0] = fields[audioFieldRegistersDigest0]
+	reg.outputDigest[0] = fields[audioFieldOutputDigest0]
+	reg.registersDigest[1] = fields[audioFieldRegistersDigest1]
+	reg.outputDigest[1] = fields[audioFieldOutputDigest1]
+	reg.wavFile = fields[audioFieldWavFile]
+	reg.Notes = fields[audioFieldNotes]
+
+	var err error
+
+	// convert number of frames field
+	reg.NumFrames, err = strconv.Atoi(fields[audioFieldNumFrames])
+	if err != nil {
+		return nil, curated.Errorf("audio: invalid numFrames field [%s]", fields[audioFieldNumFrames])
+	}
+
+	return reg, nil
+}
+
+// ID implements the database.Entry interface.
+func (reg AudioRegression) ID() string {
+	return audioEntryID
+}
+
+// String implements the database.Entry interface.
+func (reg AudioRegression) String() string {
+	s := strings.Builder{}
+
+	s.WriteString(fmt.Sprintf("[%s] %s [%s] frames=%d", reg.ID(), reg.CartLoad.ShortName(), reg.TVtype, reg.NumFrames))
+	if reg.Notes != "" {
+		s.WriteString(fmt.Sprintf(" [%s]", reg.Notes))
+	}
+	return s.String()
+}
+
+// Serialise implements the database.Entry interface.
+func (reg *AudioRegression) Serialise() (database.SerialisedEntry, error) {
+	return database.SerialisedEntry{
+			reg.CartLoad.Filename,
+			reg.CartLoad.Mapping,
+			reg.TVtype,
+			strconv.Itoa(reg.NumFrames),
+			reg.registersDigest[0],
+			reg.outputDigest[0],
+			reg.registersDigest[1],
+			reg.outputDigest[1],
+			reg.wavFile,
+			reg.Notes,
+		},
+		nil
+}
+
+// CleanUp implements the database.Entry interface.
+func (reg AudioRegression) CleanUp() error {
+	err := os.Remove(reg.wavFile)
+	if _, ok := err.(*os.PathError); ok {
+		return nil
+	}
+	return err
+}
+
+// audioChannels implements the television.AudioMixer and audio.Tracker
+// interfaces. it takes a digest of the register writes and the output of each
+// channel and forwards the audio to a stereo WavWriter.
+type audioChannels struct {
+	tv  *television.Television
+	wav *wavwriter.WavWriter
+
+	registersDigest [2]hash.Hash
+	outputDigest    [2]hash.Hash
+}
+
+func newAudioChannels(tv *television.Television, wavFile string) (*audioChannels, error) {
+	wav, err := wavwriter.NewStereo(wavFile, 1.0)
+	if err != nil {
+		return nil, err
+	}
+
+	ac := &audioChannels{
+		tv:  tv,
+		wav: wav,
+	}
+
+	for i := range ac.registersDigest {
+		ac.registersDigest[i] = sha1.New()
+		ac.outputDigest[i] = sha1.New()
+	}
+
+	return ac, nil
+}
+
+// AudioEvent implements the audio.Tracker interface. the write is added to
+// the digest of the channel the register belongs to. the last character of
+// the register name is the channel number.
+func (ac *audioChannels) AudioEvent(register string, channel0 audio.Registers, channel1 audio.Registers) {
+	i := 0
+	registers := channel0
+	if strings.HasSuffix(register, "1") {
+		i = 1
+		registers = channel1
+	}
+
+	ac.registersDigest[i].Write([]byte(fmt.Sprintf("%d %d %d %s %s\n",
+		ac.tv.GetState(signal.ReqFramenum),
+		ac.tv.GetState(signal.ReqScanline),
+		ac.tv.GetState(signal.ReqHorizPos),
+		register, registers)))
+}
+
+// SetAudio implements the television.AudioMixer interface.
+func (ac *audioChannels) SetAudio(sig signal.SignalAttributes) error {
+	ac.outputDigest[0].Write([]byte{sig.AudioChannel0})
+	ac.outputDigest[1].Write([]byte{sig.AudioChannel1})
+	return ac.wav.SetAudio(sig)
+}
+
+// EndMixing implements the television.AudioMixer interface.
+func (ac *audioChannels) EndMixing() error {
+	// the WAV file is only written when explicitly requested
+	return nil
+}
+
+// regress implements the regression.Regressor interface.
+func (reg *AudioRegression) regress(newRegression bool, output io.Writer, msg string, skipCheck func() bool) (bool, string, error) {
+	output.Write([]byte(msg))
+
+	// create headless television
+	tv, err := television.NewTelevision(reg.TVtype)
+	if err != nil {
+		return false, "", curated.Errorf("audio: %v", err)
+	}
+	defer tv.End()
+	tv.SetFPSCap(false)
+
+	// create VCS and attach cartridge
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		return false, "", curated.Errorf("audio: %v", err)
+	}
+
+	// we want the machine in a known state. the easiest way to do this is to
+	// reset the hardware preferences
+	err = vcs.Prefs.Reset()
+	if err != nil {
+		return false, "", curated.Errorf("audio: %v", err)
+	}
+
+	err = setup.AttachCartridge(vcs, reg.CartLoad)
+	if err != nil {
+		return false, "", curated.Errorf("audio: %v", err)
+	}
+
+	// the WAV file for a new regression is the expected output for all
+	// future runs. otherwise the WAV file is the actual output of this run
+	// and is only written if the regression fails
+	prepend := "audio"
+	if !newRegression {
+		prepend = "audio_failure"
+	}
+
+	wavFile, err := uniqueFilename(prepend, reg.CartLoad)
+	if err != nil {
+		return false, "", curated.Errorf("audio: %v", err)
+	}
+	wavFile = fmt.Sprintf("%s.wav", wavFile)
+
+	ac, err := newAudioChannels(tv, wavFile)
+	if err != nil {
+		return false, "", curated.Errorf("audio: %v", err)
+	}
+	tv.AddAudioMixer(ac)
+	vcs.TIA.Audio.SetTracker(ac)
+
+	// display ticker for progress meter
+	dur, _ := time.ParseDuration("1s")
+	tck := time.NewTicker(dur)
+
+	// run emulation
+	err = vcs.RunForFrameCount(reg.NumFrames, func(frame int) (bool, error) {
+		if skipCheck() {
+			return false, curated.Errorf(regressionSkipped)
+		}
+
+		// display progress meter every 1 second
+		select {
+		case <-tck.C:
+			output.Write([]byte(fmt.Sprintf("\r%s [%d/%d (%.1f%%)]", msg, frame, reg.NumFrames, 100*(float64(frame)/float64(reg.NumFrames)))))
+		default:
+		}
+
+		return true, nil
+	})
+
+	if err != nil {
+		return false, "", curated.Errorf("audio: %v", err)
+	}
+
+	var registersDigest [2]string
+	var outputDigest [2]string
+	for i := range registersDigest {
+		registersDigest[i] = fmt.Sprintf("%x", ac.registersDigest[i].Sum(nil))
+		outputDigest[i] = fmt.Sprintf("%x", ac.outputDigest[i].Sum(nil))
+	}
+
+	if newRegression {
+		// check that the filename is unique
+		if _, err := os.Stat(wavFile); err == nil {
+			return false, "", curated.Errorf("audio: wav file already exists (%s)", wavFile)
+		}
+
+		err = ac.wav.EndMixing()
+		if err != nil {
+			return false, "", curated.Errorf("audio: %v", err)
+		}
+
+		reg.wavFile = wavFile
+		reg.registersDigest = registersDigest
+		reg.outputDigest = outputDigest
+
+		// this is a new regression entry so we don't need to do the comparison
+		// stage so we return early
+		return true, "", nil
+	}
+
+	// compare digests from this run and the specimen run
+	mismatch := make([]string, 0, 4)
+	for i := range registersDigest {
+		if registersDigest[i] != reg.registersDigest[i] {
+			mismatch = append(mismatch, fmt.Sprintf("channel %d registers", i))
+		}
+		if outputDigest[i] != reg.outputDigest[i] {
+			mismatch = append(mismatch, fmt.Sprintf("channel %d output", i))
+		}
+	}
+
+	if len(mismatch) == 0 {
+		return true, "", nil
+	}
+
+	// write the actual output so that it can be compared with the expected
+	// output
+	err = ac.wav.EndMixing()
+	if err != nil {
+		return false, "", curated.Errorf("audio: %v", err)
+	}
+
+	failm := fmt.Sprintf("digest mismatch (%s): compare expected %s with actual %s",
+		strings.Join(mismatch, ", "), reg.wavFile, wavFile)
+
+	return false, failm, nil
+}
@@ -0,0 +1,165 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package regression
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+)
+
+// write a 4k cartridge that sets the volume of the AUDV register in a loop.
+func writeAudioCart(t *testing.T, filename string, register uint8, volume uint8) {
+	t.Helper()
+
+	data := make([]uint8, 4096)
+	copy(data, []uint8{
+		0xa9, volume, // f000 LDA #volume
+		0x85, register, // f002 STA AUDVx
+		0xea, 0xea, // f004 NOP NOP
+		0x4c, 0x00, 0xf0, // f006 JMP $f000
+	})
+	data[0xffc] = 0x00
+	data[0xffd] = 0xf0
+
+	err := ioutil.WriteFile(filename, data, 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAudioRegression(t *testing.T) {
+	// regression files are created in the resource path, which is relative
+	// to the current directory
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Chdir(wd)
+	dir := t.TempDir()
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const AUDV0 = 0x19
+	const AUDV1 = 0x1a
+
+	cartFile := filepath.Join(dir, "audio.bin")
+	writeAudioCart(t, cartFile, AUDV0, 0x05)
+
+	noSkip := func() bool { return false }
+
+	reg := &AudioRegression{
+		CartLoad:  cartridgeloader.NewLoader(cartFile, "AUTO"),
+		TVtype:    "NTSC",
+		NumFrames: 3,
+		Notes:     "test",
+	}
+
+	// new regression entry
+	ok, _, err := reg.regress(true, ioutil.Discard, "", noSkip)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected new regression entry to succeed")
+	}
+	defer reg.CleanUp()
+
+	if _, err := os.Stat(reg.wavFile); err != nil {
+		t.Errorf("expected WAV file to have been written (%v)", err)
+	}
+
+	// the entry survives serialisation
+	fields, err := reg.Serialise()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ent, err := deserialiseAudioEntry(fields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dreg := ent.(*AudioRegression)
+	if dreg.CartLoad.Filename != reg.CartLoad.Filename || dreg.CartLoad.Mapping != reg.CartLoad.Mapping ||
+		dreg.TVtype != reg.TVtype || dreg.NumFrames != reg.NumFrames || dreg.Notes != reg.Notes ||
+		dreg.wavFile != reg.wavFile || dreg.registersDigest != reg.registersDigest || dreg.outputDigest != reg.outputDigest {
+		t.Errorf("deserialised entry is not the same as the serialised entry")
+	}
+
+	if _, err := deserialiseAudioEntry(fields[:numAudioFields-1]); err == nil {
+		t.Errorf("expected error for too few fields")
+	}
+	fields[audioFieldNumFrames] = "three"
+	if _, err := deserialiseAudioEntry(fields); err == nil {
+		t.Errorf("expected error for invalid number of frames")
+	}
+
+	// running the regression again passes
+	ok, failm, err := dreg.regress(false, ioutil.Discard, "", noSkip)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected regression to pass (%s)", failm)
+	}
+
+	// changing the volume of channel 0 fails the regression for channel 0
+	// only and writes the actual output
+	writeAudioCart(t, cartFile, AUDV0, 0x07)
+
+	ok, failm, err = dreg.regress(false, ioutil.Discard, "", noSkip)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected regression to fail")
+	}
+	if !strings.Contains(failm, "channel 0 registers") || !strings.Contains(failm, "channel 0 output") {
+		t.Errorf("expected failure for channel 0 (%s)", failm)
+	}
+	if strings.Contains(failm, "channel 1") {
+		t.Errorf("unexpected failure for channel 1 (%s)", failm)
+	}
+
+	const actual = "with actual "
+	i := strings.LastIndex(failm, actual)
+	if i == -1 {
+		t.Fatalf("expected actual WAV file in failure message (%s)", failm)
+	}
+	if _, err := os.Stat(failm[i+len(actual):]); err != nil {
+		t.Errorf("expected actual WAV file to have been written (%v)", err)
+	}
+
+	// writing to channel 1 instead of channel 0 fails the regression for both
+	// channels
+	writeAudioCart(t, cartFile, AUDV1, 0x05)
+
+	ok, failm, err = dreg.regress(false, ioutil.Discard, "", noSkip)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected regression to fail")
+	}
+	if !strings.Contains(failm, "channel 0 registers") || !strings.Contains(failm, "channel 1 registers") {
+		t.Errorf("expected failure for both channels (%s)", failm)
+	}
+}
@@ -17,7 +17,7 @@
 // adding test results to a database, the tests can be rerun automatically and
 // checked for consistancy.
 //
-// Currently, two main types of test are supported. First the video test. This
+// Currently, four types of test are supported. First the video test. This
 // test runs a ROM for a set number of frames. A hash of the final video output
 // is created a stored for future comparison.
 //
@@ -30,6 +30,13 @@
 // number of frames. Test failure for the Log test means that something
 // (anything) in the log output has changed.
 //
+// The fourth test is the Audio test. This takes a hash of every write to the
+// audio registers, along with when the write happened, and the audio output of
+// each channel separately. The audio output of the
+// first run is saved as a WAV file and, in the event of a failure, the audio
+// output of the failed run is also saved as a WAV file so that the two can
+// be compared.
+//
 // In addition to its basic function, the video test also supports recording of
 // machine state. Four machine states are supported at the moment - TV state,
 // RIOT/Ports state, RIOT/Timer and CPU. Aprt from the TV state this doesn't
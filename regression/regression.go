@@ -73,6 +73,10 @@ func initDBSession(db *database.Session) error {
 		return err
 	}
 
+	if err := db.RegisterEntryType(audioEntryID, deserialiseAudioEntry); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,33 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package resampler converts the audio produced by the TIA, at the rate given
+// by audio.SampleFreq, to a sample rate that is more suitable for playback or
+// for saving to disk, typically 44100Hz or 48000Hz.
+//
+// Resampling is performed with a band-limited, windowed sinc filter. The
+// filter is precalculated for a fixed number of phases (a polyphase filter)
+// and the phase nearest to the position of each output sample is used. The
+// cutoff of the filter is just below the Nyquist frequency of the lower of the
+// two sample rates so the resampled audio is free of aliasing.
+//
+// Optionally, the resampler can model the low-pass characteristic of the
+// audio output stage of the VCS. This is applied to the audio before
+// resampling.
+//
+// The Preferences type allows the output sample rate and the low-pass model
+// to be chosen by the user and is shared by all audio outputs that make use
+// of the resampler.
+package resampler
@@ -0,0 +1,115 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package resampler
+
+import (
+	"github.com/jetsetilly/gopher2600/hardware/tia/audio"
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// SampleRates lists the output sample rates that are offered to the user.
+// The first entry is the native sample rate of the TIA, meaning no resampling
+// takes place.
+var SampleRates = []int{audio.SampleFreq, 44100, 48000}
+
+// Preferences for audio resampling.
+type Preferences struct {
+	dsk *prefs.Disk
+
+	// output sample rate. should be one of the values in SampleRates
+	SampleRate prefs.Int
+
+	// whether to model the low-pass characteristic of the VCS output stage
+	LowPass prefs.Bool
+}
+
+func (p *Preferences) String() string {
+	return p.dsk.String()
+}
+
+const (
+	sampleRate = 48000
+	lowPass    = false
+)
+
+// NewPreferences is the preferred method of initialisation for the Preferences type.
+func NewPreferences() (*Preferences, error) {
+	p := &Preferences{}
+	p.SetDefaults()
+
+	// save server using the prefs package
+	pth, err := paths.ResourcePath("", prefs.DefaultPrefsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	p.dsk, err = prefs.NewDisk(pth)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.dsk.Add("resampler.samplerate", &p.SampleRate)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("resampler.lowpass", &p.LowPass)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.dsk.Load(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// SetDefaults reverts all resampler settings to default values.
+func (p *Preferences) SetDefaults() {
+	p.SampleRate.Set(sampleRate)
+	p.LowPass.Set(lowPass)
+}
+
+// Load resampler preferences from disk.
+func (p *Preferences) Load() error {
+	return p.dsk.Load(false)
+}
+
+// Save current resampler preferences to disk.
+func (p *Preferences) Save() error {
+	return p.dsk.Save()
+}
+
+// Rate returns the output sample rate. If the SampleRate preference is not one
+// of the values in SampleRates then the native sample rate of the TIA is
+// returned.
+func (p *Preferences) Rate() int {
+	r := p.SampleRate.Get().(int)
+	for _, s := range SampleRates {
+		if r == s {
+			return r
+		}
+	}
+	return audio.SampleFreq
+}
+
+// NewResampler creates a new Resampler using the current preference values.
+// The input rate is always the native sample rate of the TIA.
+func (p *Preferences) NewResampler(channels int) *Resampler {
+	return New(channels, audio.SampleFreq, p.Rate(), p.LowPass.Get().(bool))
+}
@@ -0,0 +1,206 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package resampler
+
+import (
+	"math"
+)
+
+// the number of input samples either side of the output sample that are used
+// to calculate the output sample.
+const halfTaps = 16
+
+// the number of taps in the filter.
+const numTaps = halfTaps * 2
+
+// the number of phases in the filter table. the phase of each output sample is
+// rounded to the nearest phase in the table.
+const numPhases = 256
+
+// cutoff of the filter is scaled by this amount so that the transition band of
+// the filter sits below the Nyquist frequency.
+const rolloff = 0.9
+
+// cutoff frequency of the low-pass model of the VCS audio output stage. the
+// exact value will differ from console to console so this is an approximation.
+const lowPassCutoff = 8000.0
+
+// Resampler converts audio from one sample rate to another. Any number of
+// channels can be resampled at once but the number of channels must be
+// specified at time of creation.
+type Resampler struct {
+	inRate  int
+	outRate int
+
+	// whether to resample at all. if the input and output rates are the same
+	// then samples pass straight through the resampler (after being low-pass
+	// filtered if required)
+	passthrough bool
+
+	// the filter table. indexed by phase and then by tap
+	table [numPhases + 1][numTaps]float32
+
+	// the history of input samples for each channel. the most recent sample is
+	// at the end of the slice
+	history [][numTaps]float32
+
+	// position of the next output sample relative to the centre of the history,
+	// measured in input samples
+	pos  float64
+	step float64
+
+	// low-pass filter state for each channel
+	lowPass      bool
+	lowPassAlpha float32
+	lowPassState []float32
+
+	// output frame is reused between calls to Write()
+	out []float32
+}
+
+// New is the preferred method of initialisation for the Resampler type. The
+// input and output rates are in samples per second.
+func New(channels int, inRate int, outRate int, lowPass bool) *Resampler {
+	r := &Resampler{
+		inRate:       inRate,
+		outRate:      outRate,
+		passthrough:  inRate == outRate,
+		history:      make([][numTaps]float32, channels),
+		step:         float64(inRate) / float64(outRate),
+		lowPass:      lowPass,
+		lowPassAlpha: float32(1.0 - math.Exp(-2.0*math.Pi*lowPassCutoff/float64(inRate))),
+		lowPassState: make([]float32, channels),
+		out:          make([]float32, channels),
+	}
+
+	// the cutoff is measured in cycles per input sample
+	cutoff := 0.5 * rolloff
+	if outRate < inRate {
+		cutoff *= float64(outRate) / float64(inRate)
+	}
+
+	for p := 0; p <= numPhases; p++ {
+		frac := float64(p) / numPhases
+
+		var sum float64
+		var taps [numTaps]float64
+		for j := 0; j < numTaps; j++ {
+			x := frac + float64(halfTaps-1-j)
+			taps[j] = sinc(2.0*cutoff*x) * blackman(x/halfTaps)
+			sum += taps[j]
+		}
+
+		// normalise each phase so that the filter has unity gain
+		for j := 0; j < numTaps; j++ {
+			r.table[p][j] = float32(taps[j] / sum)
+		}
+	}
+
+	return r
+}
+
+// InRate returns the input sample rate.
+func (r *Resampler) InRate() int {
+	return r.inRate
+}
+
+// OutRate returns the output sample rate.
+func (r *Resampler) OutRate() int {
+	return r.outRate
+}
+
+// SetLowPass turns the low-pass model of the VCS output stage on or off.
+func (r *Resampler) SetLowPass(lowPass bool) {
+	r.lowPass = lowPass
+}
+
+// Write adds one sample for every channel to the resampler. The length of the
+// in argument must be the same as the number of channels specified when the
+// resampler was created.
+//
+// The emit function will be called zero or more times, once for every output
+// sample that can be created. The slice given to the emit function is reused
+// and should not be retained.
+func (r *Resampler) Write(in []float32, emit func(out []float32) error) error {
+	if r.lowPass {
+		for c := range in {
+			r.lowPassState[c] += r.lowPassAlpha * (in[c] - r.lowPassState[c])
+			r.out[c] = r.lowPassState[c]
+		}
+	} else {
+		copy(r.out, in)
+	}
+
+	if r.passthrough {
+		return emit(r.out)
+	}
+
+	for c := range r.history {
+		copy(r.history[c][:], r.history[c][1:])
+		r.history[c][numTaps-1] = r.out[c]
+	}
+
+	for r.pos < 1.0 {
+		taps := &r.table[int(r.pos*numPhases+0.5)]
+		for c := range r.history {
+			var v float32
+			for j := 0; j < numTaps; j++ {
+				v += r.history[c][j] * taps[j]
+			}
+			r.out[c] = v
+		}
+
+		err := emit(r.out)
+		if err != nil {
+			return err
+		}
+
+		r.pos += r.step
+	}
+	r.pos -= 1.0
+
+	return nil
+}
+
+// ToUint8 converts an output sample to an uint8 value, clamping the value if
+// necessary. Band-limiting can cause the output to overshoot the range of the
+// input.
+func ToUint8(v float32) uint8 {
+	if v <= 0.0 {
+		return 0
+	}
+	if v >= 255.0 {
+		return 255
+	}
+	return uint8(v + 0.5)
+}
+
+func sinc(x float64) float64 {
+	if x == 0.0 {
+		return 1.0
+	}
+	x *= math.Pi
+	return math.Sin(x) / x
+}
+
+// blackman window. x should be in the range -1.0 to 1.0.
+func blackman(x float64) float64 {
+	if x <= -1.0 || x >= 1.0 {
+		return 0.0
+	}
+	t := math.Pi * (x + 1.0)
+	return 0.42 - 0.5*math.Cos(t) + 0.08*math.Cos(2.0*t)
+}
@@ -0,0 +1,119 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package resampler_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/tia/audio"
+	"github.com/jetsetilly/gopher2600/resampler"
+)
+
+// resample one second of audio and return the output.
+func resample(t *testing.T, r *resampler.Resampler, f func(i int) float32) []float32 {
+	t.Helper()
+
+	out := make([]float32, 0)
+	in := []float32{0}
+	for i := 0; i < audio.SampleFreq; i++ {
+		in[0] = f(i)
+		err := r.Write(in, func(o []float32) error {
+			out = append(out, o[0])
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	return out
+}
+
+func TestPassthrough(t *testing.T) {
+	r := resampler.New(1, audio.SampleFreq, audio.SampleFreq, false)
+	out := resample(t, r, func(i int) float32 { return float32(i % 100) })
+
+	if len(out) != audio.SampleFreq {
+		t.Fatalf("unexpected number of samples (%d)", len(out))
+	}
+	for i := range out {
+		if out[i] != float32(i%100) {
+			t.Fatalf("sample %d changed by passthrough (%f)", i, out[i])
+		}
+	}
+}
+
+func TestRate(t *testing.T) {
+	for _, rate := range []int{22050, 44100, 48000} {
+		r := resampler.New(1, audio.SampleFreq, rate, false)
+		out := resample(t, r, func(i int) float32 { return 60 })
+
+		// one second of input should produce one second of output, give or
+		// take a sample
+		if len(out) < rate-1 || len(out) > rate+1 {
+			t.Errorf("%dHz: unexpected number of samples (%d)", rate, len(out))
+		}
+
+		// a constant signal should be unchanged once the filter has filled
+		for i := len(out) / 2; i < len(out); i++ {
+			if math.Abs(float64(out[i]-60)) > 0.01 {
+				t.Fatalf("%dHz: sample %d is not constant (%f)", rate, i, out[i])
+			}
+		}
+	}
+}
+
+func TestBandLimit(t *testing.T) {
+	// a tone above the Nyquist frequency of the output rate should be removed
+	// when downsampling
+	r := resampler.New(1, audio.SampleFreq, 22050, false)
+	tone := func(i int) float32 {
+		return float32(64 + 32*math.Sin(2*math.Pi*14000*float64(i)/audio.SampleFreq))
+	}
+	out := resample(t, r, tone)
+
+	for i := len(out) / 2; i < len(out); i++ {
+		if math.Abs(float64(out[i]-64)) > 2.0 {
+			t.Fatalf("sample %d has not been filtered (%f)", i, out[i])
+		}
+	}
+}
+
+func TestLowPass(t *testing.T) {
+	r := resampler.New(1, audio.SampleFreq, audio.SampleFreq, true)
+
+	// a square wave at the Nyquist frequency should be attenuated
+	out := resample(t, r, func(i int) float32 { return float32(i%2) * 100 })
+
+	for i := len(out) / 2; i < len(out); i++ {
+		if out[i] < 10 || out[i] > 90 {
+			t.Fatalf("sample %d has not been filtered (%f)", i, out[i])
+		}
+	}
+}
+
+func TestToUint8(t *testing.T) {
+	if resampler.ToUint8(-10) != 0 {
+		t.Errorf("negative value not clamped")
+	}
+	if resampler.ToUint8(300) != 255 {
+		t.Errorf("large value not clamped")
+	}
+	if resampler.ToUint8(99.6) != 100 {
+		t.Errorf("value not rounded")
+	}
+}
@@ -0,0 +1,373 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package symbols
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/paths"
+)
+
+// annotations are saved in the resource directory in a file named after the
+// hash of the cartridge.
+const annotationsPath = "annotations"
+
+// Annotation is user supplied information about an address in a cartridge
+// bank. Annotations are kept separate from the information read from the
+// symbols file so that they survive the reloading of the symbols file.
+type Annotation struct {
+	Bank    int
+	Address uint16
+	Label   string
+	Comment string
+}
+
+type annotationKey struct {
+	bank    int
+	address uint16
+}
+
+// annotations are accessed by both the emulation and the GUI so access is
+// protected by a mutex.
+type annotations struct {
+	crit    sync.Mutex
+	entries map[annotationKey]Annotation
+
+	// user labels indexed by address and then by bank. used to find the
+	// label for an address in any bank without searching every entry
+	labels map[uint16]map[int]string
+
+	// the file the annotations are saved to. annotations will not be saved if
+	// this is empty
+	filename string
+
+	// the longest label in the entries map
+	maxWidth int
+}
+
+func newAnnotations() *annotations {
+	return &annotations{
+		entries: make(map[annotationKey]Annotation),
+		labels:  make(map[uint16]map[int]string),
+	}
+}
+
+// annotations are keyed by the mapped address.
+func annotationAddress(address uint16) uint16 {
+	ma, _ := memorymap.MapAddress(address, true)
+	return ma
+}
+
+// SetLabel adds a label to the address in the specified bank. An existing
+// label for the address is replaced. An empty label removes the label.
+//
+// Labels must be unique. It is an error to use a label that has already been
+// given to a different address or bank. Labels are not case sensitive for the
+// purposes of this check.
+//
+// The label takes precedence over any label for the same address from the
+// symbols file. The annotations file for the cartridge is updated immediately.
+func (sym *Symbols) SetLabel(bank int, address uint16, label string) error {
+	if strings.ContainsAny(label, " \t\n") {
+		return curated.Errorf("symbols: label cannot contain whitespace (%s)", label)
+	}
+
+	sym.annotations.crit.Lock()
+	k := annotationKey{bank: bank, address: annotationAddress(address)}
+
+	if label != "" {
+		for ek, ea := range sym.annotations.entries {
+			if ek != k && strings.EqualFold(ea.Label, label) {
+				sym.annotations.crit.Unlock()
+				return curated.Errorf("symbols: label already used for %#04x in bank %d (%s)", ek.address, ek.bank, ea.Label)
+			}
+		}
+	}
+
+	a := sym.annotations.entries[k]
+	a.Bank = k.bank
+	a.Address = k.address
+	a.Label = label
+	sym.annotations.set(k, a)
+	sym.annotations.crit.Unlock()
+
+	return sym.saveAnnotations()
+}
+
+// SetComment adds a comment to the address in the specified bank. An existing
+// comment for the address is replaced. An empty comment removes the comment.
+//
+// The annotations file for the cartridge is updated immediately.
+func (sym *Symbols) SetComment(bank int, address uint16, comment string) error {
+	sym.annotations.crit.Lock()
+	k := annotationKey{bank: bank, address: annotationAddress(address)}
+	a := sym.annotations.entries[k]
+	a.Bank = k.bank
+	a.Address = k.address
+	a.Comment = strings.TrimSpace(strings.ReplaceAll(comment, "\n", " "))
+	sym.annotations.set(k, a)
+	sym.annotations.crit.Unlock()
+
+	return sym.saveAnnotations()
+}
+
+// set annotation and update the labels index and maxWidth. annotations with
+// no label or comment are removed. crit should be locked by caller.
+func (ann *annotations) set(k annotationKey, a Annotation) {
+	if a.Label == "" && a.Comment == "" {
+		delete(ann.entries, k)
+	} else {
+		ann.entries[k] = a
+	}
+
+	if a.Label == "" {
+		if banks, ok := ann.labels[k.address]; ok {
+			delete(banks, k.bank)
+			if len(banks) == 0 {
+				delete(ann.labels, k.address)
+			}
+		}
+	} else {
+		if _, ok := ann.labels[k.address]; !ok {
+			ann.labels[k.address] = make(map[int]string)
+		}
+		ann.labels[k.address][k.bank] = a.Label
+	}
+
+	ann.maxWidth = 0
+	for _, a := range ann.entries {
+		if len(a.Label) > ann.maxWidth {
+			ann.maxWidth = len(a.Label)
+		}
+	}
+}
+
+// Annotation returns the annotation for the address in the specified bank.
+func (sym *Symbols) Annotation(bank int, address uint16) (Annotation, bool) {
+	sym.annotations.crit.Lock()
+	defer sym.annotations.crit.Unlock()
+
+	a, ok := sym.annotations.entries[annotationKey{bank: bank, address: annotationAddress(address)}]
+	return a, ok
+}
+
+// Annotations returns all annotations sorted by bank and address.
+func (sym *Symbols) Annotations() []Annotation {
+	sym.annotations.crit.Lock()
+	defer sym.annotations.crit.Unlock()
+
+	l := make([]Annotation, 0, len(sym.annotations.entries))
+	for _, a := range sym.annotations.entries {
+		l = append(l, a)
+	}
+
+	sort.Slice(l, func(i, j int) bool {
+		if l[i].Bank == l[j].Bank {
+			return l[i].Address < l[j].Address
+		}
+		return l[i].Bank < l[j].Bank
+	})
+
+	return l
+}
+
+// LookupLabel returns the label for the address in the specified bank. User
+// labels (see SetLabel()) are preferred to labels from the symbols file.
+//
+// If bank is negative then a user label from any bank will be returned.
+func (sym *Symbols) LookupLabel(bank int, address uint16) (string, bool) {
+	ma := annotationAddress(address)
+
+	sym.annotations.crit.Lock()
+	if bank >= 0 {
+		if a, ok := sym.annotations.entries[annotationKey{bank: bank, address: ma}]; ok && a.Label != "" {
+			sym.annotations.crit.Unlock()
+			return a.Label, true
+		}
+	} else if l, ok := sym.annotations.anyBank(ma); ok {
+		sym.annotations.crit.Unlock()
+		return l, true
+	}
+	sym.annotations.crit.Unlock()
+
+	v, ok := sym.Label.Entries[ma]
+	return v, ok
+}
+
+// returns the user label for the address from the lowest numbered bank that
+// has one. crit should be locked by caller.
+func (ann *annotations) anyBank(address uint16) (string, bool) {
+	label := ""
+	bank := -1
+	for b, l := range ann.labels[address] {
+		if bank == -1 || b < bank {
+			label = l
+			bank = b
+		}
+	}
+	return label, bank != -1
+}
+
+// search user labels for symbol. symbol should be in upper case.
+//
+// SetLabel() doesn't allow duplicate labels but an annotations file edited by
+// hand might contain them. in that case the label in the lowest numbered bank
+// and then the lowest address is returned, so that the result is the same
+// every time.
+func (ann *annotations) search(symbol string) (uint16, bool) {
+	ann.crit.Lock()
+	defer ann.crit.Unlock()
+
+	var found *annotationKey
+	for k, a := range ann.entries {
+		if strings.ToUpper(a.Label) != symbol {
+			continue // for loop
+		}
+		if found == nil || k.bank < found.bank || (k.bank == found.bank && k.address < found.address) {
+			k := k
+			found = &k
+		}
+	}
+
+	if found == nil {
+		return 0, false
+	}
+	return found.address, true
+}
+
+// load annotations for the cartridge. a missing file is not an error.
+func (sym *Symbols) loadAnnotations(cart *cartridge.Cartridge) error {
+	if cart == nil || cart.Hash == "" {
+		return nil
+	}
+
+	pth, err := paths.ResourcePath(annotationsPath, cart.Hash)
+	if err != nil {
+		return curated.Errorf("symbols: %v", err)
+	}
+
+	sym.annotations.crit.Lock()
+	defer sym.annotations.crit.Unlock()
+
+	sym.annotations.filename = pth
+
+	f, err := os.Open(pth)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return curated.Errorf("symbols: %v", err)
+	}
+	defer f.Close()
+
+	// each line in the file is the bank, the address, the type of annotation
+	// and the annotation itself. for example:
+	//
+	//	0 1000 label start
+	//	0 1004 comment wait for vsync
+	//
+	// malformed lines are ignored
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		p := strings.SplitN(scanner.Text(), " ", 4)
+		if len(p) != 4 {
+			continue // for loop
+		}
+
+		bank, err := strconv.Atoi(p[0])
+		if err != nil {
+			continue // for loop
+		}
+		address, err := strconv.ParseUint(p[1], 16, 16)
+		if err != nil {
+			continue // for loop
+		}
+
+		k := annotationKey{bank: bank, address: annotationAddress(uint16(address))}
+		a := sym.annotations.entries[k]
+		a.Bank = k.bank
+		a.Address = k.address
+
+		switch p[2] {
+		case "label":
+			a.Label = p[3]
+		case "comment":
+			a.Comment = p[3]
+		default:
+			continue // for loop
+		}
+
+		sym.annotations.set(k, a)
+	}
+
+	return nil
+}
+
+// save annotations to the file named when the annotations were loaded. an
+// empty set of annotations will cause the file to be removed.
+func (sym *Symbols) saveAnnotations() error {
+	l := sym.Annotations()
+
+	sym.annotations.crit.Lock()
+	defer sym.annotations.crit.Unlock()
+
+	if sym.annotations.filename == "" {
+		return nil
+	}
+
+	if len(l) == 0 {
+		err := os.Remove(sym.annotations.filename)
+		if err != nil && !os.IsNotExist(err) {
+			return curated.Errorf("symbols: %v", err)
+		}
+		return nil
+	}
+
+	s := &strings.Builder{}
+	for _, a := range l {
+		if a.Label != "" {
+			s.WriteString(fmt.Sprintf("%d %04x label %s\n", a.Bank, a.Address, a.Label))
+		}
+		if a.Comment != "" {
+			s.WriteString(fmt.Sprintf("%d %04x comment %s\n", a.Bank, a.Address, a.Comment))
+		}
+	}
+
+	err := os.WriteFile(sym.annotations.filename, []byte(s.String()), 0600)
+	if err != nil {
+		return curated.Errorf("symbols: %v", err)
+	}
+
+	return nil
+}
+
+// load annotations and log any errors. errors are not fatal when reading a
+// symbols file.
+func (sym *Symbols) logAnnotations(cart *cartridge.Cartridge) {
+	if err := sym.loadAnnotations(cart); err != nil {
+		logger.Log("symbols", err.Error())
+	}
+}
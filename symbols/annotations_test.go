@@ -0,0 +1,197 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package symbols_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/symbols"
+)
+
+func TestAnnotations(t *testing.T) {
+	syms := symbols.NewSymbols()
+
+	err := syms.SetLabel(1, 0xf010, "kernel")
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+
+	// labels are specific to a bank
+	if l, ok := syms.LookupLabel(1, 0xf010); !ok || l != "kernel" {
+		t.Errorf("expected kernel label in bank 1 (%s)", l)
+	}
+	if _, ok := syms.LookupLabel(0, 0xf010); ok {
+		t.Errorf("unexpected label in bank 0")
+	}
+	if l, ok := syms.LookupLabel(-1, 0x1010); !ok || l != "kernel" {
+		t.Errorf("expected kernel label in any bank (%s)", l)
+	}
+
+	if ok, table, _, address := syms.Search("KERNEL", symbols.UnspecifiedSymTable); !ok || table != symbols.LabelTable || address != 0x1010 {
+		t.Errorf("expected to find user label with search")
+	}
+
+	if syms.SetLabel(0, 0xf000, "two words") == nil {
+		t.Errorf("expected error for label with whitespace")
+	}
+
+	// labels must be unique across addresses and banks
+	if syms.SetLabel(1, 0xf020, "kernel") == nil {
+		t.Errorf("expected error for duplicate label at different address")
+	}
+	if syms.SetLabel(0, 0xf010, "KERNEL") == nil {
+		t.Errorf("expected error for duplicate label in different bank")
+	}
+	if _, ok := syms.LookupLabel(0, 0xf010); ok {
+		t.Errorf("unexpected label in bank 0 after duplicate label was rejected")
+	}
+
+	// relabelling the same address with the same label is fine
+	err = syms.SetLabel(1, 0x1010, "Kernel")
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	err = syms.SetLabel(1, 0xf010, "kernel")
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+
+	err = syms.SetComment(1, 0xf010, "draw playfield")
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	if a, ok := syms.Annotation(1, 0xf010); !ok || a.Label != "kernel" || a.Comment != "draw playfield" {
+		t.Errorf("unexpected annotation (%v)", a)
+	}
+
+	// removing the label leaves the comment
+	err = syms.SetLabel(1, 0xf010, "")
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	if a, ok := syms.Annotation(1, 0xf010); !ok || a.Label != "" {
+		t.Errorf("unexpected annotation (%v)", a)
+	}
+
+	// removing the comment removes the annotation
+	err = syms.SetComment(1, 0xf010, "")
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	if len(syms.Annotations()) != 0 {
+		t.Errorf("expected no annotations")
+	}
+}
+
+func TestAnnotationsPersist(t *testing.T) {
+	cart := cartridge.NewCartridge(nil)
+	cart.Hash = "annotationstest"
+
+	syms, _ := symbols.ReadSymbolsFile(cart)
+	err := syms.SetLabel(0, 0xf000, "start")
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	err = syms.SetComment(0, 0xf004, "wait for vsync")
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+
+	// annotations are reloaded for the same cartridge
+	syms, _ = symbols.ReadSymbolsFile(cart)
+	if l, ok := syms.LookupLabel(0, 0xf000); !ok || l != "start" {
+		t.Errorf("expected start label to have been reloaded")
+	}
+	if a, _ := syms.Annotation(0, 0xf004); a.Comment != "wait for vsync" {
+		t.Errorf("expected comment to have been reloaded (%s)", a.Comment)
+	}
+
+	// removing all annotations removes the file
+	_ = syms.SetLabel(0, 0xf000, "")
+	_ = syms.SetComment(0, 0xf004, "")
+
+	syms, _ = symbols.ReadSymbolsFile(cart)
+	if len(syms.Annotations()) != 0 {
+		t.Errorf("expected no annotations")
+	}
+}
+
+func TestAnnotationsDuplicateLabels(t *testing.T) {
+	cart := cartridge.NewCartridge(nil)
+	cart.Hash = "annotationsduplicatetest"
+
+	// an annotations file edited by hand can contain duplicate labels
+	pth, err := paths.ResourcePath("annotations", cart.Hash)
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	err = os.WriteFile(pth, []byte("2 1020 label dup\n0 1040 label dup\n0 1030 label DUP\n"), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	defer os.Remove(pth)
+
+	syms, _ := symbols.ReadSymbolsFile(cart)
+
+	// the label in the lowest bank and at the lowest address is always found
+	for i := 0; i < 20; i++ {
+		if ok, _, _, address := syms.Search("DUP", symbols.UnspecifiedSymTable); !ok || address != 0x1030 {
+			t.Fatalf("unexpected search result for duplicate label (%#04x)", address)
+		}
+	}
+}
+
+func TestAnnotationsAnyBank(t *testing.T) {
+	syms := symbols.NewSymbols()
+
+	if err := syms.SetLabel(2, 0xf080, "high"); err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	if err := syms.SetLabel(0, 0xf080, "low"); err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+
+	// the label from the lowest numbered bank is preferred
+	if l, ok := syms.LookupLabel(-1, 0xf080); !ok || l != "low" {
+		t.Errorf("expected low label in any bank (%s)", l)
+	}
+
+	// a comment without a label is not found as a label
+	if err := syms.SetComment(1, 0xf080, "no label"); err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	if l, ok := syms.LookupLabel(-1, 0xf080); !ok || l != "low" {
+		t.Errorf("expected low label in any bank (%s)", l)
+	}
+
+	// removing a label falls back to the label in the next bank
+	if err := syms.SetLabel(0, 0xf080, ""); err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	if l, ok := syms.LookupLabel(-1, 0xf080); !ok || l != "high" {
+		t.Errorf("expected high label in any bank (%s)", l)
+	}
+
+	if err := syms.SetLabel(2, 0xf080, ""); err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	if l, ok := syms.LookupLabel(-1, 0xf080); ok {
+		t.Errorf("unexpected label in any bank (%s)", l)
+	}
+}
@@ -0,0 +1,137 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package symbols
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// batari Basic compiles a program (eg. game.bas) to an intermediate assembly
+// file which is then assembled by DASM. the symbols and listing files created
+// by DASM are named game.bas.symbol.txt and game.bas.list.txt
+const batariListingExt = ".list.txt"
+
+func isBatariListing(filename string) bool {
+	return strings.HasSuffix(strings.ToLower(filename), batariListingExt)
+}
+
+// every basic statement in the intermediate assembly is preceded by a label
+// and a comment containing the statement. for example:
+//
+//	.L00 ;  COLUPF = $0E
+//
+// user labels in the basic program are output in the same way.
+var batariStatementRegexp = regexp.MustCompile(`^\.(\w+)\s*;\s*(.*)$`)
+
+// add the statements in the listing to the source, mapping the addresses of
+// the generated code to the statement. basFilename is the name of the
+// original basic program, which is used to find the line number of each
+// statement.
+func (src *Source) addBatari(lines []listingLine, basFilename string) {
+	if len(lines) == 0 {
+		return
+	}
+
+	// the original basic program. if this cannot be read then the statements
+	// are numbered in the order they appear in the listing
+	bas := readLines(basFilename)
+	basName := filepath.Base(basFilename)
+	basIdx := 0
+
+	// statements only appear in the intermediate assembly file, which will be
+	// the first file in the listing
+	mainFile := lines[0].file
+
+	var stmt *SourceLine
+	stmtCt := 0
+
+	for _, l := range lines {
+		if l.file != mainFile {
+			stmt = nil
+			continue // for loop
+		}
+
+		if m := batariStatementRegexp.FindStringSubmatch(l.text); m != nil {
+			stmt = nil
+
+			text := strings.TrimSpace(m[2])
+			if bas == nil {
+				stmtCt++
+				stmt = src.add(basName, stmtCt, text)
+				continue // for loop
+			}
+
+			// find statement in the original basic program. the compiler
+			// reformats whitespace so we ignore it when comparing
+			for i := basIdx; i < len(bas); i++ {
+				if batariCompare(bas[i], text) {
+					stmt = src.add(basName, i+1, bas[i])
+					basIdx = i + 1
+					break // for loop
+				}
+			}
+
+			continue // for loop
+		}
+
+		if stmt != nil && len(l.bytes) > 0 {
+			src.setAddress(stmt, l.address, l.bytes)
+		}
+	}
+}
+
+// matches the rem keyword. rem must be a whole word so that variables such as
+// "remainder" are not mistaken for a comment.
+var batariRem = regexp.MustCompile(`(^|[^a-z0-9_])rem([^a-z0-9_]|$)`)
+
+// compare basic statements ignoring whitespace and case. the compiler does
+// not preserve comments so anything after a semicolon or rem in the original
+// is ignored.
+func batariCompare(original string, statement string) bool {
+	norm := func(s string) string {
+		s = strings.ToLower(s)
+		if i := strings.Index(s, ";"); i >= 0 {
+			s = s[:i]
+		}
+		if m := batariRem.FindStringSubmatchIndex(s); m != nil {
+			s = s[:m[3]]
+		}
+		return strings.Join(strings.Fields(s), "")
+	}
+	a := norm(original)
+	return a != "" && a == norm(statement)
+}
+
+// read all lines of a text file. returns nil if the file cannot be read.
+func readLines(filename string) []string {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+
+	return lines
+}
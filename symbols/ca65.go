@@ -0,0 +1,240 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package symbols
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// a single record from a ca65 debug information file. each line in the file
+// is a record type followed by a comma separated list of key/value pairs. for
+// example:
+//
+//	span	id=0,seg=0,start=0,size=2
+type ca65Record map[string]string
+
+func (rec ca65Record) int(key string) (int, bool) {
+	v, ok := rec[key]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(v, 0, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// parse the key/value pairs in a record. values may be quoted strings, which
+// may contain commas.
+func parseCA65Record(s string) ca65Record {
+	rec := make(ca65Record)
+
+	for len(s) > 0 {
+		eq := strings.Index(s, "=")
+		if eq < 0 {
+			break // for loop
+		}
+		key := strings.TrimSpace(s[:eq])
+		s = s[eq+1:]
+
+		var val string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.Index(s[1:], `"`)
+			if end < 0 {
+				val = s[1:]
+				s = ""
+			} else {
+				val = s[1 : end+1]
+				s = strings.TrimPrefix(s[end+2:], ",")
+			}
+		} else {
+			comma := strings.Index(s, ",")
+			if comma < 0 {
+				val = s
+				s = ""
+			} else {
+				val = s[:comma]
+				s = s[comma+1:]
+			}
+		}
+
+		rec[key] = val
+	}
+
+	return rec
+}
+
+// read a debug information file as produced by the --dbgfile option of ld65.
+// labels and equates are added to the symbols tables and line information is
+// used to map addresses to source lines.
+func (sym *Symbols) readCA65(cart *cartridge.Cartridge, filename string) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("processing error: %v", err)
+	}
+
+	// records of interest, indexed by ID
+	files := make(map[int]ca65Record)
+	segs := make(map[int]ca65Record)
+	spans := make(map[int]ca65Record)
+	lines := make([]ca65Record, 0)
+
+	for _, s := range strings.Split(string(data), "\n") {
+		f := strings.Fields(s)
+		if len(f) < 2 {
+			continue // for loop
+		}
+
+		rec := parseCA65Record(strings.TrimSpace(s[len(f[0]):]))
+		id, _ := rec.int("id")
+
+		switch f[0] {
+		case "file":
+			files[id] = rec
+		case "seg":
+			segs[id] = rec
+		case "span":
+			spans[id] = rec
+		case "line":
+			lines = append(lines, rec)
+		case "sym":
+			sym.addCA65Symbol(rec)
+		}
+	}
+
+	// the output file is used to supply the bytes for each source line. the
+	// output file is assumed to be the cartridge
+	var rom []uint8
+	if banks, err := cart.CopyBanks(); err == nil {
+		for _, b := range banks {
+			rom = append(rom, b.Data...)
+		}
+	}
+
+	src := sym.source(filepath.Dir(filename))
+
+	for _, l := range lines {
+		// ignore lines that are the result of macro expansion. the line that
+		// invoked the macro will have the same span
+		if t, ok := l.int("type"); ok && t == 2 {
+			continue // for loop
+		}
+
+		fid, ok := l.int("file")
+		if !ok {
+			continue // for loop
+		}
+		file, ok := files[fid]
+		if !ok {
+			continue // for loop
+		}
+		n, ok := l.int("line")
+		if !ok || n < 1 {
+			continue // for loop
+		}
+
+		ln := src.add(file["name"], n, "")
+
+		// the span field is a list of span IDs separated by the plus symbol
+		if l["span"] == "" {
+			continue // for loop
+		}
+		for _, sid := range strings.Split(l["span"], "+") {
+			id, err := strconv.Atoi(sid)
+			if err != nil {
+				continue // for loop
+			}
+			span, ok := spans[id]
+			if !ok {
+				continue // for loop
+			}
+			segID, _ := span.int("seg")
+			seg, ok := segs[segID]
+			if !ok {
+				continue // for loop
+			}
+
+			start, _ := span.int("start")
+			size, _ := span.int("size")
+			segStart, _ := seg.int("start")
+
+			// spans of zero size don't generate any bytes
+			if size == 0 {
+				continue // for loop
+			}
+
+			// the bytes for the span are found in the output file
+			var bytes []uint8
+			if ooffs, ok := seg.int("ooffs"); ok {
+				from := ooffs + start
+				to := from + size
+				if to > from+3 {
+					to = from + 3
+				}
+				if from >= 0 && to <= len(rom) {
+					bytes = rom[from:to]
+				}
+			}
+
+			src.setAddress(ln, uint16(segStart+start), bytes)
+		}
+	}
+
+	src.readOriginals()
+
+	return nil
+}
+
+// add sym record to symbols tables. labels are added to the label table and
+// equates are added to the read and write tables.
+func (sym *Symbols) addCA65Symbol(rec ca65Record) {
+	name := rec["name"]
+	if name == "" {
+		return
+	}
+
+	val, ok := rec.int("val")
+	if !ok {
+		return
+	}
+	address := uint16(val)
+
+	switch rec["type"] {
+	case "lab":
+		ma, area := memorymap.MapAddress(address, true)
+		if area == memorymap.Cartridge {
+			sym.Label.add(ma, name, false)
+			return
+		}
+
+		// labels in RAM are treated like equates
+		fallthrough
+
+	case "equ":
+		ma, _ := memorymap.MapAddress(address, true)
+		sym.Read.add(ma, name, false)
+		ma, _ = memorymap.MapAddress(address, false)
+		sym.Write.add(ma, name, false)
+	}
+}
@@ -0,0 +1,208 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package symbols
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// read a symbols file as produced by the -s option of DASM.
+func (sym *Symbols) readDASMSymbols(filename string) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("processing error: %v", err)
+	}
+	lines := strings.Split(string(data), "\n")
+
+	// find interesting lines in the symbols file and add to the Symbols
+	// instance.
+	for _, ln := range lines {
+		// ignore uninteresting lines
+		p := strings.Fields(ln)
+		if len(p) < 2 || p[0] == "---" {
+			continue // for loop
+		}
+
+		// get address
+		address, err := strconv.ParseUint(p[1], 16, 16)
+		if err != nil {
+			continue // for loop
+		}
+
+		// get symbol
+		symbol := p[0]
+
+		// differentiate between labels and other symbols. this is a little
+		// heavy handed, but still, it's better than nothing.
+		if unicode.IsDigit(rune(symbol[0])) {
+			// if symbol begins with a number and a period then it is a label
+			i := strings.Index(symbol, ".")
+			if i != -1 {
+				ma, _ := memorymap.MapAddress(uint16(address), true)
+				sym.Label.add(ma, symbol[i:], false)
+			}
+		} else {
+			// (non-label) symbols are both a read and write symbol.
+			// compare to canonical vcs symbols which are specific to a read or
+			// write context
+			ma, _ := memorymap.MapAddress(uint16(address), true)
+			sym.Read.add(ma, symbol, false)
+			ma, _ = memorymap.MapAddress(uint16(address), false)
+			sym.Write.add(ma, symbol, false)
+		}
+	}
+
+	return nil
+}
+
+// a single line from a DASM listing file.
+type listingLine struct {
+	file       string
+	lineNumber int
+	text       string
+	address    uint16
+	bytes      []uint8
+}
+
+// the start of every line in a DASM listing. the line number, a flag
+// indicating an uninitialised segment, and the address
+var listingLineRegexp = regexp.MustCompile(`^\s*(\d+) ([ U])([0-9a-fA-F]{4,5})(.*)$`)
+
+// the marker at the start of every file in a DASM listing
+const listingFileMarker = "------- FILE "
+
+// parse a DASM listing file. only the final pass is included in the listing
+// so there's no need to worry about the output of earlier passes.
+func parseDASMListing(filename string) ([]listingLine, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("processing error: %v", err)
+	}
+
+	lines := make([]listingLine, 0)
+	file := ""
+
+	for _, s := range strings.Split(string(data), "\n") {
+		s = strings.TrimRight(s, "\r")
+
+		if strings.HasPrefix(s, listingFileMarker) {
+			f := strings.Fields(s[len(listingFileMarker):])
+			if len(f) > 0 {
+				file = f[0]
+			}
+			continue // for loop
+		}
+
+		m := listingLineRegexp.FindStringSubmatch(s)
+		if m == nil {
+			continue // for loop
+		}
+
+		// line zero is the include directive that opens a new file
+		n, _ := strconv.Atoi(m[1])
+		if n == 0 {
+			continue // for loop
+		}
+
+		address, _ := strconv.ParseUint(m[3], 16, 32)
+		ln := listingLine{
+			file:       file,
+			lineNumber: n,
+			address:    uint16(address),
+		}
+
+		// skip over symbol flags
+		rest := strings.TrimLeft(m[4], " \t")
+		for _, flg := range []string{"????", "str", "eqm"} {
+			rest = strings.TrimLeft(strings.TrimPrefix(rest, flg), " \t")
+		}
+
+		// generated bytes. at most four bytes are listed, the fourth being
+		// followed by an asterisk if there are more bytes than that
+		text := rest
+		bytes := make([]uint8, 0, 4)
+		for len(bytes) < 4 && len(text) >= 2 && isListingHex(text[0]) && isListingHex(text[1]) {
+			if len(text) > 2 && text[2] != ' ' && text[2] != '\t' && text[2] != '*' {
+				break // for loop
+			}
+			v, _ := strconv.ParseUint(text[:2], 16, 8)
+			bytes = append(bytes, uint8(v))
+			text = strings.TrimPrefix(text[2:], "*")
+			if len(text) > 0 && text[0] == ' ' {
+				text = text[1:]
+			}
+		}
+
+		// every line that generates bytes will also have some source. if
+		// there is no source then the "bytes" were really a label
+		if strings.TrimSpace(text) == "" {
+			text = rest
+			bytes = bytes[:0]
+		}
+
+		ln.text = strings.TrimLeft(text, " \t")
+
+		// uninitialised segments do not generate any bytes in the cartridge
+		if m[2] != "U" && len(bytes) > 0 {
+			ln.bytes = bytes
+		}
+
+		lines = append(lines, ln)
+	}
+
+	return lines, nil
+}
+
+// DASM writes hex values in lower case.
+func isListingHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f')
+}
+
+// read a listing file as produced by the -l option of DASM. the listing is
+// used to map addresses to source lines. listings for batari Basic programs
+// are handled specially so that addresses are mapped to the original basic
+// source rather than the intermediate assembly.
+func (sym *Symbols) readDASMListing(filename string) error {
+	lines, err := parseDASMListing(filename)
+	if err != nil {
+		return err
+	}
+
+	src := sym.source(filepath.Dir(filename))
+
+	if isBatariListing(filename) {
+		src.addBatari(lines, strings.TrimSuffix(filename, batariListingExt))
+	} else {
+		for _, l := range lines {
+			ln := src.add(l.file, l.lineNumber, l.text)
+			if len(l.bytes) > 0 {
+				src.setAddress(ln, l.address, l.bytes)
+			}
+		}
+	}
+
+	src.readOriginals()
+
+	return nil
+}
@@ -26,4 +26,14 @@
 //
 // ReadSymbolFile() will always give addresses the default or canonised symbol.
 // In this way it is a superset of the NewTable() function.
+//
+// Some symbols files (DASM listings, ca65 debug information and batari Basic
+// listings) also provide source information. In those cases, the Source field
+// of the Symbols type maps cartridge addresses to lines in the original source
+// files.
+//
+// User labels and comments can be added to any cartridge address with the
+// SetLabel() and SetComment() functions. These annotations are saved to disk,
+// keyed by the cartridge hash, and are reloaded by ReadSymbolsFile() whenever
+// the same cartridge is loaded.
 package symbols
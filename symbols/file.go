@@ -16,30 +16,61 @@
 package symbols
 
 import (
-	"fmt"
-	"io/ioutil"
 	"os"
-	"path"
-	"strconv"
+	"path/filepath"
 	"strings"
-	"unicode"
 
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
-	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
 )
 
-// ReadSymbolsFile initialises a symbols table from the symbols file for the
+// the formats of symbols file that can be read.
+type fileFormat int
+
+const (
+	formatUnknown fileFormat = iota
+	formatDASMSymbols
+	formatDASMListing
+	formatCA65
+)
+
+// decide on the format of a symbols file from its filename. batari Basic
+// uses DASM to assemble its output but names the symbols and listing files
+// differently.
+func formatFromFilename(filename string) fileFormat {
+	lc := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(lc, ".sym"), strings.HasSuffix(lc, ".symbol.txt"):
+		return formatDASMSymbols
+	case strings.HasSuffix(lc, ".lst"), strings.HasSuffix(lc, ".list.txt"):
+		return formatDASMListing
+	case strings.HasSuffix(lc, ".dbg"):
+		return formatCA65
+	}
+	return formatUnknown
+}
+
+// ReadSymbolsFile initialises a symbols table from the symbols files for the
 // specified cartridge
 //
 // Symbols instance will always be valid even if error is returned. for example,
 // if the symbols file cannot be opened the symbols file will still contain the
 // canonical vcs symbols file
 //
-// Currently, only symbols files generated by DASM are supported.
+// Symbols files are looked for in the same directory as the cartridge and
+// with the same base name. The following formats are supported:
+//
+//	DASM symbols files (.sym) and listing files (.lst)
+//	ca65/ld65 debug information files (.dbg)
+//	batari Basic symbols and listing files (.symbol.txt and .list.txt)
+//
+// All the files that can be found are read.
 func ReadSymbolsFile(cart *cartridge.Cartridge) (*Symbols, error) {
 	sym := NewSymbols()
 
+	// user annotations are loaded whether or not there is a symbols file
+	defer sym.logAnnotations(cart)
+
 	// prefer default symbol for an address over any symbol that has been
 	// specified in the symbols file. we always do this even in the event of
 	// there being no symbols file.
@@ -51,66 +82,91 @@ func ReadSymbolsFile(cart *cartridge.Cartridge) (*Symbols, error) {
 		return sym, nil
 	}
 
-	// try to open symbols file
-	symFilename := cart.Filename
-	ext := path.Ext(symFilename)
+	found := false
+	for _, fn := range Filenames(cart.Filename) {
+		if _, err := os.Stat(fn); err != nil {
+			continue
+		}
 
-	// try to figure out the case of the file extension
-	if ext == ".BIN" {
-		symFilename = fmt.Sprintf("%s.SYM", symFilename[:len(symFilename)-len(ext)])
-	} else {
-		symFilename = fmt.Sprintf("%s.sym", symFilename[:len(symFilename)-len(ext)])
+		err := sym.read(cart, fn)
+		if err != nil {
+			return sym, err
+		}
+
+		found = true
 	}
 
-	sf, err := os.Open(symFilename)
-	if err != nil {
+	if !found {
 		return sym, curated.Errorf("symbols: file not available (%s)", cart.Filename)
 	}
-	defer sf.Close()
 
-	data, err := ioutil.ReadAll(sf)
-	if err != nil {
-		return nil, curated.Errorf("symbols: processing error: %v", err)
+	return sym, nil
+}
+
+// Filenames returns the symbols files that ReadSymbolsFile() looks for, for the
+// named cartridge file. The files may or may not exist.
+func Filenames(cartFilename string) []string {
+	if cartFilename == "" {
+		return []string{}
 	}
-	lines := strings.Split(string(data), "\n")
-
-	// find interesting lines in the symbols file and add to the Symbols
-	// instance.
-	for _, ln := range lines {
-		// ignore uninteresting lines
-		p := strings.Fields(ln)
-		if len(p) < 2 || p[0] == "---" {
-			continue // for loop
-		}
 
-		// get address
-		address, err := strconv.ParseUint(p[1], 16, 16)
-		if err != nil {
-			continue // for loop
-		}
+	base := cartFilename[:len(cartFilename)-len(filepath.Ext(cartFilename))]
 
-		// get symbol
-		symbol := p[0]
-
-		// differentiate between labels and other symbols. this is a little
-		// heavy handed, but still, it's better than nothing.
-		if unicode.IsDigit(rune(symbol[0])) {
-			// if symbol begins with a number and a period then it is a label
-			i := strings.Index(symbol, ".")
-			if i != -1 {
-				ma, _ := memorymap.MapAddress(uint16(address), true)
-				sym.Label.add(ma, symbol[i:], false)
-			}
-		} else {
-			// (non-label) symbols are both a read and write symbol.
-			// compare to canonical vcs symbols which are specific to a read or
-			// write context
-			ma, _ := memorymap.MapAddress(uint16(address), true)
-			sym.Read.add(ma, symbol, false)
-			ma, _ = memorymap.MapAddress(uint16(address), false)
-			sym.Write.add(ma, symbol, false)
-		}
+	// try to figure out the case of the file extension
+	candidates := []string{".sym", ".lst", ".dbg", ".symbol.txt", ".list.txt"}
+	if filepath.Ext(cartFilename) == ".BIN" {
+		candidates = []string{".SYM", ".LST", ".DBG", ".symbol.txt", ".list.txt"}
+	}
+
+	fns := make([]string, len(candidates))
+	for i, ext := range candidates {
+		fns[i] = base + ext
+	}
+
+	return fns
+}
+
+// ReadSymbolsFileFrom initialises a symbols table from the named symbols file
+// rather than looking for symbols files with the same name as the cartridge.
+// The format of the file is decided by the file extension. See
+// ReadSymbolsFile() for the list of supported formats.
+//
+// If the filename is empty then the function is the same as ReadSymbolsFile().
+func ReadSymbolsFileFrom(cart *cartridge.Cartridge, filename string) (*Symbols, error) {
+	if filename == "" {
+		return ReadSymbolsFile(cart)
+	}
+
+	sym := NewSymbols()
+	defer sym.logAnnotations(cart)
+	defer sym.canonise(cart)
+
+	err := sym.read(cart, filename)
+	if err != nil {
+		return sym, err
 	}
 
 	return sym, nil
 }
+
+// read symbols file, deciding on the format from the filename.
+func (sym *Symbols) read(cart *cartridge.Cartridge, filename string) error {
+	var err error
+
+	switch formatFromFilename(filename) {
+	case formatDASMSymbols:
+		err = sym.readDASMSymbols(filename)
+	case formatDASMListing:
+		err = sym.readDASMListing(filename)
+	case formatCA65:
+		err = sym.readCA65(cart, filename)
+	default:
+		return curated.Errorf("symbols: unrecognised symbols file (%s)", filename)
+	}
+
+	if err != nil {
+		return curated.Errorf("symbols: %v", err)
+	}
+
+	return nil
+}
@@ -16,7 +16,9 @@
 package symbols
 
 import (
+	"fmt"
 	"io"
+	"strings"
 )
 
 // ListSymbols outputs every symbol used in the current ROM.
@@ -30,6 +32,17 @@ func (sym *Symbols) ListSymbols(output io.Writer) {
 func (sym *Symbols) ListLabels(output io.Writer) {
 	output.Write([]byte("Labels\n---------\n"))
 	output.Write([]byte(sym.Label.String()))
+
+	s := strings.Builder{}
+	for _, a := range sym.Annotations() {
+		if a.Label != "" {
+			s.WriteString(fmt.Sprintf("%#04x -> %s (bank %d)\n", a.Address, a.Label, a.Bank))
+		}
+	}
+	if s.Len() > 0 {
+		output.Write([]byte("\nUser Labels\n-----------\n"))
+		output.Write([]byte(s.String()))
+	}
 }
 
 // ListReadSymbols outputs every read symbol used in the current ROM.
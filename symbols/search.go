@@ -16,7 +16,10 @@
 package symbols
 
 import (
+	"sort"
 	"strings"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
 )
 
 // TableType is used to select and identify a symbol table
@@ -53,6 +56,9 @@ func (sym *Symbols) Search(symbol string, target TableType) (bool, TableType, st
 	symbolUpper := strings.ToUpper(symbol)
 
 	if target == UnspecifiedSymTable || target == LabelTable {
+		if addr, ok := sym.annotations.search(symbolUpper); ok {
+			return true, LabelTable, symbol, addr
+		}
 		if addr, ok := sym.Label.search(symbolUpper); ok {
 			return true, LabelTable, symbol, addr
 		}
@@ -72,3 +78,26 @@ func (sym *Symbols) Search(symbol string, target TableType) (bool, TableType, st
 
 	return false, UnspecifiedSymTable, symbol, 0
 }
+
+// NearestLabel returns the label at the address or, if there is no label at
+// that address, the label immediately preceding it. The address is mapped
+// before searching and the label must be in the same memory area. Returns
+// false if there is no suitable label.
+func (sym *Symbols) NearestLabel(address uint16) (string, uint16, bool) {
+	ma, area := memorymap.MapAddress(address, true)
+
+	idx := sym.Label.idx
+	i := sort.Search(len(idx), func(i int) bool {
+		return idx[i] > ma
+	})
+
+	if i == 0 {
+		return "", 0, false
+	}
+
+	if _, a := memorymap.MapAddress(idx[i-1], true); a != area {
+		return "", 0, false
+	}
+
+	return sym.Label.Entries[idx[i-1]], idx[i-1], true
+}
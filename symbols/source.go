@@ -0,0 +1,203 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package symbols
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// SourceLine is a single line in a source file.
+type SourceLine struct {
+	File *SourceFile
+
+	// line number in the source file. the first line is line 1
+	LineNumber int
+
+	// text of the source line. tabs are preserved
+	Text string
+
+	// the (mapped) address of the code or data generated by the line. only
+	// valid if HasAddress is true
+	Address    uint16
+	HasAddress bool
+
+	// the bytes generated by the line. not all source formats provide this
+	// information. used to distinguish between source lines that generate code
+	// at the same address but in different cartridge banks
+	Bytes []uint8
+}
+
+func (ln *SourceLine) String() string {
+	return fmt.Sprintf("%s:%d", ln.File.Filename, ln.LineNumber)
+}
+
+// SourceFile is a single source file.
+type SourceFile struct {
+	// the filename as it was named in the symbols file
+	Filename string
+
+	// lines in the file. there will be a nil entry for any lines that are
+	// unknown. Lines[0] is line 1 in the file
+	Lines []*SourceLine
+}
+
+// Line returns the numbered line in the file. Line numbers start at 1.
+// Returns nil if the line is not known.
+func (fl *SourceFile) Line(lineNumber int) *SourceLine {
+	if lineNumber < 1 || lineNumber > len(fl.Lines) {
+		return nil
+	}
+	return fl.Lines[lineNumber-1]
+}
+
+// Source maps cartridge addresses to lines in the original source files.
+type Source struct {
+	// source files indexed by filename
+	Files map[string]*SourceFile
+
+	// filenames in the order they were first encountered
+	Filenames []string
+
+	// source lines indexed by mapped address. there may be more than one
+	// line per address in the case of multi-bank cartridges
+	addresses map[uint16][]*SourceLine
+
+	// the directory in which the symbols file was found. used to locate the
+	// original source files
+	dir string
+}
+
+func newSource(dir string) *Source {
+	return &Source{
+		Files:     make(map[string]*SourceFile),
+		Filenames: make([]string, 0),
+		addresses: make(map[uint16][]*SourceLine),
+		dir:       dir,
+	}
+}
+
+// return existing file or create a new one.
+func (src *Source) file(filename string) *SourceFile {
+	if fl, ok := src.Files[filename]; ok {
+		return fl
+	}
+	fl := &SourceFile{Filename: filename}
+	src.Files[filename] = fl
+	src.Filenames = append(src.Filenames, filename)
+	return fl
+}
+
+// add a line to the source. replaces any existing line with the same file
+// and line number.
+func (src *Source) add(filename string, lineNumber int, text string) *SourceLine {
+	fl := src.file(filename)
+	for len(fl.Lines) < lineNumber {
+		fl.Lines = append(fl.Lines, nil)
+	}
+
+	ln := &SourceLine{
+		File:       fl,
+		LineNumber: lineNumber,
+		Text:       text,
+	}
+	fl.Lines[lineNumber-1] = ln
+
+	return ln
+}
+
+// note that the source line generates code at the address.
+func (src *Source) setAddress(ln *SourceLine, address uint16, bytes []uint8) {
+	ma, _ := memorymap.MapAddress(address, true)
+	ln.Address = ma
+	ln.HasAddress = true
+	ln.Bytes = bytes
+	src.addresses[ma] = append(src.addresses[ma], ln)
+}
+
+// replace the text of every line with the text from the original source
+// files, if they can be found. the text in some symbols files is not an exact
+// copy of the original (DASM listings for example). missing files are not an
+// error.
+func (src *Source) readOriginals() {
+	for _, fl := range src.Files {
+		f, err := os.Open(filepath.Join(src.dir, fl.Filename))
+		if err != nil {
+			continue
+		}
+
+		n := 0
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			n++
+			text := strings.TrimRight(scanner.Text(), "\r")
+			if ln := fl.Line(n); ln != nil {
+				ln.Text = text
+			} else {
+				// add lines that are not in the symbols file
+				for len(fl.Lines) < n {
+					fl.Lines = append(fl.Lines, nil)
+				}
+				fl.Lines[n-1] = &SourceLine{File: fl, LineNumber: n, Text: text}
+			}
+		}
+
+		f.Close()
+	}
+}
+
+// Lookup returns the source line for the address. The bytecode argument is
+// used to choose between lines in different banks that generate code for the
+// same address; it can be nil, in which case the first matching line is
+// returned.
+func (src *Source) Lookup(address uint16, bytecode []uint8) (*SourceLine, bool) {
+	if src == nil {
+		return nil, false
+	}
+
+	ma, _ := memorymap.MapAddress(address, true)
+	lns := src.addresses[ma]
+	if len(lns) == 0 {
+		return nil, false
+	}
+
+	if len(lns) == 1 || bytecode == nil {
+		return lns[0], true
+	}
+
+	for _, ln := range lns {
+		if len(ln.Bytes) == 0 || len(ln.Bytes) > len(bytecode) {
+			continue
+		}
+		match := true
+		for i := range ln.Bytes {
+			if ln.Bytes[i] != bytecode[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return ln, true
+		}
+	}
+
+	return lns[0], true
+}
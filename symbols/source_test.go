@@ -0,0 +1,140 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package symbols_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
+	"github.com/jetsetilly/gopher2600/symbols"
+)
+
+func lookup(t *testing.T, syms *symbols.Symbols, address uint16, bytecode []uint8, expected string) {
+	t.Helper()
+
+	ln, ok := syms.Source.Lookup(address, bytecode)
+	if expected == "" {
+		if ok {
+			t.Errorf("unexpected source line for %#04x (%s)", address, ln)
+		}
+		return
+	}
+
+	if !ok {
+		t.Errorf("expected source line for %#04x", address)
+		return
+	}
+	if ln.String() != expected {
+		t.Errorf("unexpected source line for %#04x (%s, expected %s)", address, ln, expected)
+	}
+}
+
+func TestDASMListing(t *testing.T) {
+	cart := cartridge.NewCartridge(nil)
+
+	syms, err := symbols.ReadSymbolsFileFrom(cart, "testdata/source.lst")
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+	if syms.Source == nil {
+		t.Fatalf("expected source information")
+	}
+
+	lookup(t, syms, 0xf000, nil, "source.asm:5")
+	lookup(t, syms, 0xf001, nil, "source.asm:6")
+	lookup(t, syms, 0xf002, nil, "")
+	lookup(t, syms, 0xf004, nil, "source.asm:8")
+	lookup(t, syms, 0xf007, nil, "source.asm:9")
+	lookup(t, syms, 0xfffc, nil, "source.asm:11")
+
+	// the same address in a different mirror
+	lookup(t, syms, 0x1001, nil, "source.asm:6")
+
+	ln, _ := syms.Source.Lookup(0xf001, nil)
+	if ln.Text != "ldx\t#$ff" {
+		t.Errorf("unexpected source text (%q)", ln.Text)
+	}
+	if len(ln.Bytes) != 2 || ln.Bytes[0] != 0xa2 || ln.Bytes[1] != 0xff {
+		t.Errorf("unexpected source bytes (%v)", ln.Bytes)
+	}
+
+	// label lines do not generate bytes
+	if ln := syms.Source.Files["source.asm"].Line(4); ln == nil || ln.HasAddress || ln.Text != "start" {
+		t.Errorf("unexpected label line (%v)", ln)
+	}
+}
+
+func TestCA65(t *testing.T) {
+	cart := cartridge.NewCartridge(nil)
+
+	syms, err := symbols.ReadSymbolsFileFrom(cart, "testdata/source.dbg")
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+
+	lookup(t, syms, 0xf000, nil, "source.s:4")
+	lookup(t, syms, 0xf001, nil, "source.s:5")
+
+	// line 6 is a macro expansion and is ignored
+	lookup(t, syms, 0xf003, nil, "")
+
+	if syms.Label.Entries[0x1000] != "reset" {
+		t.Errorf("expected reset label")
+	}
+	if syms.Read.Entries[0x80] != "counter" || syms.Write.Entries[0x80] != "counter" {
+		t.Errorf("expected counter symbol")
+	}
+}
+
+func TestBatariListing(t *testing.T) {
+	cart := cartridge.NewCartridge(nil)
+
+	syms, err := symbols.ReadSymbolsFileFrom(cart, "testdata/game.bas.list.txt")
+	if err != nil {
+		t.Fatalf("unexpected error (%s)", err)
+	}
+
+	// addresses refer to lines in the original basic program
+	lookup(t, syms, 0xf000, nil, "game.bas:5")
+	lookup(t, syms, 0xf002, nil, "game.bas:5")
+	lookup(t, syms, 0xf004, nil, "game.bas:6")
+	lookup(t, syms, 0xf007, nil, "game.bas:7")
+
+	// the statement on line 6 has a trailing rem comment. the statement on
+	// line 8 has a variable name beginning with rem
+	lookup(t, syms, 0xf00a, nil, "game.bas:8")
+	lookup(t, syms, 0xf00c, nil, "game.bas:8")
+
+	// code in the kernel does not belong to any statement
+	lookup(t, syms, 0xf800, nil, "")
+
+	ln, _ := syms.Source.Lookup(0xf000, nil)
+	if ln.Text != "  COLUPF=$0E" {
+		t.Errorf("unexpected source text (%q)", ln.Text)
+	}
+}
+
+func TestUnrecognisedSymbolsFile(t *testing.T) {
+	cart := cartridge.NewCartridge(nil)
+
+	syms, err := symbols.ReadSymbolsFileFrom(cart, "testdata/game.bas")
+	if err == nil {
+		t.Errorf("expected error for unrecognised symbols file")
+	}
+	if syms == nil {
+		t.Errorf("symbols should be valid even on error")
+	}
+}
@@ -31,6 +31,13 @@ type Symbols struct {
 	Label *Table
 	Read  *Table
 	Write *Table
+
+	// mapping of addresses to source lines. will be nil if the symbols file
+	// did not provide any source information
+	Source *Source
+
+	// user supplied labels and comments. see annotations.go
+	annotations *annotations
 }
 
 // NewSymbols is the preferred method of initialisation for the Symbols type. In
@@ -40,12 +47,19 @@ func NewSymbols() *Symbols {
 		Label: newTable(),
 		Read:  newTable(),
 		Write: newTable(),
+
+		annotations: newAnnotations(),
 	}
 	sym.canonise(nil)
 	return sym
 }
 
 func (sym *Symbols) LabelWidth() int {
+	sym.annotations.crit.Lock()
+	defer sym.annotations.crit.Unlock()
+	if sym.annotations.maxWidth > sym.Label.maxWidth {
+		return sym.annotations.maxWidth
+	}
 	return sym.Label.maxWidth
 }
 
@@ -56,6 +70,14 @@ func (sym *Symbols) SymbolWidth() int {
 	return sym.Write.maxWidth
 }
 
+// return the Source instance, creating it if necessary.
+func (sym *Symbols) source(dir string) *Source {
+	if sym.Source == nil {
+		sym.Source = newSource(dir)
+	}
+	return sym.Source
+}
+
 // put canonical symbols into table. prefer flag should be true if canonical
 // names are to supercede any existing symbol.
 func (sym *Symbols) canonise(cart *cartridge.Cartridge) {
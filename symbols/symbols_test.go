@@ -54,6 +54,33 @@ func TestFlappySymbols(t *testing.T) {
 	}
 }
 
+func TestNearestLabel(t *testing.T) {
+	cart := cartridge.NewCartridge(nil)
+	cart.Filename = "testdata/flappy.bin"
+
+	syms, err := symbols.ReadSymbolsFile(cart)
+	if err != nil {
+		t.Errorf("unexpected error (%s)", err)
+	}
+
+	l, a, ok := syms.NearestLabel(0xf28d)
+	if !ok || l != ".vsync" || a != 0x128d {
+		t.Errorf("unexpected nearest label (%s %#04x)", l, a)
+	}
+
+	l, a, ok = syms.NearestLabel(0xf292)
+	if !ok || l != ".VSLP1" || a != 0x128f {
+		t.Errorf("unexpected nearest label (%s %#04x)", l, a)
+	}
+
+	// there is no cartridge label before 0x1285. the labels in RAM should not
+	// be returned
+	_, _, ok = syms.NearestLabel(0xf284)
+	if ok {
+		t.Errorf("unexpected nearest label for address outside of the cartridge")
+	}
+}
+
 const expectedDefaultSymbols = `Labels
 ---------
 
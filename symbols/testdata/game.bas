@@ -0,0 +1,8 @@
+; a simple program
+
+  rem set up
+main
+  COLUPF=$0E
+  drawscreen rem draw the screen
+  goto main
+  remainder=1
@@ -0,0 +1,25 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package tracker records writes to the TIA audio registers. Each write is
+// timestamped with the frame, scanline and horizontal position of the
+// television at the moment of the write.
+//
+// The Tracker type implements the audio.Tracker interface and should be
+// attached to the TIA audio sub-system with the SetTracker() function.
+//
+// The recorded entries can be exported as plain text or as CSV. See the
+// Export() function for details.
+package tracker
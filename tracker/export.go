@@ -0,0 +1,131 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package tracker
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// Format specifies how entries are written by the Export() function.
+type Format int
+
+// List of valid Format values.
+const (
+	// one entry per line in the same form as Entry.String()
+	FormatText Format = iota
+
+	// one entry per line with a header line naming the fields. register
+	// values are in decimal
+	FormatCSV
+)
+
+// FormatFromString returns the Format for the name given. Valid names are
+// TEXT and CSV.
+func FormatFromString(s string) (Format, error) {
+	switch strings.ToUpper(s) {
+	case "TEXT":
+		return FormatText, nil
+	case "CSV":
+		return FormatCSV, nil
+	}
+	return FormatText, curated.Errorf("tracker: unknown format (%s)", s)
+}
+
+// FormatFromFilename returns the Format most suitable for the filename. Files
+// ending in .csv are CSV files. All other files are text files.
+func FormatFromFilename(filename string) Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV
+	}
+	return FormatText
+}
+
+// Extension returns the preferred filename extension for the format.
+func (f Format) Extension() string {
+	switch f {
+	case FormatCSV:
+		return ".csv"
+	}
+	return ".txt"
+}
+
+// Export writes the entries to w in the specified format.
+func Export(w io.Writer, format Format, entries []Entry) error {
+	var err error
+
+	switch format {
+	case FormatText:
+		err = exportText(w, entries)
+	case FormatCSV:
+		err = exportCSV(w, entries)
+	default:
+		err = fmt.Errorf("unknown format")
+	}
+
+	if err != nil {
+		return curated.Errorf("tracker: %v", err)
+	}
+
+	return nil
+}
+
+func exportText(w io.Writer, entries []Entry) error {
+	for _, e := range entries {
+		if _, err := io.WriteString(w, fmt.Sprintf("%s\n", e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportCSV(w io.Writer, entries []Entry) error {
+	c := csv.NewWriter(w)
+
+	err := c.Write([]string{"frame", "scanline", "clock", "register",
+		"AUDC0", "AUDF0", "AUDV0", "AUDC1", "AUDF1", "AUDV1"})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		err := c.Write([]string{
+			strconv.Itoa(e.Frame),
+			strconv.Itoa(e.Scanline),
+			strconv.Itoa(e.Clock),
+			e.Register,
+			strconv.Itoa(int(e.Channel[0].Control)),
+			strconv.Itoa(int(e.Channel[0].Freq)),
+			strconv.Itoa(int(e.Channel[0].Volume)),
+			strconv.Itoa(int(e.Channel[1].Control)),
+			strconv.Itoa(int(e.Channel[1].Freq)),
+			strconv.Itoa(int(e.Channel[1].Volume)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	c.Flush()
+	return c.Error()
+}
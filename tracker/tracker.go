@@ -0,0 +1,149 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package tracker
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/tia/audio"
+)
+
+// MaxEntries is the maximum number of entries kept by the tracker. Once the
+// maximum has been reached the oldest entries are forgotten.
+const MaxEntries = 100000
+
+// TV defines the television functions required by the Tracker.
+type TV interface {
+	GetState(signal.StateReq) int
+}
+
+// Entry is a single write to an audio register.
+type Entry struct {
+	Frame    int
+	Scanline int
+	Clock    int
+
+	// the register that was written to. one of AUDC0, AUDC1, AUDF0, AUDF1,
+	// AUDV0 or AUDV1
+	Register string
+
+	// the value of the registers for each channel after the write
+	Channel [2]audio.Registers
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("%04d %03d %03d %-5s  %s  %s", e.Frame, e.Scanline, e.Clock,
+		e.Register, e.Channel[0], e.Channel[1])
+}
+
+// returns true if entry e happened after entry f.
+func (e Entry) after(f Entry) bool {
+	if e.Frame != f.Frame {
+		return e.Frame > f.Frame
+	}
+	if e.Scanline != f.Scanline {
+		return e.Scanline > f.Scanline
+	}
+	return e.Clock > f.Clock
+}
+
+// Tracker implements the audio.Tracker interface.
+type Tracker struct {
+	tv TV
+
+	// entries are added by the emulation and read by the GUI
+	crit    sync.Mutex
+	entries []Entry
+}
+
+// NewTracker is the preferred method of initialisation for the Tracker type.
+func NewTracker(tv TV) *Tracker {
+	return &Tracker{
+		tv:      tv,
+		entries: make([]Entry, 0, 1024),
+	}
+}
+
+// AudioEvent implements the audio.Tracker interface.
+func (tr *Tracker) AudioEvent(register string, channel0 audio.Registers, channel1 audio.Registers) {
+	e := Entry{
+		Frame:    tr.tv.GetState(signal.ReqFramenum),
+		Scanline: tr.tv.GetState(signal.ReqScanline),
+		Clock:    tr.tv.GetState(signal.ReqHorizPos),
+		Register: register,
+		Channel:  [2]audio.Registers{channel0, channel1},
+	}
+
+	tr.crit.Lock()
+	defer tr.crit.Unlock()
+
+	// if the emulation has been rewound then the new entry will be no later
+	// than the most recent entries. we forget the entries that are now in the
+	// future (or the present), they will be added again as the emulation
+	// catches up
+	for len(tr.entries) > 0 && !e.after(tr.entries[len(tr.entries)-1]) {
+		tr.entries = tr.entries[:len(tr.entries)-1]
+	}
+
+	if len(tr.entries) >= MaxEntries {
+		tr.entries = tr.entries[1:]
+	}
+
+	tr.entries = append(tr.entries, e)
+}
+
+// Len returns the number of entries in the tracker.
+func (tr *Tracker) Len() int {
+	tr.crit.Lock()
+	defer tr.crit.Unlock()
+	return len(tr.entries)
+}
+
+// Copy returns a copy of the entries in the tracker. The oldest entry is
+// first.
+func (tr *Tracker) Copy() []Entry {
+	tr.crit.Lock()
+	defer tr.crit.Unlock()
+
+	c := make([]Entry, len(tr.entries))
+	copy(c, tr.entries)
+	return c
+}
+
+// Recent returns a copy of the most recent n entries in the tracker. The
+// oldest entry is first.
+func (tr *Tracker) Recent(n int) []Entry {
+	tr.crit.Lock()
+	defer tr.crit.Unlock()
+
+	if n > len(tr.entries) {
+		n = len(tr.entries)
+	} else if n < 0 {
+		n = 0
+	}
+	c := make([]Entry, n)
+	copy(c, tr.entries[len(tr.entries)-n:])
+	return c
+}
+
+// Clear forgets all entries in the tracker.
+func (tr *Tracker) Clear() {
+	tr.crit.Lock()
+	defer tr.crit.Unlock()
+	tr.entries = tr.entries[:0]
+}
@@ -0,0 +1,130 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package tracker_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/tia/audio"
+	"github.com/jetsetilly/gopher2600/tracker"
+)
+
+type mockTV struct {
+	frame    int
+	scanline int
+	clock    int
+}
+
+func (tv *mockTV) GetState(req signal.StateReq) int {
+	switch req {
+	case signal.ReqFramenum:
+		return tv.frame
+	case signal.ReqScanline:
+		return tv.scanline
+	case signal.ReqHorizPos:
+		return tv.clock
+	}
+	return 0
+}
+
+func TestTracker(t *testing.T) {
+	tv := &mockTV{}
+	tr := tracker.NewTracker(tv)
+
+	tv.frame = 1
+	tv.scanline = 10
+	tr.AudioEvent("AUDC0", audio.Registers{Control: 4}, audio.Registers{})
+	tv.scanline = 11
+	tr.AudioEvent("AUDF0", audio.Registers{Control: 4, Freq: 10}, audio.Registers{})
+	tv.frame = 3
+	tv.scanline = 5
+	tr.AudioEvent("AUDV1", audio.Registers{Control: 4, Freq: 10}, audio.Registers{Volume: 8})
+
+	if tr.Len() != 3 {
+		t.Fatalf("unexpected number of entries (%d)", tr.Len())
+	}
+
+	// rewinding to an earlier point forgets the later entries
+	tv.frame = 1
+	tv.scanline = 11
+	tr.AudioEvent("AUDF0", audio.Registers{Control: 4, Freq: 10}, audio.Registers{})
+
+	e := tr.Copy()
+	if len(e) != 2 {
+		t.Fatalf("unexpected number of entries after rewind (%d)", len(e))
+	}
+	if e[1].Frame != 1 || e[1].Scanline != 11 || e[1].Register != "AUDF0" {
+		t.Errorf("unexpected entry after rewind (%s)", e[1])
+	}
+
+	r := tr.Recent(5)
+	if len(r) != 2 || r[1] != e[1] {
+		t.Errorf("unexpected recent entries")
+	}
+
+	tr.Clear()
+	if tr.Len() != 0 {
+		t.Errorf("entries remain after clear")
+	}
+}
+
+func TestExport(t *testing.T) {
+	entries := []tracker.Entry{
+		{Frame: 1, Scanline: 10, Clock: 20, Register: "AUDC0",
+			Channel: [2]audio.Registers{{Control: 4}, {}}},
+		{Frame: 3, Scanline: 5, Clock: 40, Register: "AUDV1",
+			Channel: [2]audio.Registers{{Control: 4}, {Volume: 8}}},
+	}
+
+	s := &strings.Builder{}
+	err := tracker.Export(s, tracker.FormatCSV, entries)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "frame,scanline,clock,register,AUDC0,AUDF0,AUDV0,AUDC1,AUDF1,AUDV1\n" +
+		"1,10,20,AUDC0,4,0,0,0,0,0\n" +
+		"3,5,40,AUDV1,4,0,0,0,0,8\n"
+	if s.String() != expected {
+		t.Errorf("unexpected CSV output:\n%s", s.String())
+	}
+
+	s.Reset()
+	err = tracker.Export(s, tracker.FormatText, entries)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(s.String(), "\n") != len(entries) {
+		t.Errorf("unexpected number of lines in text output")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	if tracker.FormatFromFilename("music.CSV") != tracker.FormatCSV {
+		t.Errorf("csv filename not recognised")
+	}
+	if tracker.FormatFromFilename("music") != tracker.FormatText {
+		t.Errorf("text should be the default format")
+	}
+
+	if f, err := tracker.FormatFromString("csv"); err != nil || f != tracker.FormatCSV {
+		t.Errorf("csv format not recognised")
+	}
+	if _, err := tracker.FormatFromString("mp3"); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
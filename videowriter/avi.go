@@ -0,0 +1,383 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package videowriter
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+
+	tiaAudio "github.com/jetsetilly/gopher2600/hardware/tia/audio"
+)
+
+// sizes of the fixed length AVI structures.
+const (
+	aviMainHeaderLen   = 56
+	aviStreamHeaderLen = 56
+	aviBitmapInfoLen   = 40
+	aviWaveFormatLen   = 18
+
+	// strl lists for the video and audio streams. the list type, stream
+	// header and stream format
+	aviVideoStrlLen = 4 + 8 + aviStreamHeaderLen + 8 + aviBitmapInfoLen
+	aviAudioStrlLen = 4 + 8 + aviStreamHeaderLen + 8 + aviWaveFormatLen
+
+	// hdrl list. the list type, main header and the two strl lists
+	aviHdrlLen = 4 + 8 + aviMainHeaderLen + 8 + aviVideoStrlLen + 8 + aviAudioStrlLen
+)
+
+// flags used in the AVI file.
+const (
+	aviHasIndex      = 0x10
+	aviIsInterleaved = 0x100
+	aviKeyFrame      = 0x10
+)
+
+// the RIFF format uses 32 bit sizes.
+const aviMaxLen = math.MaxUint32
+
+type aviIndexEntry struct {
+	id     string
+	offset uint32
+	size   uint32
+}
+
+// aviEncoder writes an uncompressed AVI file. the video stream is 24 bit RGB
+// and the audio stream is unsigned 8 bit PCM.
+type aviEncoder struct {
+	f   *os.File
+	buf *bufio.Writer
+
+	// number of bytes written to the file
+	pos int64
+
+	// dimensions and size of each video frame in bytes
+	width    int
+	height   int
+	frameLen int
+
+	// number of video frames and audio samples written
+	frames  uint32
+	samples uint32
+
+	// frame rate of the video. the audio rate is decided by the frame rate and
+	// the number of samples recorded per frame
+	fps float32
+
+	// file offsets of values that can only be written once recording has
+	// finished
+	riffLen     int64
+	totalFrames int64
+	videoLength int64
+	audioLength int64
+	moviLen     int64
+	audioRate   int64
+	audioFreq   int64
+	audioBytes  int64
+
+	// file offset of the movi list type. the offsets in the index are
+	// relative to this
+	movi int64
+
+	index []aviIndexEntry
+
+	// frame data in the order required by the AVI format
+	bgr []uint8
+}
+
+func newAVI(filename string) (*aviEncoder, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &aviEncoder{
+		f:   f,
+		buf: bufio.NewWriter(f),
+	}, nil
+}
+
+func (enc *aviEncoder) write(b []byte) {
+	_, _ = enc.buf.Write(b)
+	enc.pos += int64(len(b))
+}
+
+func (enc *aviEncoder) fourcc(s string) {
+	enc.write([]byte(s))
+}
+
+func (enc *aviEncoder) uint32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	enc.write(b[:])
+}
+
+func (enc *aviEncoder) uint16(v uint16) {
+	var b [2]byte
+	binary.LittleEndian.PutUint16(b[:], v)
+	enc.write(b[:])
+}
+
+// placeholder writes a zero value and returns the offset of that value in
+// the file. the real value is written by patch().
+func (enc *aviEncoder) placeholder() int64 {
+	p := enc.pos
+	enc.uint32(0)
+	return p
+}
+
+func (enc *aviEncoder) patch(offset int64, v uint32) error {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	_, err := enc.f.WriteAt(b[:], offset)
+	return err
+}
+
+func (enc *aviEncoder) start(width int, height int, fps float32) error {
+	enc.width = width
+	enc.height = height
+	enc.frameLen = width * height * 3
+	enc.bgr = make([]uint8, enc.frameLen)
+	enc.fps = fps
+
+	rate := uint32(math.Round(float64(fps) * 1000))
+
+	enc.fourcc("RIFF")
+	enc.riffLen = enc.placeholder()
+	enc.fourcc("AVI ")
+
+	enc.fourcc("LIST")
+	enc.uint32(aviHdrlLen)
+	enc.fourcc("hdrl")
+
+	// main header
+	enc.fourcc("avih")
+	enc.uint32(aviMainHeaderLen)
+	enc.uint32(uint32(math.Round(1000000 / float64(fps))))
+	enc.uint32(uint32(enc.frameLen)*uint32(math.Ceil(float64(fps))) + tiaAudio.SampleFreq)
+	enc.uint32(0)
+	enc.uint32(aviHasIndex | aviIsInterleaved)
+	enc.totalFrames = enc.placeholder()
+	enc.uint32(0)
+	enc.uint32(2)
+	enc.uint32(uint32(enc.frameLen))
+	enc.uint32(uint32(width))
+	enc.uint32(uint32(height))
+	for i := 0; i < 4; i++ {
+		enc.uint32(0)
+	}
+
+	// video stream
+	enc.fourcc("LIST")
+	enc.uint32(aviVideoStrlLen)
+	enc.fourcc("strl")
+
+	enc.fourcc("strh")
+	enc.uint32(aviStreamHeaderLen)
+	enc.fourcc("vids")
+	enc.fourcc("DIB ")
+	enc.uint32(0)
+	enc.uint16(0)
+	enc.uint16(0)
+	enc.uint32(0)
+	enc.uint32(1000)
+	enc.uint32(rate)
+	enc.uint32(0)
+	enc.videoLength = enc.placeholder()
+	enc.uint32(uint32(enc.frameLen))
+	enc.uint32(math.MaxUint32)
+	enc.uint32(0)
+	enc.uint16(0)
+	enc.uint16(0)
+	enc.uint16(uint16(width))
+	enc.uint16(uint16(height))
+
+	enc.fourcc("strf")
+	enc.uint32(aviBitmapInfoLen)
+	enc.uint32(aviBitmapInfoLen)
+	enc.uint32(uint32(width))
+	enc.uint32(uint32(height))
+	enc.uint16(1)
+	enc.uint16(24)
+	enc.uint32(0)
+	enc.uint32(uint32(enc.frameLen))
+	enc.uint32(0)
+	enc.uint32(0)
+	enc.uint32(0)
+	enc.uint32(0)
+
+	// audio stream
+	enc.fourcc("LIST")
+	enc.uint32(aviAudioStrlLen)
+	enc.fourcc("strl")
+
+	enc.fourcc("strh")
+	enc.uint32(aviStreamHeaderLen)
+	enc.fourcc("auds")
+	enc.uint32(0)
+	enc.uint32(0)
+	enc.uint16(0)
+	enc.uint16(0)
+	enc.uint32(0)
+	enc.uint32(1)
+	enc.audioRate = enc.placeholder()
+	enc.uint32(0)
+	enc.audioLength = enc.placeholder()
+	enc.uint32(tiaAudio.SampleFreq)
+	enc.uint32(math.MaxUint32)
+	enc.uint32(1)
+	enc.uint16(0)
+	enc.uint16(0)
+	enc.uint16(0)
+	enc.uint16(0)
+
+	enc.fourcc("strf")
+	enc.uint32(aviWaveFormatLen)
+	enc.uint16(1)
+	enc.uint16(1)
+	enc.audioFreq = enc.placeholder()
+	enc.audioBytes = enc.placeholder()
+	enc.uint16(1)
+	enc.uint16(8)
+	enc.uint16(0)
+
+	// movi list. the list length is not known until the end of the recording
+	enc.fourcc("LIST")
+	enc.moviLen = enc.placeholder()
+	enc.movi = enc.pos
+	enc.fourcc("movi")
+
+	return nil
+}
+
+func (enc *aviEncoder) chunk(id string, data []uint8) error {
+	// leave room for the index and the padding byte
+	if enc.pos+int64(len(data))+int64(len(enc.index)+1)*16+32 > aviMaxLen {
+		return fmt.Errorf("avi file too large")
+	}
+
+	enc.index = append(enc.index, aviIndexEntry{
+		id:     id,
+		offset: uint32(enc.pos - enc.movi),
+		size:   uint32(len(data)),
+	})
+
+	enc.fourcc(id)
+	enc.uint32(uint32(len(data)))
+	enc.write(data)
+
+	// chunks are aligned to 16 bit boundaries
+	if len(data)&0x01 == 0x01 {
+		enc.write([]byte{0})
+	}
+
+	return nil
+}
+
+func (enc *aviEncoder) frame(rgb []uint8) error {
+	// AVI frames are stored bottom-up with pixels in BGR order
+	stride := enc.width * 3
+	for y := 0; y < enc.height; y++ {
+		src := rgb[y*stride : (y+1)*stride]
+		dst := enc.bgr[(enc.height-1-y)*stride:]
+		for x := 0; x < stride; x += 3 {
+			dst[x] = src[x+2]
+			dst[x+1] = src[x+1]
+			dst[x+2] = src[x]
+		}
+	}
+
+	err := enc.chunk("00db", enc.bgr)
+	if err != nil {
+		return err
+	}
+	enc.frames++
+
+	return nil
+}
+
+func (enc *aviEncoder) audio(samples []uint8) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	err := enc.chunk("01wb", samples)
+	if err != nil {
+		return err
+	}
+	enc.samples += uint32(len(samples))
+
+	return nil
+}
+
+func (enc *aviEncoder) end() (rerr error) {
+	defer func() {
+		err := enc.f.Close()
+		if err != nil && rerr == nil {
+			rerr = err
+		}
+	}()
+
+	// nothing to finish if recording never started
+	if enc.movi == 0 {
+		return nil
+	}
+
+	// length of the movi list includes the list type
+	moviLen := uint32(enc.pos - enc.movi)
+
+	// index
+	enc.fourcc("idx1")
+	enc.uint32(uint32(len(enc.index) * 16))
+	for _, e := range enc.index {
+		enc.fourcc(e.id)
+		enc.uint32(aviKeyFrame)
+		enc.uint32(e.offset)
+		enc.uint32(e.size)
+	}
+
+	err := enc.buf.Flush()
+	if err != nil {
+		return err
+	}
+
+	rate := audioRate(enc.samples, enc.frames, enc.fps)
+
+	// write the values that were unknown at the start of the recording
+	patches := []struct {
+		offset int64
+		v      uint32
+	}{
+		{offset: enc.riffLen, v: uint32(enc.pos - 8)},
+		{offset: enc.totalFrames, v: enc.frames},
+		{offset: enc.videoLength, v: enc.frames},
+		{offset: enc.audioLength, v: enc.samples},
+		{offset: enc.audioRate, v: rate},
+		{offset: enc.audioFreq, v: rate},
+		{offset: enc.audioBytes, v: rate},
+		{offset: enc.moviLen, v: moviLen},
+	}
+	for _, p := range patches {
+		err = enc.patch(p.offset, p.v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
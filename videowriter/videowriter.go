@@ -0,0 +1,280 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package videowriter allows the recording of the television image and
+// audio to disk. The format of the recording is decided by the extension of
+// the filename. Supported formats:
+//
+//	.avi    uncompressed RGB video with 8-bit PCM audio
+//	.y4m    YUV4MPEG2 video with audio in a separate WAV file
+//
+// In the case of the Y4M format, the WAV file has the same name as the video
+// file but with the .wav extension.
+//
+// Recording does not start until the television signal is stable. The size of
+// the video and the frame rate are decided at that point by the television
+// specification and the visible area of the screen. Frames recorded after a
+// change of specification or a change to the visible area are cropped or
+// padded to fit.
+//
+// Each pixel is doubled horizontally to give an aspect ratio closer to that
+// of the real television.
+//
+// The sample rate of the audio is decided when the recording ends. It is the
+// number of audio samples recorded for each frame multiplied by the frame
+// rate, which keeps the audio in sync with the video.
+package videowriter
+
+import (
+	"image"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/crtfilter"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/framebuffer"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	tiaAudio "github.com/jetsetilly/gopher2600/hardware/tia/audio"
+)
+
+// the number of times each pixel is repeated horizontally.
+const pixelWidth = 2
+
+// audioRate returns the sample rate that keeps the audio in sync with the
+// video. the TIA doesn't produce exactly tiaAudio.SampleFreq samples for every
+// second of video so the rate is decided by the number of samples recorded
+// for each frame.
+func audioRate(samples uint32, frames uint32, fps float32) uint32 {
+	if frames == 0 || samples == 0 {
+		return tiaAudio.SampleFreq
+	}
+	return uint32(math.Round(float64(samples) / float64(frames) * float64(fps)))
+}
+
+// encoder is implemented by the different file formats.
+type encoder interface {
+	// start is called once the width, height and frame rate are known
+	start(width int, height int, fps float32) error
+
+	// frame is called with RGB data for the entire frame. audio is called
+	// with the audio samples generated during that frame
+	frame(rgb []uint8) error
+	audio(samples []uint8) error
+
+	end() error
+}
+
+// VideoWriter implements the television.PixelRenderer and
+// television.AudioMixer interfaces.
+type VideoWriter struct {
+	enc     encoder
+	started bool
+	ended   bool
+
+	// the full television signal
+	buf framebuffer.Buffer
+
+	// dimensions of the video. fixed once recording has started
+	width  int
+	height int
+
+	// the visible area of the frame, one pixel per colour clock
+	frame *image.RGBA
+
+	// frame data in RGB order and the audio samples since the last frame
+	rgb     []uint8
+	samples []uint8
+
+	// software CRT filter. can be nil
+	filter *crtfilter.Filter
+}
+
+// New is the preferred method of initialisation for the VideoWriter type. The
+// VideoWriter will add itself to the television as a PixelRenderer and as an
+// AudioMixer.
+func New(tv *television.Television, filename string) (*VideoWriter, error) {
+	w := &VideoWriter{}
+
+	var err error
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".avi":
+		w.enc, err = newAVI(filename)
+	case ".y4m":
+		w.enc, err = newY4M(filename, strings.TrimSuffix(filename, filepath.Ext(filename))+".wav")
+	default:
+		return nil, curated.Errorf("videowriter: unsupported video format (%s)", filename)
+	}
+	if err != nil {
+		return nil, curated.Errorf("videowriter: %v", err)
+	}
+
+	spec := tv.GetSpec()
+	_ = w.Resize(spec, spec.AtariSafeTop, spec.AtariSafeBottom-spec.AtariSafeTop)
+
+	tv.AddPixelRenderer(w)
+	tv.AddAudioMixer(w)
+
+	return w, nil
+}
+
+// SetFilter sets the software CRT filter to apply to every frame. The filter
+// doubles the height of the video and so must be set before recording starts.
+// Setting the filter after recording has started has no effect.
+func (w *VideoWriter) SetFilter(filter *crtfilter.Filter) {
+	if w.started {
+		return
+	}
+	w.filter = filter
+}
+
+// Resize implements the television.PixelRenderer interface.
+func (w *VideoWriter) Resize(spec specification.Spec, topScanline, visibleScanlines int) error {
+	w.buf.Resize(spec, topScanline, visibleScanlines)
+	return nil
+}
+
+// NewFrame implements the television.PixelRenderer interface.
+func (w *VideoWriter) NewFrame(isStable bool) error {
+	if w.ended {
+		return nil
+	}
+
+	if !w.started {
+		// discard audio until the television is stable so that audio and
+		// video remain in sync
+		if !isStable {
+			w.samples = w.samples[:0]
+			return nil
+		}
+
+		w.frame = image.NewRGBA(image.Rect(0, 0, specification.HorizClksVisible, w.buf.Visible))
+		w.width = specification.HorizClksVisible * pixelWidth
+		w.height = w.buf.Visible
+		if w.filter != nil {
+			w.width = specification.HorizClksVisible * crtfilter.PixelWidth
+			w.height = w.buf.Visible * crtfilter.PixelHeight
+		}
+		w.rgb = make([]uint8, w.width*w.height*3)
+
+		err := w.enc.start(w.width, w.height, w.buf.Spec.FramesPerSecond)
+		if err != nil {
+			return curated.Errorf("videowriter: %v", err)
+		}
+		w.started = true
+	}
+
+	// the visible area may have changed since recording started. centre the
+	// visible area in the video frame, cropping or padding as required
+	frameBounds := w.frame.Bounds()
+	top := w.buf.Top + (w.buf.Visible-frameBounds.Dy())/2
+
+	for y := 0; y < frameBounds.Dy(); y++ {
+		for x := 0; x < frameBounds.Dx(); x++ {
+			w.frame.SetRGBA(x, y, w.buf.Color(x+specification.HorizClksHBlank, y+top))
+		}
+	}
+
+	if w.filter != nil {
+		out := w.filter.Apply(w.frame)
+		i := 0
+		for p := 0; p < len(out.Pix); p += 4 {
+			w.rgb[i] = out.Pix[p]
+			w.rgb[i+1] = out.Pix[p+1]
+			w.rgb[i+2] = out.Pix[p+2]
+			i += 3
+		}
+	} else {
+		i := 0
+		for y := 0; y < frameBounds.Dy(); y++ {
+			for x := 0; x < frameBounds.Dx(); x++ {
+				c := w.frame.RGBAAt(x, y)
+				for p := 0; p < pixelWidth; p++ {
+					w.rgb[i] = c.R
+					w.rgb[i+1] = c.G
+					w.rgb[i+2] = c.B
+					i += 3
+				}
+			}
+		}
+	}
+
+	err := w.enc.frame(w.rgb)
+	if err != nil {
+		return curated.Errorf("videowriter: %v", err)
+	}
+
+	err = w.enc.audio(w.samples)
+	if err != nil {
+		return curated.Errorf("videowriter: %v", err)
+	}
+	w.samples = w.samples[:0]
+
+	return nil
+}
+
+// NewScanline implements the television.PixelRenderer interface.
+func (w *VideoWriter) NewScanline(_ int) error {
+	return nil
+}
+
+// UpdatingPixels implements the television.PixelRenderer interface.
+func (w *VideoWriter) UpdatingPixels(_ bool) {
+}
+
+// SetPixel implements the television.PixelRenderer interface.
+func (w *VideoWriter) SetPixel(sig signal.SignalAttributes, _ bool) error {
+	w.buf.SetPixel(sig)
+	return nil
+}
+
+// Reset implements the television.PixelRenderer interface.
+func (w *VideoWriter) Reset() {
+	w.buf.Reset()
+}
+
+// EndRendering implements the television.PixelRenderer interface.
+func (w *VideoWriter) EndRendering() error {
+	if w.ended {
+		return nil
+	}
+	w.ended = true
+
+	err := w.enc.end()
+	if err != nil {
+		return curated.Errorf("videowriter: %v", err)
+	}
+
+	return nil
+}
+
+// SetAudio implements the television.AudioMixer interface.
+func (w *VideoWriter) SetAudio(sig signal.SignalAttributes) error {
+	if !w.ended {
+		w.samples = append(w.samples, sig.AudioData)
+	}
+	return nil
+}
+
+// EndMixing implements the television.AudioMixer interface.
+//
+// The recording is concluded by EndRendering(), which the television calls
+// before EndMixing(). Any audio after the last frame is discarded.
+func (w *VideoWriter) EndMixing() error {
+	return w.EndRendering()
+}
@@ -0,0 +1,387 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package videowriter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/crtfilter"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	tiaAudio "github.com/jetsetilly/gopher2600/hardware/tia/audio"
+)
+
+// testEncoder records the calls made to it by VideoWriter.
+type testEncoder struct {
+	width   int
+	height  int
+	frames  int
+	samples int
+	last    []uint8
+	ended   bool
+}
+
+func (enc *testEncoder) start(width int, height int, fps float32) error {
+	enc.width = width
+	enc.height = height
+	return nil
+}
+
+func (enc *testEncoder) frame(rgb []uint8) error {
+	enc.frames++
+	enc.last = append(enc.last[:0], rgb...)
+	return nil
+}
+
+func (enc *testEncoder) audio(samples []uint8) error {
+	enc.samples += len(samples)
+	return nil
+}
+
+func (enc *testEncoder) end() error {
+	enc.ended = true
+	return nil
+}
+
+func TestVideoWriter(t *testing.T) {
+	enc := &testEncoder{}
+	w := &VideoWriter{enc: enc}
+
+	spec := specification.SpecNTSC
+	_ = w.Resize(spec, spec.AtariSafeTop, spec.AtariSafeBottom-spec.AtariSafeTop)
+
+	// nothing is recorded until the television is stable
+	_ = w.SetAudio(signal.SignalAttributes{AudioData: 10})
+	_ = w.NewFrame(false)
+	if enc.frames != 0 || enc.width != 0 {
+		t.Fatalf("unexpected recording before television is stable")
+	}
+
+	// set a pixel in the top left of the visible screen
+	_ = w.SetPixel(signal.SignalAttributes{
+		Pixel:    0x0e,
+		HorizPos: specification.HorizClksHBlank,
+		Scanline: spec.AtariSafeTop,
+	}, true)
+	_ = w.SetAudio(signal.SignalAttributes{AudioData: 10})
+	_ = w.SetAudio(signal.SignalAttributes{AudioData: 10})
+	_ = w.NewFrame(true)
+
+	if enc.width != specification.HorizClksVisible*pixelWidth || enc.height != spec.AtariSafeBottom-spec.AtariSafeTop {
+		t.Errorf("unexpected video size (%dx%d)", enc.width, enc.height)
+	}
+	if enc.frames != 1 || enc.samples != 2 {
+		t.Errorf("unexpected number of frames or samples (%d, %d)", enc.frames, enc.samples)
+	}
+
+	// pixel should have been doubled
+	col := spec.GetColor(0x0e)
+	for p := 0; p < pixelWidth; p++ {
+		if enc.last[p*3] != col.R || enc.last[p*3+1] != col.G || enc.last[p*3+2] != col.B {
+			t.Errorf("unexpected pixel color in video frame")
+		}
+	}
+
+	// unstable frames after recording has started are still recorded
+	_ = w.NewFrame(false)
+	if enc.frames != 2 {
+		t.Errorf("unexpected number of frames (%d)", enc.frames)
+	}
+
+	// a change to the visible area does not change the video size
+	_ = w.Resize(spec, spec.AtariSafeTop-10, spec.AtariSafeBottom-spec.AtariSafeTop+20)
+	_ = w.NewFrame(true)
+	if len(enc.last) != enc.width*enc.height*3 {
+		t.Errorf("unexpected frame length after resize (%d)", len(enc.last))
+	}
+
+	_ = w.EndRendering()
+	_ = w.EndMixing()
+	if !enc.ended {
+		t.Errorf("expected encoder to have ended")
+	}
+
+	// no more audio after recording has ended
+	_ = w.SetAudio(signal.SignalAttributes{AudioData: 10})
+	if enc.samples != 2 {
+		t.Errorf("unexpected audio after end of recording")
+	}
+}
+
+func TestVideoWriterFilter(t *testing.T) {
+	enc := &testEncoder{}
+	w := &VideoWriter{enc: enc}
+
+	spec := specification.SpecNTSC
+	_ = w.Resize(spec, spec.AtariSafeTop, spec.AtariSafeBottom-spec.AtariSafeTop)
+
+	p := &crtfilter.Preferences{}
+	p.SetDefaults()
+	w.SetFilter(crtfilter.NewFilter(p))
+
+	_ = w.NewFrame(true)
+
+	// the filter changes the size of the video
+	if enc.width != specification.HorizClksVisible*crtfilter.PixelWidth ||
+		enc.height != (spec.AtariSafeBottom-spec.AtariSafeTop)*crtfilter.PixelHeight {
+		t.Errorf("unexpected video size (%dx%d)", enc.width, enc.height)
+	}
+	if len(enc.last) != enc.width*enc.height*3 {
+		t.Errorf("unexpected frame length (%d)", len(enc.last))
+	}
+
+	// the filter cannot be removed once recording has started
+	w.SetFilter(nil)
+	_ = w.NewFrame(true)
+	if len(enc.last) != enc.width*enc.height*3 {
+		t.Errorf("unexpected frame length after removing filter (%d)", len(enc.last))
+	}
+}
+
+func TestAVI(t *testing.T) {
+	dir, err := ioutil.TempDir("", "videowriter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fn := filepath.Join(dir, "test.avi")
+	enc, err := newAVI(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const width = 4
+	const height = 2
+
+	err = enc.start(width, height, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rgb := make([]uint8, width*height*3)
+	rgb[0] = 0xff
+	for i := 0; i < 3; i++ {
+		if err := enc.frame(rgb); err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.audio([]uint8{1, 2, 3}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = enc.end()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u32 := func(offset int) uint32 {
+		return binary.LittleEndian.Uint32(data[offset:])
+	}
+
+	if string(data[0:4]) != "RIFF" || string(data[8:12]) != "AVI " {
+		t.Fatalf("not an AVI file")
+	}
+	if int(u32(4)) != len(data)-8 {
+		t.Errorf("unexpected RIFF length (%d)", u32(4))
+	}
+	if string(data[12:16]) != "LIST" || u32(16) != aviHdrlLen {
+		t.Errorf("unexpected hdrl list")
+	}
+
+	// total frames in main header
+	if u32(48) != 3 {
+		t.Errorf("unexpected number of frames in main header (%d)", u32(48))
+	}
+
+	// movi list follows the hdrl list
+	movi := 20 + aviHdrlLen
+	if string(data[movi:movi+4]) != "LIST" || string(data[movi+8:movi+12]) != "movi" {
+		t.Fatalf("missing movi list")
+	}
+
+	// first chunk is a video frame. the red pixel in the top left of the
+	// frame should be in the bottom row of the AVI frame and in BGR order
+	chunk := movi + 12
+	if string(data[chunk:chunk+4]) != "00db" || int(u32(chunk+4)) != len(rgb) {
+		t.Fatalf("unexpected first chunk")
+	}
+	frame := data[chunk+8 : chunk+8+len(rgb)]
+	if frame[(height-1)*width*3+2] != 0xff {
+		t.Errorf("unexpected pixel order in AVI frame")
+	}
+
+	// index follows the movi list
+	idx := movi + 8 + int(u32(movi+4))
+	if string(data[idx:idx+4]) != "idx1" || u32(idx+4) != 6*16 {
+		t.Errorf("unexpected index")
+	}
+}
+
+func TestY4M(t *testing.T) {
+	dir, err := ioutil.TempDir("", "videowriter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fn := filepath.Join(dir, "test.y4m")
+	wfn := filepath.Join(dir, "test.wav")
+	enc, err := newY4M(fn, wfn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = enc.start(4, 2, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = enc.frame(make([]uint8, 4*2*3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = enc.audio([]uint8{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = enc.end()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := []byte("YUV4MPEG2 W4 H2 F50:1 Ip A1:1 C444\nFRAME\n")
+	if !bytes.HasPrefix(data, header) {
+		t.Errorf("unexpected Y4M header")
+	}
+	if len(data) != len(header)+4*2*3 {
+		t.Errorf("unexpected Y4M length (%d)", len(data))
+	}
+
+	if _, err := os.Stat(wfn); err != nil {
+		t.Errorf("expected WAV file: %v", err)
+	}
+}
+
+// the TIA doesn't generate tiaAudio.SampleFreq samples for every second of
+// video. the declared audio rate must be decided by the number of samples
+// generated for each frame or the audio will drift.
+func TestAudioRate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "videowriter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	const numFrames = 10
+
+	for _, spec := range []specification.Spec{specification.SpecNTSC, specification.SpecPAL} {
+		avi, err := newAVI(filepath.Join(dir, spec.ID+".avi"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		wfn := filepath.Join(dir, spec.ID+".wav")
+		y4m, err := newY4M(filepath.Join(dir, spec.ID+".y4m"), wfn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encs := []encoder{avi, y4m}
+		for _, enc := range encs {
+			if err := enc.start(4, 2, spec.FramesPerSecond); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// generate audio for every colour clock in the frame
+		au := tiaAudio.NewAudio()
+		rgb := make([]uint8, 4*2*3)
+		numSamples := 0
+		for f := 0; f < numFrames; f++ {
+			var samples []uint8
+			for c := 0; c < spec.ScanlinesTotal*specification.HorizClksScanline; c++ {
+				if ok, v, _, _ := au.Mix(); ok {
+					samples = append(samples, v)
+				}
+			}
+			numSamples += len(samples)
+
+			for _, enc := range encs {
+				if err := enc.frame(rgb); err != nil {
+					t.Fatal(err)
+				}
+				if err := enc.audio(samples); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		for _, enc := range encs {
+			if err := enc.end(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		expected := uint32(math.Round(float64(numSamples) / numFrames * float64(spec.FramesPerSecond)))
+		if expected == tiaAudio.SampleFreq {
+			t.Fatalf("%s: test audio is generated at the nominal sample rate", spec.ID)
+		}
+
+		// the audio rate in the AVI stream header and the wave format
+		data, err := ioutil.ReadFile(filepath.Join(dir, spec.ID+".avi"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		auds := bytes.Index(data, []byte("auds"))
+		if auds < 0 {
+			t.Fatalf("%s: missing audio stream header", spec.ID)
+		}
+		if rate := binary.LittleEndian.Uint32(data[auds+24:]); rate != expected {
+			t.Errorf("%s: unexpected audio rate in AVI stream header (%d, expected %d)", spec.ID, rate, expected)
+		}
+		strf := auds + bytes.Index(data[auds:], []byte("strf"))
+		if rate := binary.LittleEndian.Uint32(data[strf+12:]); rate != expected {
+			t.Errorf("%s: unexpected audio rate in AVI wave format (%d, expected %d)", spec.ID, rate, expected)
+		}
+
+		// the sample rate in the WAV file accompanying the Y4M file
+		data, err = ioutil.ReadFile(wfn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data[12:16]) != "fmt " {
+			t.Fatalf("%s: unexpected WAV header", spec.ID)
+		}
+		if rate := binary.LittleEndian.Uint32(data[24:]); rate != expected {
+			t.Errorf("%s: unexpected sample rate in WAV file (%d, expected %d)", spec.ID, rate, expected)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package videowriter
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/wavwriter"
+)
+
+// y4mEncoder writes a YUV4MPEG2 file with no chroma subsampling. the audio
+// is written to a separate WAV file.
+type y4mEncoder struct {
+	f   *os.File
+	buf *bufio.Writer
+	wav *wavwriter.WavWriter
+
+	// the Y, Cb and Cr planes of the most recent frame
+	planes []uint8
+
+	// frame rate and the number of frames and samples written. used to decide
+	// the sample rate of the WAV file
+	fps     float32
+	frames  uint32
+	samples uint32
+}
+
+func newY4M(filename string, wavFilename string) (*y4mEncoder, error) {
+	wav, err := wavwriter.New(wavFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &y4mEncoder{
+		f:   f,
+		buf: bufio.NewWriter(f),
+		wav: wav,
+	}, nil
+}
+
+func (enc *y4mEncoder) start(width int, height int, fps float32) error {
+	enc.planes = make([]uint8, width*height*3)
+	enc.fps = fps
+
+	// frame rate is expressed as a ratio
+	num := int(math.Round(float64(fps) * 1000))
+	den := 1000
+	g := gcd(num, den)
+
+	_, err := fmt.Fprintf(enc.buf, "YUV4MPEG2 W%d H%d F%d:%d Ip A1:1 C444\n", width, height, num/g, den/g)
+	return err
+}
+
+func gcd(a int, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func (enc *y4mEncoder) frame(rgb []uint8) error {
+	n := len(rgb) / 3
+	for i := 0; i < n; i++ {
+		y, cb, cr := color.RGBToYCbCr(rgb[i*3], rgb[i*3+1], rgb[i*3+2])
+		enc.planes[i] = y
+		enc.planes[n+i] = cb
+		enc.planes[n*2+i] = cr
+	}
+
+	_, err := enc.buf.WriteString("FRAME\n")
+	if err != nil {
+		return err
+	}
+	_, err = enc.buf.Write(enc.planes)
+	if err != nil {
+		return err
+	}
+	enc.frames++
+
+	return nil
+}
+
+func (enc *y4mEncoder) audio(samples []uint8) error {
+	for _, s := range samples {
+		err := enc.wav.SetAudio(signal.SignalAttributes{AudioData: s})
+		if err != nil {
+			return err
+		}
+	}
+	enc.samples += uint32(len(samples))
+	return nil
+}
+
+func (enc *y4mEncoder) end() error {
+	err := enc.buf.Flush()
+	if err != nil {
+		_ = enc.f.Close()
+		return err
+	}
+
+	err = enc.f.Close()
+	if err != nil {
+		return err
+	}
+
+	enc.wav.SetSampleRate(int(audioRate(enc.samples, enc.frames, enc.fps)))
+
+	return enc.wav.EndMixing()
+}
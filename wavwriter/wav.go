@@ -20,7 +20,9 @@ import (
 	"os"
 
 	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
 	tiaAudio "github.com/jetsetilly/gopher2600/hardware/tia/audio"
+	"github.com/jetsetilly/gopher2600/resampler"
 
 	"github.com/go-audio/audio"
 	"github.com/go-audio/wav"
@@ -30,6 +32,20 @@ import (
 type WavWriter struct {
 	filename string
 	buffer   []int8
+
+	// stereo wav files have the left and right samples interleaved in the
+	// buffer. see tiaAudio.Stereo() for the meaning of separation
+	stereo     bool
+	separation float32
+
+	// by default the resampler passes samples through unchanged. see the
+	// Resample() function
+	resampler *resampler.Resampler
+	in        []float32
+
+	// the sample rate written to the WAV file if it is not zero. see
+	// SetSampleRate() function
+	rate int
 }
 
 // New is the preferred method of initialisation for the Audio2Wav type.
@@ -39,16 +55,70 @@ func New(filename string) (*WavWriter, error) {
 		buffer:   make([]int8, 0),
 	}
 
+	aw.Resample(tiaAudio.SampleFreq, false)
+
 	return aw, nil
 }
 
-// SetAudio implements the television.AudioMixer interface.
-func (aw *WavWriter) SetAudio(audioData uint8) error {
-	// bring audioData into the correct range
-	aw.buffer = append(aw.buffer, int8(int16(audioData)-127))
+// NewStereo creates a WavWriter that writes a stereo WAV file. The separation
+// argument is the same as for the tiaAudio.Stereo() function. A value of 1.0
+// puts channel 0 in the left speaker and channel 1 in the right speaker.
+func NewStereo(filename string, separation float32) (*WavWriter, error) {
+	aw, err := New(filename)
+	if err != nil {
+		return nil, err
+	}
+	aw.stereo = true
+	aw.separation = separation
+	aw.Resample(tiaAudio.SampleFreq, false)
+	return aw, nil
+}
+
+// Resample changes the sample rate of the WAV file and whether the low-pass
+// model of the VCS output stage is used. See the resampler package for
+// details. Should be called before any audio has been added.
+func (aw *WavWriter) Resample(rate int, lowPass bool) {
+	aw.resampler = resampler.New(aw.numChannels(), tiaAudio.SampleFreq, rate, lowPass)
+	aw.in = make([]float32, aw.numChannels())
+}
+
+// SetSampleRate changes the sample rate written to the WAV file without
+// resampling the audio. Useful when the audio must stay in sync with video
+// recorded at a frame rate that doesn't match tiaAudio.SampleFreq exactly. A
+// value of zero restores the output rate of the resampler.
+func (aw *WavWriter) SetSampleRate(rate int) {
+	aw.rate = rate
+}
+
+func (aw *WavWriter) numChannels() int {
+	if aw.stereo {
+		return 2
+	}
+	return 1
+}
+
+// emit is called by the resampler for every output sample.
+func (aw *WavWriter) emit(out []float32) error {
+	// bring audio data into the correct range
+	for _, v := range out {
+		aw.buffer = append(aw.buffer, int8(int16(resampler.ToUint8(v))-127))
+	}
 	return nil
 }
 
+// SetAudio implements the television.AudioMixer interface.
+func (aw *WavWriter) SetAudio(sig signal.SignalAttributes) error {
+	if aw.stereo {
+		left, right := tiaAudio.Stereo(sig.AudioChannel0, sig.AudioChannel1, aw.separation)
+		aw.in[0] = float32(left)
+		aw.in[1] = float32(right)
+	} else {
+		aw.in[0] = float32(sig.AudioData)
+	}
+
+	return aw.resampler.Write(aw.in, aw.emit)
+}
+
 // EndMixing implements the television.AudioMixer interface.
 func (aw *WavWriter) EndMixing() (rerr error) {
 	f, err := os.Create(aw.filename)
@@ -64,7 +134,13 @@ func (aw *WavWriter) EndMixing() (rerr error) {
 
 	// see audio commentary in sdlplay package for thinking around sample rates
 
-	enc := wav.NewEncoder(f, tiaAudio.SampleFreq, 8, 1, 1)
+	numChannels := aw.numChannels()
+	sampleRate := aw.resampler.OutRate()
+	if aw.rate != 0 {
+		sampleRate = aw.rate
+	}
+
+	enc := wav.NewEncoder(f, sampleRate, 8, numChannels, 1)
 	if enc == nil {
 		return curated.Errorf("wavwriter: %v", "bad parameters for wav encoding")
 	}
@@ -77,8 +153,8 @@ func (aw *WavWriter) EndMixing() (rerr error) {
 
 	buf := audio.PCMBuffer{
 		Format: &audio.Format{
-			NumChannels: 1,
-			SampleRate:  tiaAudio.SampleFreq,
+			NumChannels: numChannels,
+			SampleRate:  sampleRate,
 		},
 		I8:             aw.buffer,
 		DataType:       audio.DataTypeI8,
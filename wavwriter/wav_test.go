@@ -0,0 +1,121 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package wavwriter_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/wavwriter"
+
+	"github.com/go-audio/wav"
+)
+
+// audio signal with the specified channel volumes. the AudioData field is the
+// mono mix of the channels in the same way as the TIA.
+func sig(ch0 uint8, ch1 uint8) signal.SignalAttributes {
+	return signal.SignalAttributes{
+		AudioData:     ch0 + ch1,
+		AudioChannel0: ch0,
+		AudioChannel1: ch1,
+		AudioUpdate:   true,
+	}
+}
+
+// write the signals to a WAV file and return the number of channels and the
+// samples in the file.
+func write(t *testing.T, aw *wavwriter.WavWriter, filename string, sigs ...signal.SignalAttributes) (int, []int) {
+	t.Helper()
+
+	for _, s := range sigs {
+		err := aw.SetAudio(s)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	err := aw.EndMixing()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	dec := wav.NewDecoder(f)
+	buf, err := dec.FullPCMBuffer()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return int(dec.NumChans), buf.Data
+}
+
+func TestMono(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "mono.wav")
+	aw, err := wavwriter.New(filename)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	chans, data := write(t, aw, filename, sig(60, 0), sig(0, 60), sig(20, 20))
+	if chans != 1 {
+		t.Errorf("unexpected number of channels (%d)", chans)
+	}
+	if len(data) != 3 {
+		t.Fatalf("unexpected number of samples (%d)", len(data))
+	}
+	if data[0] != data[1] || data[0] <= data[2] {
+		t.Errorf("unexpected mono samples (%v)", data)
+	}
+}
+
+func TestStereo(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stereo.wav")
+	aw, err := wavwriter.NewStereo(filename, 1.0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	chans, data := write(t, aw, filename, sig(60, 0), sig(0, 60), sig(0, 0))
+	if chans != 2 {
+		t.Errorf("unexpected number of channels (%d)", chans)
+	}
+
+	// left and right samples are interleaved. with full separation channel 0
+	// is only in the left speaker and channel 1 is only in the right speaker
+	if len(data) != 6 {
+		t.Fatalf("unexpected number of samples (%d)", len(data))
+	}
+	silence := data[4]
+	if data[5] != silence {
+		t.Errorf("unexpected silence (%v)", data)
+	}
+	if data[0] <= silence || data[1] != silence {
+		t.Errorf("channel 0 is not only in the left speaker (%v)", data)
+	}
+	if data[2] != silence || data[3] <= silence {
+		t.Errorf("channel 1 is not only in the right speaker (%v)", data)
+	}
+	if data[0] != data[3] {
+		t.Errorf("channels are not equally loud (%v)", data)
+	}
+}